@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.19.6
+// source: api/proto/collector.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EvidenceCollector_Collect_FullMethodName   = "/protocols.EvidenceCollector/Collect"
+	EvidenceCollector_Configure_FullMethodName = "/protocols.EvidenceCollector/Configure"
+)
+
+// EvidenceCollectorClient is the client API for EvidenceCollector service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// collect raw evidence for evaluation by PVPs
+type EvidenceCollectorClient interface {
+	Collect(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*CollectResponse, error)
+	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error)
+}
+
+type evidenceCollectorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEvidenceCollectorClient(cc grpc.ClientConnInterface) EvidenceCollectorClient {
+	return &evidenceCollectorClient{cc}
+}
+
+func (c *evidenceCollectorClient) Collect(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*CollectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectResponse)
+	err := c.cc.Invoke(ctx, EvidenceCollector_Collect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *evidenceCollectorClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigureResponse)
+	err := c.cc.Invoke(ctx, EvidenceCollector_Configure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EvidenceCollectorServer is the server API for EvidenceCollector service.
+// All implementations must embed UnimplementedEvidenceCollectorServer
+// for forward compatibility.
+//
+// collect raw evidence for evaluation by PVPs
+type EvidenceCollectorServer interface {
+	Collect(context.Context, *PolicyRequest) (*CollectResponse, error)
+	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
+	mustEmbedUnimplementedEvidenceCollectorServer()
+}
+
+// UnimplementedEvidenceCollectorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEvidenceCollectorServer struct{}
+
+func (UnimplementedEvidenceCollectorServer) Collect(context.Context, *PolicyRequest) (*CollectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Collect not implemented")
+}
+func (UnimplementedEvidenceCollectorServer) Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (UnimplementedEvidenceCollectorServer) mustEmbedUnimplementedEvidenceCollectorServer() {}
+func (UnimplementedEvidenceCollectorServer) testEmbeddedByValue()                           {}
+
+// UnsafeEvidenceCollectorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EvidenceCollectorServer will
+// result in compilation errors.
+type UnsafeEvidenceCollectorServer interface {
+	mustEmbedUnimplementedEvidenceCollectorServer()
+}
+
+func RegisterEvidenceCollectorServer(s grpc.ServiceRegistrar, srv EvidenceCollectorServer) {
+	// If the following call pancis, it indicates UnimplementedEvidenceCollectorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EvidenceCollector_ServiceDesc, srv)
+}
+
+func _EvidenceCollector_Collect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvidenceCollectorServer).Collect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EvidenceCollector_Collect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvidenceCollectorServer).Collect(ctx, req.(*PolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EvidenceCollector_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvidenceCollectorServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EvidenceCollector_Configure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvidenceCollectorServer).Configure(ctx, req.(*ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EvidenceCollector_ServiceDesc is the grpc.ServiceDesc for EvidenceCollector service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EvidenceCollector_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocols.EvidenceCollector",
+	HandlerType: (*EvidenceCollectorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Collect",
+			Handler:    _EvidenceCollector_Collect_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _EvidenceCollector_Configure_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/collector.proto",
+}
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	PolicyEngine_Generate_FullMethodName   = "/protocols.PolicyEngine/Generate"
 	PolicyEngine_GetResults_FullMethodName = "/protocols.PolicyEngine/GetResults"
+	PolicyEngine_Evaluate_FullMethodName   = "/protocols.PolicyEngine/Evaluate"
 	PolicyEngine_Configure_FullMethodName  = "/protocols.PolicyEngine/Configure"
 )
 
@@ -32,6 +33,7 @@ const (
 type PolicyEngineClient interface {
 	Generate(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
 	GetResults(ctx context.Context, in *PolicyRequest, opts ...grpc.CallOption) (*ResultsResponse, error)
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*ResultsResponse, error)
 	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error)
 }
 
@@ -63,6 +65,16 @@ func (c *policyEngineClient) GetResults(ctx context.Context, in *PolicyRequest,
 	return out, nil
 }
 
+func (c *policyEngineClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*ResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResultsResponse)
+	err := c.cc.Invoke(ctx, PolicyEngine_Evaluate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *policyEngineClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ConfigureResponse)
@@ -81,6 +93,7 @@ func (c *policyEngineClient) Configure(ctx context.Context, in *ConfigureRequest
 type PolicyEngineServer interface {
 	Generate(context.Context, *PolicyRequest) (*GenerateResponse, error)
 	GetResults(context.Context, *PolicyRequest) (*ResultsResponse, error)
+	Evaluate(context.Context, *EvaluateRequest) (*ResultsResponse, error)
 	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
 	mustEmbedUnimplementedPolicyEngineServer()
 }
@@ -98,6 +111,9 @@ func (UnimplementedPolicyEngineServer) Generate(context.Context, *PolicyRequest)
 func (UnimplementedPolicyEngineServer) GetResults(context.Context, *PolicyRequest) (*ResultsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetResults not implemented")
 }
+func (UnimplementedPolicyEngineServer) Evaluate(context.Context, *EvaluateRequest) (*ResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
 func (UnimplementedPolicyEngineServer) Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
@@ -158,6 +174,24 @@ func _PolicyEngine_GetResults_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PolicyEngine_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyEngineServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyEngine_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyEngineServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PolicyEngine_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -191,6 +225,10 @@ var PolicyEngine_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetResults",
 			Handler:    _PolicyEngine_GetResults_Handler,
 		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _PolicyEngine_Evaluate_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _PolicyEngine_Configure_Handler,
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.19.6
+// source: api/proto/remediation.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RemediationEngine_Remediate_FullMethodName = "/protocols.RemediationEngine/Remediate"
+	RemediationEngine_Configure_FullMethodName = "/protocols.RemediationEngine/Configure"
+)
+
+// RemediationEngineClient is the client API for RemediationEngine service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// propose fixes for failed PVP results
+type RemediationEngineClient interface {
+	Remediate(ctx context.Context, in *RemediateRequest, opts ...grpc.CallOption) (*RemediateResponse, error)
+	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error)
+}
+
+type remediationEngineClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRemediationEngineClient(cc grpc.ClientConnInterface) RemediationEngineClient {
+	return &remediationEngineClient{cc}
+}
+
+func (c *remediationEngineClient) Remediate(ctx context.Context, in *RemediateRequest, opts ...grpc.CallOption) (*RemediateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemediateResponse)
+	err := c.cc.Invoke(ctx, RemediationEngine_Remediate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remediationEngineClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigureResponse)
+	err := c.cc.Invoke(ctx, RemediationEngine_Configure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RemediationEngineServer is the server API for RemediationEngine service.
+// All implementations must embed UnimplementedRemediationEngineServer
+// for forward compatibility.
+//
+// propose fixes for failed PVP results
+type RemediationEngineServer interface {
+	Remediate(context.Context, *RemediateRequest) (*RemediateResponse, error)
+	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
+	mustEmbedUnimplementedRemediationEngineServer()
+}
+
+// UnimplementedRemediationEngineServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRemediationEngineServer struct{}
+
+func (UnimplementedRemediationEngineServer) Remediate(context.Context, *RemediateRequest) (*RemediateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remediate not implemented")
+}
+func (UnimplementedRemediationEngineServer) Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (UnimplementedRemediationEngineServer) mustEmbedUnimplementedRemediationEngineServer() {}
+func (UnimplementedRemediationEngineServer) testEmbeddedByValue()                           {}
+
+// UnsafeRemediationEngineServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RemediationEngineServer will
+// result in compilation errors.
+type UnsafeRemediationEngineServer interface {
+	mustEmbedUnimplementedRemediationEngineServer()
+}
+
+func RegisterRemediationEngineServer(s grpc.ServiceRegistrar, srv RemediationEngineServer) {
+	// If the following call pancis, it indicates UnimplementedRemediationEngineServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RemediationEngine_ServiceDesc, srv)
+}
+
+func _RemediationEngine_Remediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemediateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemediationEngineServer).Remediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemediationEngine_Remediate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemediationEngineServer).Remediate(ctx, req.(*RemediateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemediationEngine_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemediationEngineServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RemediationEngine_Configure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemediationEngineServer).Configure(ctx, req.(*ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RemediationEngine_ServiceDesc is the grpc.ServiceDesc for RemediationEngine service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RemediationEngine_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protocols.RemediationEngine",
+	HandlerType: (*RemediationEngineServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Remediate",
+			Handler:    _RemediationEngine_Remediate_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _RemediationEngine_Configure_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/remediation.proto",
+}
@@ -46,6 +46,8 @@ func New() *cobra.Command {
 		subcommands.NewOSCAL2Posture(logger),
 		subcommands.NewOSCAL2Policy(logger),
 		subcommands.NewResult2OSCAL(logger),
+		subcommands.NewRemediate(logger),
+		subcommands.NewPlugin(logger),
 	)
 	command.PersistentFlags().BoolVar(&debug, "debug", false, "Run with debug log level")
 
@@ -14,20 +14,85 @@ import (
 	"github.com/oscal-compass/oscal-sdk-go/validation"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/config"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 )
 
 // Config returns a populated C2PConfig for the CLI to use.
 func Config(option *Options) (*config.C2PConfig, error) {
 	c2pConfig := config.DefaultConfig()
 	componentPath := option.Definition
-	pluginsPath := option.PluginDir
-	if pluginsPath != "" {
-		c2pConfig.PluginDir = pluginsPath
+	switch {
+	case len(option.PluginPath) > 0:
+		c2pConfig.PluginSearchPath = option.PluginPath
+	case option.pluginDirSet && option.PluginDir != "":
+		// An explicit plugin directory is the only location searched
+		c2pConfig.PluginDir = option.PluginDir
+	default:
+		c2pConfig.PluginSearchPath = config.DefaultPluginSearchPath()
 	}
 
 	// Set logger
 	c2pConfig.Logger = option.logger
 
+	c2pConfig.SignaturePolicy = plugin.SignaturePolicy(option.SignaturePolicy)
+	trustedKeys, err := plugin.LoadTrustedKeys(option.TrustedKeys...)
+	if err != nil {
+		return nil, err
+	}
+	c2pConfig.TrustedKeys = trustedKeys
+
+	if option.Sandbox != nil {
+		c2pConfig.Sandbox = &plugin.Sandbox{
+			Environment: option.Sandbox.Environment,
+			WorkDir:     option.Sandbox.WorkDir,
+			Limits: plugin.ResourceLimits{
+				CPUSeconds:  option.Sandbox.Limits.CPUSeconds,
+				MemoryBytes: option.Sandbox.Limits.MemoryBytes,
+				OpenFiles:   option.Sandbox.Limits.OpenFiles,
+			},
+		}
+	}
+
+	c2pConfig.EvidenceDir = option.EvidenceDir
+	for _, processorOption := range option.ResultProcessors {
+		c2pConfig.ResultProcessors = append(c2pConfig.ResultProcessors, config.ResultProcessor{
+			Name:    processorOption.Name,
+			Plugin:  processorOption.Plugin,
+			Options: processorOption.Options,
+		})
+	}
+
+	if option.PluginLogs != nil {
+		c2pConfig.PluginLogs = &plugin.LogCapture{
+			Dir:         option.PluginLogs.Dir,
+			MaxFileSize: option.PluginLogs.MaxFileSize,
+			MaxFiles:    option.PluginLogs.MaxFiles,
+			BufferLines: option.PluginLogs.BufferLines,
+			TailLines:   option.PluginLogs.TailLines,
+		}
+	}
+
+	if len(option.RemotePlugins) > 0 {
+		c2pConfig.RemotePlugins = make(map[string]plugin.Remote, len(option.RemotePlugins))
+		for id, remoteOption := range option.RemotePlugins {
+			remote := plugin.Remote{
+				Address:    remoteOption.Address,
+				CAFile:     remoteOption.CAFile,
+				CertFile:   remoteOption.CertFile,
+				KeyFile:    remoteOption.KeyFile,
+				ServerName: remoteOption.ServerName,
+			}
+			if remoteOption.Reattach != nil {
+				remote.Reattach = &plugin.Reattach{
+					Network: remoteOption.Reattach.Network,
+					Address: remoteOption.Reattach.Address,
+					Pid:     remoteOption.Reattach.Pid,
+				}
+			}
+			c2pConfig.RemotePlugins[id] = remote
+		}
+	}
+
 	compDef, err := loadCompDef(componentPath)
 	if err != nil {
 		return nil, err
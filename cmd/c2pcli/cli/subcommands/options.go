@@ -31,6 +31,10 @@ const (
 	ComponentDefinition = "component-definition"
 	Name                = "name"
 	Catalog             = "catalog"
+	PluginDir           = "plugin-dir"
+	PluginPath          = "plugin-path"
+	SignaturePolicy     = "signature-policy"
+	TrustedKeys         = "trusted-keys"
 )
 
 // BindCommonFlags binds common flags for all commands.
@@ -42,8 +46,11 @@ func BindCommonFlags(fs *pflag.FlagSet) {
 // BindPluginFlags binds flags for command that interact with the plugin manager.
 func BindPluginFlags(fs *pflag.FlagSet) {
 	BindCommonFlags(fs)
-	fs.StringP("plugin-dir", "p", "c2p-plugins", "Path to plugin directory. Defaults to `c2p-plugins`.")
+	fs.StringP(PluginDir, "p", "c2p-plugins", "Path to plugin directory. Defaults to `c2p-plugins`.")
+	fs.StringSlice(PluginPath, nil, "Plugin directories to search in order of precedence. Defaults to $C2P_PLUGIN_PATH, c2p-plugins, the XDG data directory and the system directory.")
 	fs.StringP(Name, "n", "", "short name of the control source for the implementation to be evaluated.")
+	fs.String(SignaturePolicy, "", "Enforcement of plugin manifest signatures, one of off, warn, require-signed. Defaults to `off`.")
+	fs.StringSlice(TrustedKeys, nil, "Paths to PEM encoded ed25519 public keys plugin manifest signatures are verified against.")
 }
 
 // ConfigError is an error for missing configuration options
@@ -57,14 +64,76 @@ func (c *ConfigError) Error() string {
 
 // Options define config options when for the CLI commands.
 type Options struct {
-	PluginDir         string                       `yaml:"plugin-dir" mapstructure:"plugin-dir"`
-	Name              string                       `yaml:"name" mapstructure:"name"`
-	Definition        string                       `yaml:"component-definition" mapstructure:"component-definition"`
-	Catalog           string                       `yaml:"catalog" mapstructure:"catalog"`
-	AssessmentResults string                       `yaml:"assessment-results" mapstructure:"assessment-results"`
-	Plugins           map[string]map[string]string `yaml:"plugins" mapstructure:"plugins"`
-	Output            string                       `yaml:"out" mapstructure:"out"`
-	logger            hclog.Logger
+	PluginDir          string                       `yaml:"plugin-dir" mapstructure:"plugin-dir"`
+	PluginPath         []string                     `yaml:"plugin-path" mapstructure:"plugin-path"`
+	Name               string                       `yaml:"name" mapstructure:"name"`
+	Definition         string                       `yaml:"component-definition" mapstructure:"component-definition"`
+	Catalog            string                       `yaml:"catalog" mapstructure:"catalog"`
+	AssessmentResults  string                       `yaml:"assessment-results" mapstructure:"assessment-results"`
+	Plugins            map[string]map[string]string `yaml:"plugins" mapstructure:"plugins"`
+	Output             string                       `yaml:"out" mapstructure:"out"`
+	SignaturePolicy    string                       `yaml:"signature-policy" mapstructure:"signature-policy"`
+	TrustedKeys        []string                     `yaml:"trusted-keys" mapstructure:"trusted-keys"`
+	Sandbox            *SandboxOptions              `yaml:"sandbox" mapstructure:"sandbox"`
+	RemotePlugins      map[string]RemoteOptions     `yaml:"remote-plugins" mapstructure:"remote-plugins"`
+	PluginLogs         *PluginLogsOptions           `yaml:"plugin-logs" mapstructure:"plugin-logs"`
+	RemediationPlugins []string                     `yaml:"remediation-plugins" mapstructure:"remediation-plugins"`
+	CollectorPlugins   []string                     `yaml:"collector-plugins" mapstructure:"collector-plugins"`
+	EvidenceDir        string                       `yaml:"evidence-dir" mapstructure:"evidence-dir"`
+	ResultProcessors   []ResultProcessorOptions     `yaml:"result-processors" mapstructure:"result-processors"`
+	Waivers            string                       `yaml:"waivers" mapstructure:"waivers"`
+	logger             hclog.Logger
+	// pluginDirSet is whether the plugin directory was given with a flag or in the config,
+	// even when it is the default plugin directory.
+	pluginDirSet bool
+}
+
+// SandboxOptions define the sandbox the plugins are launched in.
+type SandboxOptions struct {
+	Environment []string      `yaml:"environment" mapstructure:"environment"`
+	WorkDir     string        `yaml:"work-dir" mapstructure:"work-dir"`
+	Limits      LimitsOptions `yaml:"limits" mapstructure:"limits"`
+}
+
+// LimitsOptions define the resource limits of sandboxed plugins.
+type LimitsOptions struct {
+	CPUSeconds  uint64 `yaml:"cpu-seconds" mapstructure:"cpu-seconds"`
+	MemoryBytes uint64 `yaml:"memory-bytes" mapstructure:"memory-bytes"`
+	OpenFiles   uint64 `yaml:"open-files" mapstructure:"open-files"`
+}
+
+// RemoteOptions define the endpoint of a remote plugin.
+type RemoteOptions struct {
+	Address    string           `yaml:"address" mapstructure:"address"`
+	CAFile     string           `yaml:"ca-file" mapstructure:"ca-file"`
+	CertFile   string           `yaml:"cert-file" mapstructure:"cert-file"`
+	KeyFile    string           `yaml:"key-file" mapstructure:"key-file"`
+	ServerName string           `yaml:"server-name" mapstructure:"server-name"`
+	Reattach   *ReattachOptions `yaml:"reattach" mapstructure:"reattach"`
+}
+
+// ReattachOptions define the reattach configuration printed
+// by a plugin served with `serve --reattach`.
+type ReattachOptions struct {
+	Network string `yaml:"network" mapstructure:"network"`
+	Address string `yaml:"address" mapstructure:"address"`
+	Pid     int    `yaml:"pid" mapstructure:"pid"`
+}
+
+// PluginLogsOptions define how the plugin log streams are captured.
+type PluginLogsOptions struct {
+	Dir         string `yaml:"dir" mapstructure:"dir"`
+	MaxFileSize int64  `yaml:"max-file-size" mapstructure:"max-file-size"`
+	MaxFiles    int    `yaml:"max-files" mapstructure:"max-files"`
+	BufferLines int    `yaml:"buffer-lines" mapstructure:"buffer-lines"`
+	TailLines   int    `yaml:"tail-lines" mapstructure:"tail-lines"`
+}
+
+// ResultProcessorOptions define a step of the result processor pipeline.
+type ResultProcessorOptions struct {
+	Name    string            `yaml:"name" mapstructure:"name"`
+	Plugin  string            `yaml:"plugin" mapstructure:"plugin"`
+	Options map[string]string `yaml:"options" mapstructure:"options"`
 }
 
 // NewOptions returns an initialized Options struct.
@@ -89,7 +158,13 @@ func (o *Options) Complete(cmd *cobra.Command) error {
 		if err := viper.ReadInConfig(); err != nil {
 			return err
 		}
-		return viper.Unmarshal(o)
+		if err := viper.Unmarshal(o); err != nil {
+			return err
+		}
+	}
+	if viper.IsSet(PluginDir) {
+		o.PluginDir = viper.GetString(PluginDir)
+		o.pluginDirSet = true
 	}
 	return nil
 }
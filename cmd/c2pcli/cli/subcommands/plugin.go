@@ -0,0 +1,414 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcommands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/config"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+)
+
+const pluginDirFlag = "plugin-dir"
+
+// NewPlugin returns the command to manage the plugins installed in the plugin directory.
+func NewPlugin(logger hclog.Logger) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage installed plugins.",
+	}
+	command.AddCommand(
+		newPluginList(),
+		newPluginInspect(),
+		newPluginInstall(logger),
+		newPluginVerify(logger),
+		newPluginSign(logger),
+		newPluginUninstall(logger),
+	)
+	return command
+}
+
+func bindPluginDirFlag(fs *pflag.FlagSet, pluginDir *string) {
+	fs.StringVarP(pluginDir, pluginDirFlag, "p", "", "Path to plugin directory. Required.")
+}
+
+func bindPluginSearchFlag(fs *pflag.FlagSet, pluginDir *string) {
+	fs.StringVarP(pluginDir, pluginDirFlag, "p", "", "Path to plugin directory. Defaults to the plugin search path.")
+}
+
+// pluginSearchPath returns the plugin directory if it is set and the default
+// plugin search path otherwise.
+func pluginSearchPath(pluginDir string) []string {
+	if pluginDir != "" {
+		return []string{pluginDir}
+	}
+	return config.DefaultPluginSearchPath()
+}
+
+// findPluginDir returns the directory of the search path that holds the manifest
+// of the plugin that is launched with the given ID.
+func findPluginDir(searchPath []string, pluginID string) (string, error) {
+	manifests, err := plugin.FindPluginsInPath(searchPath, plugin.WithProviderIds([]string{pluginID}))
+	if err != nil {
+		return "", err
+	}
+	manifestPath := manifests[pluginID].ManifestPath()
+	for _, dir := range searchPath {
+		rel, err := filepath.Rel(dir, manifestPath)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("manifest %s of plugin %s is not in the plugin search path", manifestPath, pluginID)
+}
+
+func newPluginList() *cobra.Command {
+	var pluginDir string
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List the installed plugins.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			searchPath := pluginSearchPath(pluginDir)
+			manifests, err := plugin.FindPluginsInPath(searchPath)
+			if err != nil {
+				if errors.Is(err, plugin.ErrPluginsNotFound) {
+					_, err = fmt.Fprintf(cmd.OutOrStdout(), "No plugins found in %s\n", strings.Join(searchPath, string(os.PathListSeparator)))
+				}
+				return err
+			}
+			return printPluginList(cmd.OutOrStdout(), manifests)
+		},
+	}
+	bindPluginSearchFlag(command.Flags(), &pluginDir)
+	return command
+}
+
+func printPluginList(out io.Writer, manifests plugin.Manifests) error {
+	writer := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	if _, err := fmt.Fprintln(writer, "ID\tVERSION\tTYPES\tLOCATION\tDESCRIPTION"); err != nil {
+		return err
+	}
+	for _, id := range sortedIDs(manifests) {
+		manifest := manifests[id]
+		_, err := fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", manifest.ID, manifest.Version, strings.Join(manifest.Types, ","),
+			filepath.Dir(manifest.ManifestPath()), manifest.Description)
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func newPluginInspect() *cobra.Command {
+	var (
+		pluginDir string
+		output    string
+	)
+	command := &cobra.Command{
+		Use:   "inspect PLUGIN_ID",
+		Short: "Show the metadata and configuration options of an installed plugin.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifests, err := plugin.FindPluginsInPath(pluginSearchPath(pluginDir), plugin.WithProviderIds(args))
+			if err != nil {
+				return err
+			}
+			manifest := manifests[args[0]]
+			switch output {
+			case "json":
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(manifest)
+			case "text":
+				return printPluginManifest(cmd.OutOrStdout(), manifest)
+			default:
+				return fmt.Errorf("invalid output format %q: must be one of text, json", output)
+			}
+		},
+	}
+	bindPluginSearchFlag(command.Flags(), &pluginDir)
+	command.Flags().StringVarP(&output, "output", "o", "text", "Output format, text or json.")
+	return command
+}
+
+func printPluginManifest(out io.Writer, manifest plugin.Manifest) error {
+	writer := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	lines := []string{
+		fmt.Sprintf("ID:\t%s", manifest.ID),
+		fmt.Sprintf("Description:\t%s", manifest.Description),
+		fmt.Sprintf("Version:\t%s", manifest.Version),
+		fmt.Sprintf("Types:\t%s", strings.Join(manifest.Types, ", ")),
+	}
+	switch remote := manifest.Remote; {
+	case remote == nil:
+		lines = append(lines,
+			fmt.Sprintf("Executable:\t%s", manifest.ExecutablePath),
+			fmt.Sprintf("SHA256:\t%s", manifest.Checksum))
+	case remote.Reattach != nil:
+		lines = append(lines, fmt.Sprintf("Remote:\treattach %s %s (pid %d)",
+			remote.Reattach.Network, remote.Reattach.Address, remote.Reattach.Pid))
+	default:
+		lines = append(lines, fmt.Sprintf("Remote:\t%s", remote.Address))
+	}
+	lines = append(lines, fmt.Sprintf("Manifest:\t%s", manifest.ManifestPath()))
+	if len(manifest.Args) > 0 {
+		lines = append(lines, fmt.Sprintf("Args:\t%s", strings.Join(manifest.Args, " ")))
+	}
+	if len(manifest.Environment) > 0 {
+		lines = append(lines, fmt.Sprintf("Environment:\t%s", strings.Join(manifest.Environment, ", ")))
+	}
+	if limits := manifest.Limits; limits != nil {
+		lines = append(lines, fmt.Sprintf("Limits:\tcpu-seconds=%d memory-bytes=%d open-files=%d",
+			limits.CPUSeconds, limits.MemoryBytes, limits.OpenFiles))
+	}
+	for _, shadowed := range manifest.Shadowed {
+		lines = append(lines, fmt.Sprintf("Shadows:\t%s", shadowed))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(writer, line); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if len(manifest.Configuration) == 0 {
+		_, err := fmt.Fprintln(out, "Configuration: none")
+		return err
+	}
+
+	if _, err := fmt.Fprintln(out, "Configuration:"); err != nil {
+		return err
+	}
+	writer = tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	if _, err := fmt.Fprintln(writer, "  NAME\tREQUIRED\tDEFAULT\tDESCRIPTION"); err != nil {
+		return err
+	}
+	for _, option := range manifest.Configuration {
+		defaultValue := "-"
+		if option.Default != nil {
+			defaultValue = fmt.Sprintf("%q", *option.Default)
+		}
+		_, err := fmt.Fprintf(writer, "  %s\t%t\t%s\t%s\n", option.Name, option.Required, defaultValue, option.Description)
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func newPluginInstall(logger hclog.Logger) *cobra.Command {
+	var (
+		pluginDir    string
+		manifestPath string
+		force        bool
+		manifest     plugin.Manifest
+	)
+	command := &cobra.Command{
+		Use:   "install BINARY",
+		Short: "Install a plugin binary and generate its manifest.",
+		Long: `Install copies a plugin binary into the plugin directory and writes its manifest
+with the computed checksum. The metadata and configuration options can be read from a manifest
+shipped with the plugin. The checksum of that manifest is ignored and its executable path is
+used as the name of the installed executable. The flags override the values of the manifest.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			base := plugin.Manifest{}
+			if manifestPath != "" {
+				data, err := os.ReadFile(manifestPath)
+				if err != nil {
+					return err
+				}
+				if err := json.Unmarshal(data, &base); err != nil {
+					return fmt.Errorf("failed to read manifest %s: %w", manifestPath, err)
+				}
+				base.Checksum = ""
+			}
+			flags := cmd.Flags()
+			if flags.Changed("id") {
+				base.ID = manifest.ID
+			}
+			if flags.Changed("description") {
+				base.Description = manifest.Description
+			}
+			if flags.Changed("version") {
+				base.Version = manifest.Version
+			}
+			if flags.Changed("type") || len(base.Types) == 0 {
+				base.Types = manifest.Types
+			}
+			if flags.Changed("executable") || manifestPath == "" {
+				base.ExecutablePath = manifest.ExecutablePath
+			}
+			if pluginDir == "" {
+				return &ConfigError{Option: pluginDirFlag}
+			}
+			if base.ID == "" {
+				return &ConfigError{Option: "id"}
+			}
+
+			var opts []plugin.InstallOption
+			if force {
+				opts = append(opts, plugin.WithOverwrite())
+			}
+			installed, err := plugin.Install(pluginDir, args[0], base, opts...)
+			if err != nil {
+				return err
+			}
+			logger.Info(fmt.Sprintf("Installed plugin %s to %s", installed.ID, pluginDir))
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", installed.ID, installed.Checksum)
+			return err
+		},
+	}
+	bindPluginDirFlag(command.Flags(), &pluginDir)
+	command.Flags().StringVarP(&manifestPath, "manifest", "m", "", "Path to a plugin manifest to take the metadata and configuration options from.")
+	command.Flags().StringVar(&manifest.ID, "id", "", "ID of the plugin.")
+	command.Flags().StringVar(&manifest.Description, "description", "", "Description of the plugin.")
+	command.Flags().StringVar(&manifest.Version, "version", "", "Version of the plugin.")
+	command.Flags().StringSliceVar(&manifest.Types, "type", []string{plugin.PVPPluginName}, "Plugin types implemented by the plugin.")
+	command.Flags().StringVar(&manifest.ExecutablePath, "executable", "", "Name of the executable in the plugin directory. Defaults to the name of the binary.")
+	command.Flags().BoolVarP(&force, "force", "f", false, "Replace an installed plugin with the same ID.")
+	return command
+}
+
+func newPluginVerify(logger hclog.Logger) *cobra.Command {
+	var (
+		pluginDir       string
+		signaturePolicy string
+		trustedKeyPaths []string
+	)
+	command := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the checksums and manifest signatures of the installed plugins.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			trustedKeys, err := plugin.LoadTrustedKeys(trustedKeyPaths...)
+			if err != nil {
+				return err
+			}
+			verifier, err := plugin.NewVerifier(plugin.SignaturePolicy(signaturePolicy), trustedKeys, logger)
+			if err != nil {
+				return err
+			}
+			verifications, err := plugin.VerifyPlugins(pluginSearchPath(pluginDir), plugin.WithVerifier(verifier))
+			if err != nil {
+				return err
+			}
+			// failed verifications are reported in the output
+			cmd.SilenceUsage = true
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 2, ' ', 0)
+			failed := 0
+			for _, verification := range verifications {
+				status := "OK"
+				if signature := verification.Manifest.Signature; signature != nil {
+					status = fmt.Sprintf("OK (signed by %s %s)", signature.Signer, signature.KeyID)
+				}
+				if verification.Err != nil {
+					status = fmt.Sprintf("FAILED: %v", verification.Err)
+					failed++
+				}
+				if _, err := fmt.Fprintf(writer, "%s\t%s\n", verification.PluginID, status); err != nil {
+					return err
+				}
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d plugins failed verification", failed, len(verifications))
+			}
+			return nil
+		},
+	}
+	bindPluginSearchFlag(command.Flags(), &pluginDir)
+	command.Flags().StringVar(&signaturePolicy, SignaturePolicy, string(plugin.SignaturePolicyOff), "Enforcement of manifest signatures, one of off, warn, require-signed.")
+	command.Flags().StringSliceVar(&trustedKeyPaths, TrustedKeys, nil, "Paths to PEM encoded ed25519 public keys manifest signatures are verified against.")
+	return command
+}
+
+func newPluginSign(logger hclog.Logger) *cobra.Command {
+	var (
+		pluginDir string
+		keyPath   string
+	)
+	command := &cobra.Command{
+		Use:   "sign PLUGIN_ID",
+		Short: "Sign the manifest of an installed plugin.",
+		Long: `Sign writes a detached ed25519 signature of the plugin manifest next to it in the
+plugin directory. The private key is a PEM encoded PKCS #8 key, as generated with
+"openssl genpkey -algorithm ed25519".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keyPath == "" {
+				return &ConfigError{Option: "key"}
+			}
+			data, err := os.ReadFile(keyPath)
+			if err != nil {
+				return err
+			}
+			key, err := plugin.ParsePrivateKey(data)
+			if err != nil {
+				return fmt.Errorf("failed to read private key %s: %w", keyPath, err)
+			}
+			dir, err := findPluginDir(pluginSearchPath(pluginDir), args[0])
+			if err != nil {
+				return err
+			}
+			if err := plugin.SignManifest(dir, args[0], key); err != nil {
+				return err
+			}
+			logger.Info(fmt.Sprintf("Signed the manifest of plugin %s in %s", args[0], dir))
+			return nil
+		},
+	}
+	bindPluginSearchFlag(command.Flags(), &pluginDir)
+	command.Flags().StringVarP(&keyPath, "key", "k", "", "Path to the PEM encoded ed25519 private key.")
+	return command
+}
+
+func newPluginUninstall(logger hclog.Logger) *cobra.Command {
+	var pluginDir string
+	command := &cobra.Command{
+		Use:   "uninstall PLUGIN_ID",
+		Short: "Remove an installed plugin and its manifest.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := findPluginDir(pluginSearchPath(pluginDir), args[0])
+			if err != nil {
+				return err
+			}
+			if err := plugin.Uninstall(dir, args[0]); err != nil {
+				return err
+			}
+			logger.Info(fmt.Sprintf("Uninstalled plugin %s from %s", args[0], dir))
+			return nil
+		},
+	}
+	bindPluginSearchFlag(command.Flags(), &pluginDir)
+	return command
+}
+
+func sortedIDs(manifests plugin.Manifests) []string {
+	ids := make([]string, 0, len(manifests))
+	for id := range manifests {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
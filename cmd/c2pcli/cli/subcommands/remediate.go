@@ -0,0 +1,176 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package subcommands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/framework"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/config"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	RemediationPlugins = "remediation-plugins"
+	remediationsIndex  = "remediations.json"
+)
+
+func NewRemediate(logger hclog.Logger) *cobra.Command {
+	options := NewOptions()
+	options.logger = logger
+
+	command := &cobra.Command{
+		Use:   "remediate",
+		Short: "Propose fixes for the failed policy results with remediation plugins.",
+		Long: "Propose fixes for the failed policy results with remediation plugins. The proposed fixes are " +
+			"written to the output directory and are not applied.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.Complete(cmd); err != nil {
+				return err
+			}
+			if err := validateRemediate(options); err != nil {
+				return err
+			}
+			return runRemediate(cmd.Context(), options)
+		},
+	}
+
+	fs := command.Flags()
+	fs.StringP("out", "o", "./remediations", "path to the output directory for the proposed fixes")
+	fs.StringSlice(RemediationPlugins, nil, "IDs of the remediation plugins that propose the fixes")
+	BindPluginFlags(fs)
+
+	return command
+}
+
+// validateRemediate required options with no defaults
+// are in place.
+func validateRemediate(options *Options) error {
+	if options.Name == "" {
+		return &ConfigError{Option: Name}
+	}
+	if options.Definition == "" {
+		return &ConfigError{Option: ComponentDefinition}
+	}
+	if len(options.RemediationPlugins) == 0 {
+		return &ConfigError{Option: RemediationPlugins}
+	}
+	return nil
+}
+
+func runRemediate(ctx context.Context, option *Options) error {
+	frameworkConfig, err := Config(option)
+	if err != nil {
+		return err
+	}
+
+	settings, err := Settings(frameworkConfig, option)
+	if err != nil {
+		return err
+	}
+
+	manager, err := framework.NewPluginManager(frameworkConfig)
+	if err != nil {
+		return err
+	}
+	foundPlugins, err := manager.FindRequestedPlugins()
+	if err != nil {
+		return err
+	}
+	foundRemediators, err := manager.FindRemediationPlugins(option.RemediationPlugins)
+	if err != nil {
+		return err
+	}
+
+	var configSelections config.PluginConfig = func(pluginID string) map[string]string {
+		return option.Plugins[pluginID]
+	}
+	defer manager.Clean()
+	launchedPlugins, err := manager.LaunchPolicyPlugins(foundPlugins, configSelections)
+	if err != nil {
+		return err
+	}
+	launchedRemediators, err := manager.LaunchRemediationPlugins(foundRemediators, configSelections)
+	if err != nil {
+		return err
+	}
+
+	proposed, err := manager.ProposeRemediations(ctx, launchedPlugins, launchedRemediators, settings.AllSettings())
+	if err != nil {
+		return err
+	}
+
+	option.logger.Info(fmt.Sprintf("Writing proposed remediations to %s.", option.Output))
+	return writeRemediations(option.Output, proposed)
+}
+
+// proposedRemediation is an entry of the remediations index.
+type proposedRemediation struct {
+	Plugin      string            `json:"plugin"`
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	CheckID     string            `json:"checkId,omitempty"`
+	ResourceID  string            `json:"resourceId,omitempty"`
+	Artifacts   []string          `json:"artifacts,omitempty"`
+	Props       []policy.Property `json:"props,omitempty"`
+}
+
+// writeRemediations writes the artifacts of each remediation plugin to a subdirectory
+// named after the plugin ID and an index of all the remediations.
+func writeRemediations(outDir string, proposed map[string][]policy.Remediation) error {
+	pluginIDs := make([]string, 0, len(proposed))
+	for pluginID := range proposed {
+		pluginIDs = append(pluginIDs, pluginID)
+	}
+	sort.Strings(pluginIDs)
+
+	index := []proposedRemediation{}
+	for _, pluginID := range pluginIDs {
+		pluginDir := filepath.Join(outDir, pluginID)
+		if err := os.MkdirAll(pluginDir, 0750); err != nil {
+			return err
+		}
+		written := make(map[string]bool)
+		for _, remediation := range proposed[pluginID] {
+			entry := proposedRemediation{
+				Plugin:      pluginID,
+				Title:       remediation.Title,
+				Description: remediation.Description,
+				CheckID:     remediation.CheckID,
+				ResourceID:  remediation.ResourceID,
+				Props:       remediation.Props,
+			}
+			for _, artifact := range remediation.Artifacts {
+				name := artifact.Name
+				if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+					return fmt.Errorf("remediation plugin %s proposed an artifact with invalid name %q", pluginID, name)
+				}
+				if written[name] {
+					return fmt.Errorf("remediation plugin %s proposed the artifact %s more than once", pluginID, name)
+				}
+				written[name] = true
+				path := filepath.Join(pluginDir, name)
+				if err := os.WriteFile(path, artifact.Content, 0600); err != nil {
+					return err
+				}
+				entry.Artifacts = append(entry.Artifacts, filepath.Join(pluginID, name))
+			}
+			index = append(index, entry)
+		}
+	}
+	if err := os.MkdirAll(outDir, 0750); err != nil {
+		return err
+	}
+	return pkg.WriteObjToJsonFile(filepath.Join(outDir, remediationsIndex), index)
+}
@@ -18,16 +18,27 @@ package subcommands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-2"
 	"github.com/hashicorp/go-hclog"
+	"github.com/oscal-compass/oscal-sdk-go/settings"
 	"github.com/oscal-compass/oscal-sdk-go/validation"
 	"github.com/spf13/cobra"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/framework"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/config"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	CollectorPlugins = "collector-plugins"
+	EvidenceDir      = "evidence-dir"
+	Waivers          = "waivers"
 )
 
 func NewResult2OSCAL(logger hclog.Logger) *cobra.Command {
@@ -50,6 +61,10 @@ func NewResult2OSCAL(logger hclog.Logger) *cobra.Command {
 
 	fs := command.Flags()
 	fs.StringP("out", "o", "./assessment-results.json", "path to output OSCAL Assessment Results")
+	fs.StringSlice(CollectorPlugins, nil, "IDs of the collector plugins that collect the evidence evaluated by the PVPs")
+	fs.String(EvidenceDir, "", "path to the directory where the collected evidence is stored. "+
+		"Without collector plugins, the evidence stored in the directory is evaluated again.")
+	fs.String(Waivers, "", "path to the waiver file with the approved exceptions applied to the results")
 	BindPluginFlags(fs)
 
 	return command
@@ -64,6 +79,9 @@ func validateResult2OSCAL(options *Options) error {
 	if options.Definition == "" {
 		return &ConfigError{Option: ComponentDefinition}
 	}
+	if len(options.CollectorPlugins) > 0 && options.EvidenceDir == "" {
+		return &ConfigError{Option: EvidenceDir}
+	}
 	return nil
 }
 
@@ -96,7 +114,25 @@ func runResult2Policy(ctx context.Context, option *Options) error {
 	}
 	defer manager.Clean()
 
-	results, err := manager.AggregateResults(ctx, launchedPlugins, settings.AllSettings())
+	evidence, err := collectOrLoadEvidence(ctx, manager, launchedPlugins, settings.AllSettings(), configSelections, option)
+	if err != nil {
+		return err
+	}
+	var results []policy.PVPResult
+	if len(evidence) > 0 {
+		results, err = manager.EvaluateEvidence(ctx, launchedPlugins, evidence, settings.AllSettings())
+	} else {
+		results, err = manager.AggregateResults(ctx, launchedPlugins, settings.AllSettings())
+	}
+	if err != nil {
+		return err
+	}
+
+	pipeline, err := manager.LaunchResultProcessors()
+	if err != nil {
+		return err
+	}
+	results, err = pipeline.Process(results)
 	if err != nil {
 		return err
 	}
@@ -106,7 +142,19 @@ func runResult2Policy(ctx context.Context, option *Options) error {
 		return err
 	}
 
-	assessmentResults, err := reporter.GenerateAssessmentResults(ctx, "REPLACE_ME", settings, results)
+	generateOpts := []framework.GenerateOption{framework.WithPluginManifests(foundPlugins)}
+	if option.Waivers != "" {
+		waivers, err := framework.LoadWaivers(option.Waivers)
+		if err != nil {
+			return err
+		}
+		generateOpts = append(generateOpts, framework.WithWaivers(waivers))
+	}
+
+	assessmentResults, err := reporter.GenerateAssessmentResults(ctx, "REPLACE_ME", settings, results, generateOpts...)
+	if err != nil {
+		return err
+	}
 	oscalModels := oscalTypes.OscalModels{
 		AssessmentResults: &assessmentResults,
 	}
@@ -125,3 +173,28 @@ func runResult2Policy(ctx context.Context, option *Options) error {
 	}
 	return nil
 }
+
+// collectOrLoadEvidence collects the evidence with the collector plugins, or loads the evidence
+// stored in the evidence directory when no collector plugins are set.
+func collectOrLoadEvidence(ctx context.Context, manager *framework.PluginManager, pluginSet map[string]policy.Provider, complianceSettings settings.Settings, pluginConfig config.PluginConfig, option *Options) ([]policy.Evidence, error) {
+	if len(option.CollectorPlugins) == 0 {
+		if option.EvidenceDir == "" {
+			return nil, nil
+		}
+		if _, err := os.Stat(filepath.Join(option.EvidenceDir, framework.EvidenceIndex)); errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		option.logger.Info(fmt.Sprintf("Evaluating the evidence stored in %s.", option.EvidenceDir))
+		return framework.LoadEvidence(option.EvidenceDir)
+	}
+
+	foundCollectors, err := manager.FindCollectorPlugins(option.CollectorPlugins)
+	if err != nil {
+		return nil, err
+	}
+	launchedCollectors, err := manager.LaunchCollectorPlugins(foundCollectors, pluginConfig)
+	if err != nil {
+		return nil, err
+	}
+	return manager.CollectEvidence(ctx, pluginSet, launchedCollectors, complianceSettings)
+}
@@ -0,0 +1,115 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+)
+
+const (
+	defaultNamespace   = "openshift-compliance"
+	defaultProduct     = "ocp4"
+	defaultName        = "compliance-to-policy"
+	defaultScanSetting = "default"
+	defaultProductType = "Platform"
+)
+
+var productTypes = []string{"Platform", "Node"}
+
+type Config struct {
+	// PolicyResultsDir is a directory of exported ComplianceCheckResults and ComplianceRemediations,
+	// such as the output of `oc get compliancecheckresults -o yaml`.
+	PolicyResultsDir string `mapstructure:"policy-results-dir"`
+	OutputDir        string `mapstructure:"output-dir"`
+	// Namespace is the namespace of the Compliance Operator.
+	Namespace string `mapstructure:"namespace"`
+	// Name is the name of the generated TailoredProfile and ScanSettingBinding.
+	Name string `mapstructure:"name"`
+	// Profile is the name of the Profile extended by the TailoredProfile, such as ocp4-cis.
+	Profile string `mapstructure:"profile"`
+	// ProductType is the product type of a TailoredProfile that does not extend a Profile
+	// ("Platform" or "Node").
+	ProductType string `mapstructure:"product-type"`
+	// Product is the prefix of the Rule and Variable names of the Compliance Operator, such as ocp4 or rhcos4.
+	Product string `mapstructure:"product"`
+	// ScanSetting is the name of the ScanSetting referenced by the ScanSettingBinding.
+	ScanSetting string `mapstructure:"scan-setting"`
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if err := pluginutil.CheckRequired("policy results directory", c.PolicyResultsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckRequired("output directory", c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.PolicyResultsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckOneOf("product type", c.ProductType, productTypes); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) namespace() string {
+	return withDefault(c.Namespace, defaultNamespace)
+}
+
+func (c Config) name() string {
+	return withDefault(c.Name, defaultName)
+}
+
+func (c Config) scanSetting() string {
+	return withDefault(c.ScanSetting, defaultScanSetting)
+}
+
+func (c Config) productType() string {
+	return withDefault(c.ProductType, defaultProductType)
+}
+
+func (c Config) productPrefix() string {
+	return withDefault(c.Product, defaultProduct) + "-"
+}
+
+// RuleName returns the name of the Rule of a check. Check IDs that already start with the product are used as is.
+func (c Config) RuleName(checkID string) string {
+	name := pluginutil.ToDNSCompliant(checkID)
+	if strings.HasPrefix(name, c.productPrefix()) {
+		return name
+	}
+	return c.productPrefix() + name
+}
+
+// ShortRuleName returns the name of the Rule of a check without the product, as it is annotated on
+// ComplianceCheckResults.
+func (c Config) ShortRuleName(checkID string) string {
+	return strings.TrimPrefix(c.RuleName(checkID), c.productPrefix())
+}
+
+// VariableName returns the name of the Variable of a parameter. Parameter IDs that already start with the
+// product are used as is.
+func (c Config) VariableName(parameterID string) string {
+	name := pluginutil.ToDNSCompliant(parameterID)
+	if strings.HasPrefix(name, c.productPrefix()) {
+		return name
+	}
+	return c.productPrefix() + name
+}
+
+func withDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,140 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/go-hclog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	typeco "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/complianceoperator"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	tailoredProfileFileName    = "tailored-profile.yaml"
+	scanSettingBindingFileName = "scan-setting-binding.yaml"
+)
+
+// Oscal2Policy generates a TailoredProfile that enables the Rule of each OSCAL check and sets the Variable
+// of each rule parameter, and a ScanSettingBinding that schedules its scans.
+type Oscal2Policy struct {
+	config Config
+	logger hclog.Logger
+}
+
+func NewOscal2Policy(config Config) *Oscal2Policy {
+	return &Oscal2Policy{
+		config: config,
+		logger: logger.Named("composer"),
+	}
+}
+
+func (c *Oscal2Policy) Generate(pl policy.Policy) error {
+	if err := os.MkdirAll(c.config.OutputDir, 0o755); err != nil {
+		return err
+	}
+	if err := pkg.WriteObjToYamlFile(filepath.Join(c.config.OutputDir, tailoredProfileFileName), c.TailoredProfile(pl)); err != nil {
+		return err
+	}
+	return pkg.WriteObjToYamlFile(filepath.Join(c.config.OutputDir, scanSettingBindingFileName), c.ScanSettingBinding())
+}
+
+// TailoredProfile returns the TailoredProfile of the policy.
+func (c *Oscal2Policy) TailoredProfile(pl policy.Policy) *typeco.TailoredProfile {
+	tailoredProfile := &typeco.TailoredProfile{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: typeco.APIVersion,
+			Kind:       "TailoredProfile",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.config.name(),
+			Namespace: c.config.namespace(),
+		},
+		Spec: typeco.TailoredProfileSpec{
+			Extends:     c.config.Profile,
+			Title:       "Compliance to Policy",
+			Description: "Rules and variables of the OSCAL component definition",
+		},
+	}
+	// a TailoredProfile that does not extend a Profile must declare the product type of its rules
+	if c.config.Profile == "" {
+		tailoredProfile.Annotations = map[string]string{
+			typeco.AnnotationProductType: c.config.productType(),
+		}
+	}
+
+	rules := map[string]struct{}{}
+	variables := map[string]struct{}{}
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			ruleName := c.config.RuleName(check.ID)
+			c.logger.Debug(fmt.Sprintf("processing check %s (%s) for rule %s", check.ID, ruleName, ruleSet.Rule.ID))
+			if _, ok := rules[ruleName]; ok {
+				continue
+			}
+			rules[ruleName] = struct{}{}
+			tailoredProfile.Spec.EnableRules = append(tailoredProfile.Spec.EnableRules, typeco.RuleReferenceSpec{
+				Name:      ruleName,
+				Rationale: fmt.Sprintf("Check %s of rule %s", check.ID, ruleSet.Rule.ID),
+			})
+		}
+
+		parameter := ruleSet.Rule.Parameter
+		if parameter == nil || parameter.Value == "" {
+			continue
+		}
+		variableName := c.config.VariableName(parameter.ID)
+		if _, ok := variables[variableName]; ok {
+			continue
+		}
+		variables[variableName] = struct{}{}
+		tailoredProfile.Spec.SetValues = append(tailoredProfile.Spec.SetValues, typeco.VariableValueSpec{
+			Name:      variableName,
+			Rationale: fmt.Sprintf("Parameter %s of rule %s", parameter.ID, ruleSet.Rule.ID),
+			Value:     parameter.Value,
+		})
+	}
+	// the order of the rule sets is not stable, keep the output reproducible
+	sort.Slice(tailoredProfile.Spec.EnableRules, func(i, j int) bool {
+		return tailoredProfile.Spec.EnableRules[i].Name < tailoredProfile.Spec.EnableRules[j].Name
+	})
+	sort.Slice(tailoredProfile.Spec.SetValues, func(i, j int) bool {
+		return tailoredProfile.Spec.SetValues[i].Name < tailoredProfile.Spec.SetValues[j].Name
+	})
+	return tailoredProfile
+}
+
+// ScanSettingBinding returns the ScanSettingBinding of the TailoredProfile.
+func (c *Oscal2Policy) ScanSettingBinding() *typeco.ScanSettingBinding {
+	return &typeco.ScanSettingBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: typeco.APIVersion,
+			Kind:       "ScanSettingBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.config.name(),
+			Namespace: c.config.namespace(),
+		},
+		Profiles: []typeco.NamedObjectReference{
+			{
+				Name:     c.config.name(),
+				Kind:     "TailoredProfile",
+				APIGroup: typeco.APIVersion,
+			},
+		},
+		SettingsRef: &typeco.NamedObjectReference{
+			Name:     c.config.scanSetting(),
+			Kind:     "ScanSetting",
+			APIGroup: typeco.APIVersion,
+		},
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"fmt"
+
+	sigyaml "sigs.k8s.io/yaml"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	typeco "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/complianceoperator"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const remediationApplied = "Applied"
+
+// Remediations proposes to apply the ComplianceRemediation of each failed ComplianceCheckResult. The proposed
+// artifact is a ComplianceRemediation with `spec.apply` set to true, so the fix is only applied by the
+// Compliance Operator once the artifact is applied to the cluster.
+func Remediations(result policy.PVPResult, checkResults []*typeco.ComplianceCheckResult, remediations []*typeco.ComplianceRemediation) ([]policy.Remediation, error) {
+	remediationsByName := map[string]*typeco.ComplianceRemediation{}
+	for _, remediation := range remediations {
+		remediationsByName[remediation.Namespace+"/"+remediation.Name] = remediation
+	}
+	// The operator names the ComplianceRemediation after the ComplianceCheckResult it fixes
+	remediationsByResource := map[string]*typeco.ComplianceRemediation{}
+	for _, checkResult := range checkResults {
+		if remediation, ok := remediationsByName[checkResult.Namespace+"/"+checkResult.Name]; ok {
+			remediationsByResource[resourceID(typeco.APIVersion, "ComplianceCheckResult", checkResult.Namespace, checkResult.Name)] = remediation
+		}
+	}
+
+	var proposed []policy.Remediation
+	for _, observation := range result.ObservationsByCheck {
+		for _, subject := range observation.Subjects {
+			if subject.Result != policy.ResultFail {
+				continue
+			}
+			remediation, ok := remediationsByResource[subject.ResourceID]
+			if !ok {
+				logger.Debug(fmt.Sprintf("no ComplianceRemediation for %s", subject.ResourceID))
+				continue
+			}
+			if remediation.Status.ApplicationState == remediationApplied {
+				logger.Debug(fmt.Sprintf("ComplianceRemediation %s is already applied", remediation.Name))
+				continue
+			}
+			content, err := sigyaml.Marshal(applyRemediation(remediation))
+			if err != nil {
+				return nil, err
+			}
+			props := []policy.Property{pluginutil.MakeProp("remediation", remediation.Name)}
+			if remediation.Spec.Type != "" {
+				props = append(props, pluginutil.MakeProp("remediation-type", remediation.Spec.Type))
+			}
+			if remediation.Status.ApplicationState != "" {
+				props = append(props, pluginutil.MakeProp("remediation-state", remediation.Status.ApplicationState))
+			}
+			proposed = append(proposed, policy.Remediation{
+				Title:       fmt.Sprintf("Apply ComplianceRemediation %s", remediation.Name),
+				Description: fmt.Sprintf("Set spec.apply of the ComplianceRemediation %s/%s to let the Compliance Operator apply the fix", remediation.Namespace, remediation.Name),
+				CheckID:     observation.CheckID,
+				ResourceID:  subject.ResourceID,
+				Artifacts: []policy.Artifact{
+					{
+						Name:      remediation.Name + ".yaml",
+						MediaType: "application/yaml",
+						Content:   content,
+					},
+				},
+				Props: props,
+			})
+		}
+	}
+	return proposed, nil
+}
+
+// applyRemediation returns the manifest that applies the ComplianceRemediation.
+func applyRemediation(remediation *typeco.ComplianceRemediation) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": typeco.APIVersion,
+		"kind":       "ComplianceRemediation",
+		"metadata": map[string]interface{}{
+			"name":      remediation.Name,
+			"namespace": remediation.Namespace,
+		},
+		"spec": map[string]interface{}{
+			"apply": true,
+		},
+	}
+}
@@ -0,0 +1,244 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	typeco "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/complianceoperator"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+type ResultToOscal struct {
+	policy       policy.Policy
+	checkResults []*typeco.ComplianceCheckResult
+	remediations map[string]*typeco.ComplianceRemediation
+	config       Config
+}
+
+func NewResultToOscal(pl policy.Policy, checkResults []*typeco.ComplianceCheckResult, remediations []*typeco.ComplianceRemediation, config Config) *ResultToOscal {
+	remediationsByName := map[string]*typeco.ComplianceRemediation{}
+	for _, remediation := range remediations {
+		remediationsByName[remediation.Namespace+"/"+remediation.Name] = remediation
+	}
+	return &ResultToOscal{
+		policy:       pl,
+		checkResults: checkResults,
+		remediations: remediationsByName,
+		config:       config,
+	}
+}
+
+func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
+	var observations []policy.ObservationByCheck
+	for _, rule := range r.policy {
+		for _, check := range rule.Checks {
+			ruleName := r.config.RuleName(check.ID)
+			logger.Debug(fmt.Sprintf("processing check %s (%s) for rule %s", check.ID, ruleName, rule.Rule.ID))
+			observation := policy.ObservationByCheck{
+				Title:       rule.Rule.ID,
+				CheckID:     check.ID,
+				Description: fmt.Sprintf("Observation of check %s", check.ID),
+				Methods:     []string{"TEST-AUTOMATED"},
+				Props: []policy.Property{
+					pluginutil.MakeProp("assessment-rule-id", rule.Rule.ID),
+					pluginutil.MakeProp("compliance-operator-rule", ruleName),
+				},
+				Collected: time.Now(),
+				Subjects:  []policy.Subject{},
+			}
+			for _, checkResult := range r.findCheckResults(check.ID) {
+				observation.Subjects = append(observation.Subjects, r.subject(checkResult))
+			}
+			observations = append(observations, observation)
+		}
+	}
+	return policy.PVPResult{
+		ObservationsByCheck: observations,
+	}, nil
+}
+
+// findCheckResults returns the ComplianceCheckResults of the Rule of a check, one for each scan
+// that evaluated it. Results are matched by their rule annotation and, if it is missing, by the
+// name suffix the operator appends to the scan name.
+func (r *ResultToOscal) findCheckResults(checkID string) []*typeco.ComplianceCheckResult {
+	shortRuleName := r.config.ShortRuleName(checkID)
+	var checkResults []*typeco.ComplianceCheckResult
+	for _, checkResult := range r.checkResults {
+		if rule, ok := checkResult.Annotations[typeco.AnnotationRule]; ok {
+			if rule == shortRuleName {
+				checkResults = append(checkResults, checkResult)
+			}
+			continue
+		}
+		if strings.HasSuffix(checkResult.Name, "-"+shortRuleName) {
+			checkResults = append(checkResults, checkResult)
+		}
+	}
+	return checkResults
+}
+
+func (r *ResultToOscal) subject(checkResult *typeco.ComplianceCheckResult) policy.Subject {
+	props := []policy.Property{
+		pluginutil.MakeProp("check-status", checkResult.Status),
+	}
+	if checkResult.Severity != "" {
+		props = append(props, pluginutil.MakeProp("severity", checkResult.Severity))
+	}
+	if scanName := checkResult.Labels[typeco.LabelScanName]; scanName != "" {
+		props = append(props, pluginutil.MakeProp("scan-name", scanName))
+	}
+	if checkResult.ID != "" {
+		props = append(props, pluginutil.MakeProp("xccdf-rule-id", checkResult.ID))
+	}
+	if instructions := strings.TrimSpace(checkResult.Instructions); instructions != "" {
+		props = append(props, pluginutil.MakeProp("instructions", instructions))
+	}
+	if remediation, ok := r.remediations[checkResult.Namespace+"/"+checkResult.Name]; ok {
+		props = append(props, pluginutil.MakeProp("remediation", remediation.Name))
+		if remediation.Status.ApplicationState != "" {
+			props = append(props, pluginutil.MakeProp("remediation-state", remediation.Status.ApplicationState))
+		}
+	}
+
+	var warnings []string
+	for _, warning := range checkResult.Warnings {
+		if w := strings.TrimSpace(warning); w != "" {
+			warnings = append(warnings, w)
+		}
+	}
+	reason := strings.Join(warnings, "; ")
+	if reason == "" {
+		reason = fmt.Sprintf("compliance check status: %s", checkResult.Status)
+	}
+
+	evaluatedOn := checkResult.CreationTimestamp.UTC()
+	if checkResult.CreationTimestamp.IsZero() {
+		evaluatedOn = time.Now()
+	}
+
+	gvknsn := fmt.Sprintf("ApiVersion: %s, Kind: %s, Namespace: %s, Name: %s", typeco.APIVersion, "ComplianceCheckResult", checkResult.Namespace, checkResult.Name)
+	return policy.Subject{
+		Title:       gvknsn,
+		ResourceID:  resourceID(typeco.APIVersion, "ComplianceCheckResult", checkResult.Namespace, checkResult.Name),
+		Type:        "resource",
+		Result:      mapCheckStatus(checkResult.Status),
+		EvaluatedOn: evaluatedOn,
+		Reason:      reason,
+		Props:       props,
+	}
+}
+
+// mapCheckStatus maps the status of a ComplianceCheckResult to a policy.Result.
+// Manual checks cannot be assessed automatically and are reported as warnings.
+// Informational checks do not assess compliance and are reported as skipped.
+func mapCheckStatus(status string) policy.Result {
+	switch status {
+	case typeco.CheckStatusPass:
+		return policy.ResultPass
+	case typeco.CheckStatusFail:
+		return policy.ResultFail
+	// the check passed on some nodes of a pool and failed on others
+	case typeco.CheckStatusInconsistent:
+		return policy.ResultFail
+	case typeco.CheckStatusManual:
+		return policy.ResultWarning
+	case typeco.CheckStatusError:
+		return policy.ResultError
+	case typeco.CheckStatusInfo, typeco.CheckStatusNotApplicable:
+		return policy.ResultSkipped
+	default:
+		return policy.ResultInvalid
+	}
+}
+
+func resourceID(apiVersion, kind, namespace, name string) string {
+	tokens := []string{apiVersion, kind}
+	if namespace != "" {
+		tokens = append(tokens, namespace)
+	}
+	tokens = append(tokens, name)
+	return strings.Join(tokens, "/")
+}
+
+// LoadResultsFromDirectory loads the ComplianceCheckResults and ComplianceRemediations from the YAML and
+// JSON files in dir and its subdirectories. Lists are expanded into their items. Results are sorted by
+// namespace and name.
+func LoadResultsFromDirectory(dir string) ([]*typeco.ComplianceCheckResult, []*typeco.ComplianceRemediation, error) {
+	var checkResults []*typeco.ComplianceCheckResult
+	var remediations []*typeco.ComplianceRemediation
+	appendObject := func(obj *unstructured.Unstructured) error {
+		if obj.GroupVersionKind().Group != typeco.Group {
+			return nil
+		}
+		switch obj.GetKind() {
+		case "ComplianceCheckResult":
+			checkResult := &typeco.ComplianceCheckResult{}
+			if err := pkg.ToK8sTypedObject(obj, checkResult); err != nil {
+				return fmt.Errorf("invalid ComplianceCheckResult %s: %w", obj.GetName(), err)
+			}
+			checkResults = append(checkResults, checkResult)
+		case "ComplianceRemediation":
+			remediation := &typeco.ComplianceRemediation{}
+			if err := pkg.ToK8sTypedObject(obj, remediation); err != nil {
+				return fmt.Errorf("invalid ComplianceRemediation %s: %w", obj.GetName(), err)
+			}
+			remediations = append(remediations, remediation)
+		}
+		return nil
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !(isYaml(d.Name()) || strings.HasSuffix(d.Name(), ".json")) {
+			return nil
+		}
+		objs, err := pkg.LoadYaml(path)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", path, err)
+		}
+		for _, obj := range objs {
+			if !obj.IsList() {
+				if err := appendObject(obj); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := obj.EachListItem(func(item runtime.Object) error {
+				return appendObject(item.(*unstructured.Unstructured))
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.SliceStable(checkResults, func(i, j int) bool {
+		if checkResults[i].Namespace != checkResults[j].Namespace {
+			return checkResults[i].Namespace < checkResults[j].Namespace
+		}
+		return checkResults[i].Name < checkResults[j].Name
+	})
+	return checkResults, remediations, nil
+}
+
+func isYaml(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
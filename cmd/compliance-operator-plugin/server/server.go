@@ -0,0 +1,70 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var (
+	_      policy.Provider   = (*Plugin)(nil)
+	_      policy.Remediator = (*Plugin)(nil)
+	logger hclog.Logger      = logging.NewPluginLogger()
+)
+
+func Logger() hclog.Logger {
+	return logger
+}
+
+type Plugin struct {
+	config Config
+}
+
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Configure(m map[string]string) error {
+	var config Config
+	if err := mapstructure.Decode(m, &config); err != nil {
+		return errors.New("error decoding configuration")
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	p.config = config
+	return nil
+}
+
+func (p *Plugin) Generate(pl policy.Policy) error {
+	logger.Debug(fmt.Sprintf("Writing Compliance Operator resources to %s", p.config.OutputDir))
+	composer := NewOscal2Policy(p.config)
+	return composer.Generate(pl)
+}
+
+func (p *Plugin) GetResults(pl policy.Policy) (policy.PVPResult, error) {
+	checkResults, remediations, err := LoadResultsFromDirectory(p.config.PolicyResultsDir)
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	results := NewResultToOscal(pl, checkResults, remediations, p.config)
+	return results.GenerateResults()
+}
+
+func (p *Plugin) Remediate(_ policy.Policy, result policy.PVPResult) ([]policy.Remediation, error) {
+	checkResults, remediations, err := LoadResultsFromDirectory(p.config.PolicyResultsDir)
+	if err != nil {
+		return nil, err
+	}
+	return Remediations(result, checkResults, remediations)
+}
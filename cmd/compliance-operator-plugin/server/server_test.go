@@ -0,0 +1,278 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oscal-compass/oscal-sdk-go/settings"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	typeco "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/complianceoperator"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin/plugintest"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestOscal2Policy(t *testing.T) {
+	tempDir := t.TempDir()
+	config := Config{
+		OutputDir: tempDir,
+		Profile:   "ocp4-cis",
+	}
+	require.NoError(t, NewOscal2Policy(config).Generate(createPolicy(t)))
+
+	tailoredProfile := typeco.TailoredProfile{}
+	require.NoError(t, pkg.LoadYamlFileToK8sTypedObject(filepath.Join(tempDir, tailoredProfileFileName), &tailoredProfile))
+	require.Equal(t, "TailoredProfile", tailoredProfile.Kind)
+	require.Equal(t, "compliance-to-policy", tailoredProfile.Name)
+	require.Equal(t, "openshift-compliance", tailoredProfile.Namespace)
+	require.Equal(t, "ocp4-cis", tailoredProfile.Spec.Extends)
+	require.Empty(t, tailoredProfile.Annotations)
+
+	var enabledRules []string
+	for _, rule := range tailoredProfile.Spec.EnableRules {
+		enabledRules = append(enabledRules, rule.Name)
+	}
+	require.Equal(t, []string{
+		"ocp4-api-server-anonymous-auth",
+		"ocp4-audit-log-forwarding-enabled",
+		"ocp4-kubelet-eviction-thresholds-set-hard-imagefs-available",
+	}, enabledRules)
+	require.Len(t, tailoredProfile.Spec.SetValues, 1)
+	require.Equal(t, "ocp4-var-kubelet-evictionhard-imagefs-available", tailoredProfile.Spec.SetValues[0].Name)
+	require.Equal(t, "10%", tailoredProfile.Spec.SetValues[0].Value)
+
+	binding := typeco.ScanSettingBinding{}
+	require.NoError(t, pkg.LoadYamlFileToK8sTypedObject(filepath.Join(tempDir, scanSettingBindingFileName), &binding))
+	require.Equal(t, []typeco.NamedObjectReference{
+		{Name: "compliance-to-policy", Kind: "TailoredProfile", APIGroup: "compliance.openshift.io/v1alpha1"},
+	}, binding.Profiles)
+	require.Equal(t, &typeco.NamedObjectReference{Name: "default", Kind: "ScanSetting", APIGroup: "compliance.openshift.io/v1alpha1"}, binding.SettingsRef)
+}
+
+func TestOscal2PolicyWithoutProfile(t *testing.T) {
+	config := Config{
+		Namespace:   "compliance",
+		Name:        "custom",
+		ProductType: "Node",
+		ScanSetting: "weekly",
+	}
+	composer := NewOscal2Policy(config)
+	tailoredProfile := composer.TailoredProfile(createPolicy(t))
+	require.Equal(t, "custom", tailoredProfile.Name)
+	require.Equal(t, "compliance", tailoredProfile.Namespace)
+	require.Empty(t, tailoredProfile.Spec.Extends)
+	require.Equal(t, map[string]string{typeco.AnnotationProductType: "Node"}, tailoredProfile.Annotations)
+	require.Equal(t, "weekly", composer.ScanSettingBinding().SettingsRef.Name)
+}
+
+func TestLoadResultsFromDirectory(t *testing.T) {
+	checkResults, remediations, err := LoadResultsFromDirectory(pkg.PathFromPkgDirectory("./testdata/compliance-operator/results"))
+	require.NoError(t, err)
+	require.Len(t, checkResults, 5)
+	require.Equal(t, "ocp4-cis-api-server-anonymous-auth", checkResults[0].Name)
+	require.Len(t, remediations, 1)
+	require.Equal(t, "NotApplied", remediations[0].Status.ApplicationState)
+}
+
+func TestResult2Oscal(t *testing.T) {
+	checkResults, remediations, err := LoadResultsFromDirectory(pkg.PathFromPkgDirectory("./testdata/compliance-operator/results"))
+	require.NoError(t, err)
+
+	reporter := NewResultToOscal(createPolicy(t), checkResults, remediations, Config{})
+	results, err := reporter.GenerateResults()
+	require.NoError(t, err)
+
+	type subjectResult struct {
+		name   string
+		result policy.Result
+		reason string
+	}
+	wantResults := map[string][]subjectResult{
+		"api-server-anonymous-auth": {
+			{name: "ocp4-cis-api-server-anonymous-auth", result: policy.ResultPass, reason: "compliance check status: PASS"},
+		},
+		"kubelet-eviction-thresholds-set-hard-imagefs-available": {
+			{name: "ocp4-cis-node-master-kubelet-eviction-thresholds-set-hard-imagefs-available", result: policy.ResultFail, reason: "compliance check status: FAIL"},
+			{name: "ocp4-cis-node-worker-kubelet-eviction-thresholds-set-hard-imagefs-available", result: policy.ResultPass, reason: "compliance check status: PASS"},
+		},
+		"audit-log-forwarding-enabled": {
+			{name: "ocp4-cis-audit-log-forwarding-enabled", result: policy.ResultWarning, reason: "The ClusterLogForwarder API could not be read"},
+		},
+	}
+	require.Len(t, results.ObservationsByCheck, 3)
+	observations := map[string]policy.ObservationByCheck{}
+	for _, observation := range results.ObservationsByCheck {
+		observations[observation.CheckID] = observation
+		t.Run(observation.CheckID, func(t *testing.T) {
+			var got []subjectResult
+			for _, subject := range observation.Subjects {
+				require.Equal(t, "resource", subject.Type)
+				got = append(got, subjectResult{name: filepath.Base(subject.ResourceID), result: subject.Result, reason: subject.Reason})
+			}
+			require.Equal(t, wantResults[observation.CheckID], got)
+		})
+	}
+
+	observation := observations["kubelet-eviction-thresholds-set-hard-imagefs-available"]
+	require.Equal(t, []policy.Property{
+		{Name: "assessment-rule-id", Value: "kubelet-eviction-thresholds-set-hard-imagefs-available"},
+		{Name: "compliance-operator-rule", Value: "ocp4-kubelet-eviction-thresholds-set-hard-imagefs-available"},
+	}, observation.Props)
+
+	subject := observation.Subjects[0]
+	require.Equal(t, "ApiVersion: compliance.openshift.io/v1alpha1, Kind: ComplianceCheckResult, Namespace: openshift-compliance, "+
+		"Name: ocp4-cis-node-master-kubelet-eviction-thresholds-set-hard-imagefs-available", subject.Title)
+	require.Equal(t, time.Date(2025, 3, 20, 10, 6, 0, 0, time.UTC), subject.EvaluatedOn)
+	require.Equal(t, []policy.Property{
+		{Name: "check-status", Value: "FAIL"},
+		{Name: "severity", Value: "medium"},
+		{Name: "scan-name", Value: "ocp4-cis-node-master"},
+		{Name: "xccdf-rule-id", Value: "xccdf_org.ssgproject.content_rule_kubelet_eviction_thresholds_set_hard_imagefs_available"},
+		{
+			Name: "instructions",
+			Value: "Run the following command on the kubelet node(s):\n" +
+				"$ oc debug -q node/$NODE -- jq -r '.evictionHard.\"imagefs.available\"' /host/etc/kubernetes/kubelet.conf",
+		},
+		{Name: "remediation", Value: "ocp4-cis-node-master-kubelet-eviction-thresholds-set-hard-imagefs-available"},
+		{Name: "remediation-state", Value: "NotApplied"},
+	}, subject.Props)
+}
+
+func TestRemediate(t *testing.T) {
+	plugin := NewPlugin()
+	require.NoError(t, plugin.Configure(map[string]string{
+		"policy-results-dir": pkg.PathFromPkgDirectory("./testdata/compliance-operator/results"),
+		"output-dir":         t.TempDir(),
+	}))
+	testPolicy := createPolicy(t)
+	results, err := plugin.GetResults(testPolicy)
+	require.NoError(t, err)
+
+	// Only the failed subject with a ComplianceRemediation is remediated
+	remediations, err := plugin.Remediate(testPolicy, results.Failed())
+	require.NoError(t, err)
+	require.Len(t, remediations, 1)
+	remediation := remediations[0]
+	require.Equal(t, "kubelet-eviction-thresholds-set-hard-imagefs-available", remediation.CheckID)
+	require.Equal(t, "compliance.openshift.io/v1alpha1/ComplianceCheckResult/openshift-compliance/"+
+		"ocp4-cis-node-master-kubelet-eviction-thresholds-set-hard-imagefs-available", remediation.ResourceID)
+	require.Equal(t, []policy.Property{
+		{Name: "remediation", Value: "ocp4-cis-node-master-kubelet-eviction-thresholds-set-hard-imagefs-available"},
+		{Name: "remediation-type", Value: "Configuration"},
+		{Name: "remediation-state", Value: "NotApplied"},
+	}, remediation.Props)
+	require.Equal(t, []policy.Artifact{
+		{
+			Name:      "ocp4-cis-node-master-kubelet-eviction-thresholds-set-hard-imagefs-available.yaml",
+			MediaType: "application/yaml",
+			Content: []byte(`apiVersion: compliance.openshift.io/v1alpha1
+kind: ComplianceRemediation
+metadata:
+  name: ocp4-cis-node-master-kubelet-eviction-thresholds-set-hard-imagefs-available
+  namespace: openshift-compliance
+spec:
+  apply: true
+`),
+		},
+	}, remediation.Artifacts)
+
+	// Applied remediations are not proposed again
+	checkResults, complianceRemediations, err := LoadResultsFromDirectory(pkg.PathFromPkgDirectory("./testdata/compliance-operator/results"))
+	require.NoError(t, err)
+	complianceRemediations[0].Status.ApplicationState = "Applied"
+	remediations, err = Remediations(results, checkResults, complianceRemediations)
+	require.NoError(t, err)
+	require.Empty(t, remediations)
+}
+
+func TestFindCheckResultsByName(t *testing.T) {
+	checkResults := []*typeco.ComplianceCheckResult{
+		{ObjectMeta: metav1.ObjectMeta{Name: "rhcos4-moderate-master-sshd-disable-root-login"}, Status: "FAIL"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "rhcos4-moderate-master-sshd-disable-empty-passwords"}, Status: "PASS"},
+	}
+	reporter := NewResultToOscal(nil, checkResults, nil, Config{Product: "rhcos4"})
+	found := reporter.findCheckResults("rhcos4-sshd-disable-root-login")
+	require.Len(t, found, 1)
+	require.Equal(t, "rhcos4-moderate-master-sshd-disable-root-login", found[0].Name)
+}
+
+func TestMapCheckStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   policy.Result
+	}{
+		{status: "PASS", want: policy.ResultPass},
+		{status: "INFO", want: policy.ResultSkipped},
+		{status: "FAIL", want: policy.ResultFail},
+		{status: "INCONSISTENT", want: policy.ResultFail},
+		{status: "MANUAL", want: policy.ResultWarning},
+		{status: "ERROR", want: policy.ResultError},
+		{status: "NOT-APPLICABLE", want: policy.ResultSkipped},
+		{status: "SKIP", want: policy.ResultInvalid},
+	}
+	for _, c := range tests {
+		t.Run(c.status, func(t *testing.T) {
+			require.Equal(t, c.want, mapCheckStatus(c.status))
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	config := Config{}
+	require.Equal(t, "ocp4-api-server-anonymous-auth", config.RuleName("api_server_anonymous_auth"))
+	require.Equal(t, "ocp4-api-server-anonymous-auth", config.RuleName("ocp4-api-server-anonymous-auth"))
+	require.Equal(t, "api-server-anonymous-auth", config.ShortRuleName("ocp4-api-server-anonymous-auth"))
+	require.Equal(t, "ocp4-var-kubelet-evictionhard-imagefs-available", config.VariableName("var_kubelet_evictionhard_imagefs_available"))
+
+	config.Product = "rhcos4"
+	require.Equal(t, "rhcos4-sshd-disable-root-login", config.RuleName("sshd_disable_root_login"))
+}
+
+func TestConfigure(t *testing.T) {
+	plugin := NewPlugin()
+	err := plugin.Configure(map[string]string{})
+	require.EqualError(t, err, "policy results directory must be set\noutput directory must be set")
+
+	configuration := map[string]string{
+		"policy-results-dir": "not-exist",
+		"output-dir":         t.TempDir(),
+	}
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "path \"not-exist\": stat not-exist: no such file or directory")
+
+	configuration["policy-results-dir"] = pkg.PathFromPkgDirectory("./testdata/compliance-operator/results")
+	configuration["product-type"] = "Node"
+	err = plugin.Configure(configuration)
+	require.NoError(t, err)
+
+	configuration["product-type"] = "Cluster"
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "invalid product type \"Cluster\": must be one of Platform, Node")
+	require.Equal(t, "Node", plugin.config.ProductType)
+
+	// options of an earlier configuration are not kept
+	delete(configuration, "product-type")
+	require.NoError(t, plugin.Configure(configuration))
+	require.Equal(t, defaultProductType, plugin.config.productType())
+}
+
+func createPolicy(t *testing.T) policy.Policy {
+	// apply the parameter values as the framework does before calling the plugin
+	testSettings := settings.NewSettings(
+		map[string]struct{}{
+			"api-server-anonymous-auth":                              {},
+			"kubelet-eviction-thresholds-set-hard-imagefs-available": {},
+			"audit-log-forwarding-enabled":                           {},
+		},
+		map[string]string{"var_kubelet_evictionhard_imagefs_available": "10%"},
+	)
+	return plugintest.LoadPolicy(t, pkg.PathFromPkgDirectory("./testdata/compliance-operator/component-definition.json"), "compliance-operator", testSettings)
+}
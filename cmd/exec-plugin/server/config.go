@@ -0,0 +1,58 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+)
+
+const defaultTimeout = time.Minute
+
+type Config struct {
+	// PoliciesDir is the directory of the check scripts. The script of a check is named after
+	// the check ID, with or without extension.
+	PoliciesDir string `mapstructure:"policy-dir"`
+	// EvidenceDir is the directory the stdout and stderr of the scripts are written to. The output
+	// is not kept when it is not set.
+	EvidenceDir string `mapstructure:"evidence-dir"`
+	// Timeout is the maximum duration of a script run, such as 30s.
+	Timeout string `mapstructure:"timeout"`
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if err := pluginutil.CheckRequired("policy directory", c.PoliciesDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.PoliciesDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.EvidenceDir); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid timeout %q: %w", c.Timeout, err))
+		} else if timeout <= 0 {
+			errs = append(errs, fmt.Errorf("invalid timeout %q: must be positive", c.Timeout))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ScriptTimeout returns the timeout of a script run. Validate must be called first.
+func (c Config) ScriptTimeout() time.Duration {
+	if c.Timeout == "" {
+		return defaultTimeout
+	}
+	timeout, _ := time.ParseDuration(c.Timeout)
+	return timeout
+}
@@ -0,0 +1,294 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// maxStderrProp is the maximum length of the stderr kept in the observation props. The full
+// output is written to the evidence directory.
+const maxStderrProp = 2048
+
+// ScriptOutput is the JSON a script writes to stdout.
+type ScriptOutput struct {
+	// Result is the result of the check ("pass", "fail", "error", "warning" or "skipped"). It is the
+	// result of the subjects that do not have one.
+	Result string `json:"result"`
+	// Reason is the reason of the subjects that do not have one.
+	Reason   string          `json:"reason,omitempty"`
+	Subjects []ScriptSubject `json:"subjects,omitempty"`
+}
+
+// ScriptSubject is a resource evaluated by a script.
+type ScriptSubject struct {
+	Title      string            `json:"title"`
+	ResourceID string            `json:"resource-id,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	Result     string            `json:"result,omitempty"`
+	Reason     string            `json:"reason,omitempty"`
+	Props      map[string]string `json:"props,omitempty"`
+}
+
+type ResultToOscal struct {
+	policy      policy.Policy
+	policiesDir string
+	evidenceDir string
+	timeout     time.Duration
+}
+
+func NewResultToOscal(pl policy.Policy, policiesDir, evidenceDir string, timeout time.Duration) *ResultToOscal {
+	return &ResultToOscal{
+		policy:      pl,
+		policiesDir: policiesDir,
+		evidenceDir: evidenceDir,
+		timeout:     timeout,
+	}
+}
+
+func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
+	var observations []policy.ObservationByCheck
+	for _, rule := range r.policy {
+		for _, check := range rule.Checks {
+			logger.Debug(fmt.Sprintf("processing check %s for rule %s", check.ID, rule.Rule.ID))
+			observation := policy.ObservationByCheck{
+				Title:       rule.Rule.ID,
+				CheckID:     check.ID,
+				Description: fmt.Sprintf("Observation of check %s", check.ID),
+				Methods:     []string{"TEST-AUTOMATED"},
+				Props: []policy.Property{
+					pluginutil.MakeProp("assessment-rule-id", rule.Rule.ID),
+				},
+				Subjects: []policy.Subject{},
+			}
+			if err := pluginutil.CheckFileName(check.ID); err != nil {
+				observation.Collected = time.Now()
+				observation.Subjects = append(observation.Subjects, errorSubject(check.ID, observation.Collected, err.Error()))
+				observations = append(observations, observation)
+				continue
+			}
+			script, err := FindScript(r.policiesDir, check.ID)
+			if err != nil {
+				observation.Collected = time.Now()
+				observation.Subjects = append(observation.Subjects, errorSubject(check.ID, observation.Collected, err.Error()))
+				observations = append(observations, observation)
+				continue
+			}
+			run := Run(context.Background(), script, r.timeout, rule.RuleSet, check.ID)
+			observation.Collected = run.Finished
+			observation.Props = append(observation.Props, runProps(run)...)
+			links, err := r.writeEvidence(check.ID, run)
+			if err != nil {
+				return policy.PVPResult{}, err
+			}
+			observation.RelevantEvidences = links
+			observation.Subjects = subjects(check.ID, run)
+			observations = append(observations, observation)
+		}
+	}
+	return policy.PVPResult{
+		ObservationsByCheck: observations,
+	}, nil
+}
+
+// subjects returns the subjects of a script run. Runs that failed or did not write a valid result
+// are reported as an error of the script.
+func subjects(checkID string, run RunResult) []policy.Subject {
+	scriptName := filepath.Base(run.Script)
+	if run.Err != nil {
+		return []policy.Subject{errorSubject(scriptName, run.Finished, run.Err.Error())}
+	}
+	output := ScriptOutput{}
+	if err := json.Unmarshal(bytes.TrimSpace(run.Stdout), &output); err != nil {
+		reason := fmt.Sprintf("invalid script output: %v", err)
+		if run.Truncated {
+			reason = fmt.Sprintf("invalid script output: the output exceeds %d bytes", maxOutput)
+		}
+		if run.ExitCode != 0 {
+			reason = fmt.Sprintf("script exited with code %d", run.ExitCode)
+			if stderr := lastLine(run.Stderr); stderr != "" {
+				reason = fmt.Sprintf("%s: %s", reason, stderr)
+			}
+		}
+		return []policy.Subject{errorSubject(scriptName, run.Finished, reason)}
+	}
+
+	if len(output.Subjects) == 0 {
+		reason := output.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("script result: %s", output.Result)
+		}
+		return []policy.Subject{{
+			Title:       fmt.Sprintf("Script: %s", scriptName),
+			ResourceID:  scriptName,
+			Type:        "resource",
+			Result:      parseResult(output.Result),
+			EvaluatedOn: run.Finished,
+			Reason:      reason,
+			Props: []policy.Property{
+				pluginutil.MakeProp("check-id", checkID),
+			},
+		}}
+	}
+
+	var result []policy.Subject
+	for _, s := range output.Subjects {
+		subject := policy.Subject{
+			Title:       s.Title,
+			ResourceID:  s.ResourceID,
+			Type:        s.Type,
+			Result:      parseResult(s.Result),
+			EvaluatedOn: run.Finished,
+			Reason:      s.Reason,
+		}
+		if s.Result == "" {
+			subject.Result = parseResult(output.Result)
+		}
+		if subject.Reason == "" {
+			subject.Reason = output.Reason
+		}
+		if subject.ResourceID == "" {
+			subject.ResourceID = subject.Title
+		}
+		if subject.Title == "" {
+			subject.Title = subject.ResourceID
+		}
+		if subject.Type == "" {
+			subject.Type = "resource"
+		}
+		var names []string
+		for name := range s.Props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			subject.Props = append(subject.Props, pluginutil.MakeProp(name, s.Props[name]))
+		}
+		result = append(result, subject)
+	}
+	return result
+}
+
+func errorSubject(resourceID string, evaluatedOn time.Time, reason string) policy.Subject {
+	return policy.Subject{
+		Title:       fmt.Sprintf("Script: %s", resourceID),
+		ResourceID:  resourceID,
+		Type:        "resource",
+		Result:      policy.ResultError,
+		EvaluatedOn: evaluatedOn,
+		Reason:      reason,
+	}
+}
+
+// runProps returns the props of a script run. The end of stderr is kept so that it is available
+// even when the evidence directory is not set.
+func runProps(run RunResult) []policy.Property {
+	props := []policy.Property{
+		pluginutil.MakeProp("script", filepath.Base(run.Script)),
+		pluginutil.MakeProp("exit-code", strconv.Itoa(run.ExitCode)),
+		pluginutil.MakeProp("duration", run.Finished.Sub(run.Started).Round(time.Millisecond).String()),
+	}
+	stderr := strings.TrimSpace(string(run.Stderr))
+	if len(stderr) > maxStderrProp {
+		stderr = stderr[len(stderr)-maxStderrProp:]
+	}
+	if stderr != "" {
+		props = append(props, pluginutil.MakeProp("stderr", stderr))
+	}
+	if run.Truncated {
+		props = append(props, pluginutil.MakeProp("output-truncated", "true"))
+	}
+	return props
+}
+
+// writeEvidence writes the stdout and stderr of a script run to the evidence directory.
+func (r *ResultToOscal) writeEvidence(checkID string, run RunResult) ([]policy.Link, error) {
+	if r.evidenceDir == "" {
+		return nil, nil
+	}
+	if err := pluginutil.CheckFileName(checkID); err != nil {
+		return nil, err
+	}
+	var links []policy.Link
+	for _, output := range []struct {
+		name string
+		data []byte
+	}{
+		{name: "stdout", data: run.Stdout},
+		{name: "stderr", data: run.Stderr},
+	} {
+		path := filepath.Join(r.evidenceDir, fmt.Sprintf("%s.%s", checkID, output.name))
+		if err := os.WriteFile(path, output.data, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write evidence of check %s: %w", checkID, err)
+		}
+		links = append(links, policy.Link{
+			Description: fmt.Sprintf("%s of script %s", output.name, filepath.Base(run.Script)),
+			Href:        path,
+			Props: []policy.Property{
+				pluginutil.MakeProp("exit-code", strconv.Itoa(run.ExitCode)),
+			},
+		})
+	}
+	return links, nil
+}
+
+// parseResult maps the result of the script output to a policy.Result.
+func parseResult(result string) policy.Result {
+	switch strings.ToLower(strings.TrimSpace(result)) {
+	case "pass":
+		return policy.ResultPass
+	case "fail":
+		return policy.ResultFail
+	case "error":
+		return policy.ResultError
+	case "warning":
+		return policy.ResultWarning
+	case "skipped":
+		return policy.ResultSkipped
+	default:
+		return policy.ResultInvalid
+	}
+}
+
+func lastLine(data []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// checkScripts returns an error for each check of the policy that has no script.
+func checkScripts(pl policy.Policy, policiesDir string) []error {
+	var errs []error
+	for _, rule := range pl {
+		for _, check := range rule.Checks {
+			script, err := FindScript(policiesDir, check.ID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			info, err := os.Stat(script)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if info.Mode()&0o111 == 0 {
+				errs = append(errs, fmt.Errorf("script %s of check %s is not executable", script, check.ID))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,167 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+)
+
+// Environment variables set for the scripts in addition to the environment of the plugin.
+const (
+	EnvCheckID     = "C2P_CHECK_ID"
+	EnvRuleID      = "C2P_RULE_ID"
+	EnvParamPrefix = "C2P_PARAM_"
+)
+
+// waitDelay is the time given to a script to exit after it has been killed on timeout.
+const waitDelay = time.Second
+
+// maxOutput is the maximum number of bytes of stdout and of stderr captured from a script run.
+// The rest of the output is discarded.
+const maxOutput = 1 << 20
+
+// RunResult is the outcome of a script run.
+type RunResult struct {
+	Script   string
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+	Started  time.Time
+	Finished time.Time
+	// Truncated is set when stdout or stderr was longer than maxOutput.
+	Truncated bool
+	// Err is set when the script could not be run or did not finish in time.
+	Err error
+}
+
+// FindScript returns the path of the script of a check in dir. The script is named after the check ID,
+// with or without extension.
+func FindScript(dir, checkID string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var candidates []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if name == checkID || strings.TrimSuffix(name, filepath.Ext(name)) == checkID {
+			candidates = append(candidates, name)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no script found for check %s in %s", checkID, dir)
+	case 1:
+		return filepath.Join(dir, candidates[0]), nil
+	default:
+		sort.Strings(candidates)
+		return "", fmt.Errorf("several scripts found for check %s: %s", checkID, strings.Join(candidates, ", "))
+	}
+}
+
+// Run runs the script of a check with the rule parameter in its environment. The working directory is
+// the directory of the script.
+func Run(ctx context.Context, script string, timeout time.Duration, ruleSet extensions.RuleSet, checkID string) RunResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := RunResult{Script: script, Started: time.Now()}
+	// an absolute path keeps the script from being looked up in PATH
+	path, err := filepath.Abs(script)
+	if err != nil {
+		result.Err = err
+		result.Finished = result.Started
+		return result
+	}
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Dir = filepath.Dir(path)
+	cmd.Env = append(os.Environ(), scriptEnv(ruleSet, checkID)...)
+	cmd.WaitDelay = waitDelay
+	stdout := &limitedBuffer{max: maxOutput}
+	stderr := &limitedBuffer{max: maxOutput}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err = cmd.Run()
+	result.Finished = time.Now()
+	result.Stdout = stdout.Bytes()
+	result.Stderr = stderr.Bytes()
+	result.Truncated = stdout.truncated || stderr.truncated
+	result.ExitCode = cmd.ProcessState.ExitCode()
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.Err = fmt.Errorf("script timed out after %s", timeout)
+	case errors.As(err, &exitErr):
+		// a non-zero exit code is not a failure to run the script, its output is still parsed
+	case err != nil:
+		result.Err = err
+	}
+	return result
+}
+
+// limitedBuffer keeps the first max bytes written to it and discards the rest, so that
+// the output of a script cannot exhaust the memory of the plugin.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.max - b.buf.Len(); len(p) > remaining {
+		b.truncated = true
+		if remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		// the discarded output is reported as written so the script is not interrupted
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+func scriptEnv(ruleSet extensions.RuleSet, checkID string) []string {
+	env := []string{
+		fmt.Sprintf("%s=%s", EnvCheckID, checkID),
+		fmt.Sprintf("%s=%s", EnvRuleID, ruleSet.Rule.ID),
+	}
+	if parameter := ruleSet.Rule.Parameter; parameter != nil {
+		env = append(env, fmt.Sprintf("%s=%s", ParamEnvName(parameter.ID), parameter.Value))
+	}
+	return env
+}
+
+// ParamEnvName returns the name of the environment variable of a parameter, for example
+// C2P_PARAM_MIN_PASSWORD_LENGTH for min-password-length.
+func ParamEnvName(parameterID string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, parameterID)
+	return EnvParamPrefix + name
+}
@@ -0,0 +1,58 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var (
+	_      policy.Provider = (*Plugin)(nil)
+	logger hclog.Logger    = logging.NewPluginLogger()
+)
+
+func Logger() hclog.Logger {
+	return logger
+}
+
+type Plugin struct {
+	config Config
+}
+
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Configure(m map[string]string) error {
+	var config Config
+	if err := mapstructure.Decode(m, &config); err != nil {
+		return errors.New("error decoding configuration")
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	p.config = config
+	return nil
+}
+
+// Generate checks that every check of the policy has an executable script. The scripts are
+// maintained by the user, so nothing is written.
+func (p *Plugin) Generate(pl policy.Policy) error {
+	logger.Debug(fmt.Sprintf("Checking scripts in %s", p.config.PoliciesDir))
+	return errors.Join(checkScripts(pl, p.config.PoliciesDir)...)
+}
+
+func (p *Plugin) GetResults(pl policy.Policy) (policy.PVPResult, error) {
+	results := NewResultToOscal(pl, p.config.PoliciesDir, p.config.EvidenceDir, p.config.ScriptTimeout())
+	return results.GenerateResults()
+}
@@ -0,0 +1,271 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/oscal-compass/oscal-sdk-go/settings"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin/plugintest"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var scriptsDir = pkg.PathFromPkgDirectory("./testdata/exec/scripts")
+
+func TestFindScript(t *testing.T) {
+	script, err := FindScript(scriptsDir, "check-password-length")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(scriptsDir, "check-password-length.sh"), script)
+
+	// scripts without extension
+	script, err = FindScript(scriptsDir, "check-audit-log-retention")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(scriptsDir, "check-audit-log-retention"), script)
+
+	_, err = FindScript(scriptsDir, "check-world-writable-files")
+	require.EqualError(t, err, "no script found for check check-world-writable-files in "+scriptsDir)
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "check.sh"), nil, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "check.py"), nil, 0o700))
+	_, err = FindScript(tempDir, "check")
+	require.EqualError(t, err, "several scripts found for check check: check.py, check.sh")
+}
+
+func TestParamEnvName(t *testing.T) {
+	require.Equal(t, "C2P_PARAM_MIN_PASSWORD_LENGTH", ParamEnvName("min-password-length"))
+	require.Equal(t, "C2P_PARAM_VAR_ACCOUNTS_TMOUT", ParamEnvName("var_accounts_tmout"))
+	require.Equal(t, "C2P_PARAM_A_B_C", ParamEnvName("a.b/c"))
+}
+
+func TestRunTimeout(t *testing.T) {
+	tempDir := t.TempDir()
+	script := filepath.Join(tempDir, "slow.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho started >&2\nsleep 10\n"), 0o700))
+
+	start := time.Now()
+	run := Run(context.Background(), script, 100*time.Millisecond, extensions.RuleSet{}, "slow")
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.EqualError(t, run.Err, "script timed out after 100ms")
+	require.Equal(t, "script timed out after 100ms", subjects("slow", run)[0].Reason)
+}
+
+func TestRunOutputLimit(t *testing.T) {
+	tempDir := t.TempDir()
+	script := filepath.Join(tempDir, "chatty.sh")
+	content := fmt.Sprintf("#!/bin/sh\nhead -c %d /dev/zero\nhead -c %d /dev/zero >&2\n", 2*maxOutput, maxOutput)
+	require.NoError(t, os.WriteFile(script, []byte(content), 0o700))
+
+	run := Run(context.Background(), script, 10*time.Second, extensions.RuleSet{}, "chatty")
+	require.NoError(t, run.Err)
+	require.Len(t, run.Stdout, maxOutput)
+	require.Len(t, run.Stderr, maxOutput)
+	require.True(t, run.Truncated)
+	require.Contains(t, runProps(run), policy.Property{Name: "output-truncated", Value: "true"})
+	require.Equal(t, fmt.Sprintf("invalid script output: the output exceeds %d bytes", maxOutput), subjects("chatty", run)[0].Reason)
+}
+
+func TestResult2Oscal(t *testing.T) {
+	evidenceDir := t.TempDir()
+	reporter := NewResultToOscal(createPolicy(t), scriptsDir, evidenceDir, 10*time.Second)
+	results, err := reporter.GenerateResults()
+	require.NoError(t, err)
+	require.Len(t, results.ObservationsByCheck, 4)
+
+	observations := map[string]policy.ObservationByCheck{}
+	for _, observation := range results.ObservationsByCheck {
+		observations[observation.CheckID] = observation
+	}
+
+	// subjects written by the script, with the rule parameter in the environment
+	observation := observations["check-password-length"]
+	require.Equal(t, "min-password-length", observation.Title)
+	require.Equal(t, []policy.Property{
+		{Name: "assessment-rule-id", Value: "min-password-length"},
+		{Name: "script", Value: "check-password-length.sh"},
+		{Name: "exit-code", Value: "0"},
+	}, withoutProp(observation.Props, "duration"))
+	require.Len(t, observation.Subjects, 2)
+	require.Equal(t, policy.Subject{
+		Title:       "Account: bob",
+		ResourceID:  "host-1/bob",
+		Type:        "resource",
+		Result:      policy.ResultFail,
+		EvaluatedOn: observation.Collected,
+		Reason:      "minimum length 8 is lower than 12",
+		Props: []policy.Property{
+			{Name: "min-length", Value: "8"},
+			{Name: "required-length", Value: "12"},
+		},
+	}, observation.Subjects[1])
+	// the result of the check is the result of the subjects without one
+	require.Equal(t, policy.ResultPass, observation.Subjects[0].Result)
+
+	require.Len(t, observation.RelevantEvidences, 2)
+	require.Equal(t, policy.Link{
+		Description: "stdout of script check-password-length.sh",
+		Href:        filepath.Join(evidenceDir, "check-password-length.stdout"),
+		Props:       []policy.Property{{Name: "exit-code", Value: "0"}},
+	}, observation.RelevantEvidences[0])
+	stdout, err := os.ReadFile(filepath.Join(evidenceDir, "check-password-length.stdout"))
+	require.NoError(t, err)
+	require.Contains(t, string(stdout), `"required-length": "12"`)
+
+	// the output is parsed when the exit code is not zero
+	observation = observations["check-ssh-root-login"]
+	require.Equal(t, []policy.Subject{{
+		Title:       "Script: check-ssh-root-login.sh",
+		ResourceID:  "check-ssh-root-login.sh",
+		Type:        "resource",
+		Result:      policy.ResultFail,
+		EvaluatedOn: observation.Collected,
+		Reason:      "PermitRootLogin is yes",
+		Props:       []policy.Property{{Name: "check-id", Value: "check-ssh-root-login"}},
+	}}, observation.Subjects)
+	require.Equal(t, []policy.Property{
+		{Name: "assessment-rule-id", Value: "ssh-root-login-disabled"},
+		{Name: "script", Value: "check-ssh-root-login.sh"},
+		{Name: "exit-code", Value: "1"},
+		{Name: "stderr", Value: "reading sshd_config of check check-ssh-root-login"},
+	}, withoutProp(observation.Props, "duration"))
+
+	observation = observations["check-audit-log-retention"]
+	require.Len(t, observation.Subjects, 1)
+	require.Equal(t, policy.ResultError, observation.Subjects[0].Result)
+	require.Equal(t, "script exited with code 2: auditd is not installed", observation.Subjects[0].Reason)
+	stderr, err := os.ReadFile(filepath.Join(evidenceDir, "check-audit-log-retention.stderr"))
+	require.NoError(t, err)
+	require.Equal(t, "starting check\nauditd is not installed\n", string(stderr))
+
+	observation = observations["check-world-writable-files"]
+	require.Len(t, observation.Subjects, 1)
+	require.Equal(t, policy.ResultError, observation.Subjects[0].Result)
+	require.Equal(t, "check-world-writable-files", observation.Subjects[0].ResourceID)
+	require.Empty(t, observation.RelevantEvidences)
+}
+
+func TestResult2OscalInvalidCheckID(t *testing.T) {
+	evidenceDir := filepath.Join(t.TempDir(), "evidence")
+	require.NoError(t, os.Mkdir(evidenceDir, 0o700))
+	pl := policy.Policy{
+		{
+			RuleSet: extensions.RuleSet{
+				Rule:   extensions.Rule{ID: "traversal"},
+				Checks: []extensions.Check{{ID: "../../x"}},
+			},
+		},
+	}
+	results, err := NewResultToOscal(pl, scriptsDir, evidenceDir, 10*time.Second).GenerateResults()
+	require.NoError(t, err)
+	require.Len(t, results.ObservationsByCheck, 1)
+	subjects := results.ObservationsByCheck[0].Subjects
+	require.Len(t, subjects, 1)
+	require.Equal(t, policy.ResultError, subjects[0].Result)
+	require.Equal(t, `invalid check id "../../x": must be a file name`, subjects[0].Reason)
+
+	_, err = NewResultToOscal(pl, scriptsDir, evidenceDir, 10*time.Second).writeEvidence("../x", RunResult{})
+	require.ErrorContains(t, err, "invalid check id")
+	_, err = os.Stat(filepath.Join(filepath.Dir(evidenceDir), "x.stdout"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestParseResult(t *testing.T) {
+	tests := []struct {
+		result string
+		want   policy.Result
+	}{
+		{result: "pass", want: policy.ResultPass},
+		{result: "FAIL", want: policy.ResultFail},
+		{result: "error", want: policy.ResultError},
+		{result: "warning", want: policy.ResultWarning},
+		{result: "skipped", want: policy.ResultSkipped},
+		{result: "unknown", want: policy.ResultInvalid},
+	}
+	for _, c := range tests {
+		t.Run(c.result, func(t *testing.T) {
+			require.Equal(t, c.want, parseResult(c.result))
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	plugin := NewPlugin()
+	require.NoError(t, plugin.Configure(map[string]string{"policy-dir": scriptsDir}))
+	err := plugin.Generate(createPolicy(t))
+	require.EqualError(t, err, "no script found for check check-world-writable-files in "+scriptsDir)
+}
+
+func TestConfigure(t *testing.T) {
+	plugin := NewPlugin()
+	err := plugin.Configure(map[string]string{})
+	require.EqualError(t, err, "policy directory must be set")
+
+	configuration := map[string]string{
+		"policy-dir": "not-exist",
+	}
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "path \"not-exist\": stat not-exist: no such file or directory")
+
+	configuration["policy-dir"] = scriptsDir
+	configuration["timeout"] = "0s"
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "invalid timeout \"0s\": must be positive")
+
+	configuration["timeout"] = "30s"
+	require.NoError(t, plugin.Configure(configuration))
+	require.Equal(t, 30*time.Second, plugin.config.ScriptTimeout())
+
+	plugin = NewPlugin()
+	delete(configuration, "timeout")
+	require.NoError(t, plugin.Configure(configuration))
+	require.Equal(t, time.Minute, plugin.config.ScriptTimeout())
+}
+
+func TestConformance(t *testing.T) {
+	defaultTimeout := "60s"
+	plugintest.Run(t, NewPlugin(), plugintest.Options{
+		Manifest: plugin.Manifest{
+			Configuration: []plugin.ConfigurationOption{
+				{Name: "policy-dir", Required: true},
+				{Name: "evidence-dir"},
+				{Name: "timeout", Default: &defaultTimeout},
+			},
+		},
+		Configuration:        map[string]string{"policy-dir": scriptsDir},
+		InvalidConfiguration: map[string]string{"policy-dir": scriptsDir, "timeout": "-1s"},
+		Policy:               createPolicy(t),
+		Results:              []policy.Result{policy.ResultPass, policy.ResultFail, policy.ResultError},
+	})
+}
+
+func withoutProp(props []policy.Property, name string) []policy.Property {
+	var result []policy.Property
+	for _, prop := range props {
+		if prop.Name != name {
+			result = append(result, prop)
+		}
+	}
+	return result
+}
+
+func createPolicy(t *testing.T) policy.Policy {
+	// apply the parameter values as the framework does before calling the plugin
+	testSettings := settings.NewSettings(
+		map[string]struct{}{"min-password-length": {}, "ssh-root-login-disabled": {}, "audit-log-retention": {}, "no-world-writable-files": {}},
+		map[string]string{"min-password-length": "12"},
+	)
+	return plugintest.LoadPolicy(t, pkg.PathFromPkgDirectory("./testdata/exec/component-definition.json"), "exec", testSettings)
+}
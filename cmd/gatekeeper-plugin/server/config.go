@@ -0,0 +1,52 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+)
+
+var enforcementActions = []string{"deny", "dryrun", "warn"}
+
+type Config struct {
+	PoliciesDir      string `mapstructure:"policy-dir"`
+	PolicyResultsDir string `mapstructure:"policy-results-dir"`
+	OutputDir        string `mapstructure:"output-dir"`
+	// Kubeconfig is the path to a kubeconfig for the cluster running Gatekeeper. When set,
+	// Constraints are read from the cluster instead of from the policy results directory.
+	Kubeconfig string `mapstructure:"kubeconfig"`
+	// EnforcementAction sets the enforcement action of the generated Constraints
+	// ("deny", "dryrun" or "warn"). Gatekeeper defaults to "deny" when it is not set.
+	EnforcementAction string `mapstructure:"enforcement-action"`
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if err := pluginutil.CheckRequired("policy directory", c.PoliciesDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckRequired("output directory", c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.PoliciesDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.PolicyResultsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.Kubeconfig); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckOneOf("enforcement action", c.EnforcementAction, enforcementActions); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
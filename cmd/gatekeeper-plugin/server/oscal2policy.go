@@ -0,0 +1,223 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	typegatekeeper "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/gatekeeper"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	// AnnotationCheckID is set on generated Constraints to map their results back to the OSCAL check.
+	AnnotationCheckID = "compliance-to-policy.check-id"
+
+	matchFileName              = "match.yaml"
+	constraintTemplateFileName = "constraint-template.yaml"
+	constraintFileName         = "constraint.yaml"
+)
+
+// Oscal2Policy generates a ConstraintTemplate and a Constraint for each OSCAL check.
+// The ConstraintTemplate of a check is read from the directory named after the check ID
+// in the policies directory. An optional match.yaml in the same directory is used as the
+// spec.match of the Constraint.
+type Oscal2Policy struct {
+	policiesDir       string
+	outputDir         string
+	enforcementAction string
+	logger            hclog.Logger
+}
+
+func NewOscal2Policy(policiesDir, outputDir, enforcementAction string) *Oscal2Policy {
+	return &Oscal2Policy{
+		policiesDir:       policiesDir,
+		outputDir:         outputDir,
+		enforcementAction: enforcementAction,
+		logger:            logger.Named("composer"),
+	}
+}
+
+func (c *Oscal2Policy) Generate(pl policy.Policy) error {
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			c.logger.Debug(fmt.Sprintf("processing check %s for rule %s", check.ID, ruleSet.Rule.ID))
+			if err := pluginutil.CheckFileName(check.ID); err != nil {
+				return err
+			}
+			checkDir := filepath.Join(c.policiesDir, check.ID)
+			template, err := loadConstraintTemplate(checkDir)
+			if err != nil {
+				return fmt.Errorf("check %s: %w", check.ID, err)
+			}
+			constraint, err := c.newConstraint(check.ID, template, ruleSet.Rule.Parameter)
+			if err != nil {
+				return fmt.Errorf("check %s: %w", check.ID, err)
+			}
+			match, err := loadMatch(checkDir)
+			if err != nil {
+				return fmt.Errorf("check %s: %w", check.ID, err)
+			}
+			constraint.Spec.Match = match
+
+			destDir := filepath.Join(c.outputDir, check.ID)
+			if err := os.MkdirAll(destDir, 0o755); err != nil {
+				return err
+			}
+			if err := pkg.WriteObjToYamlFile(filepath.Join(destDir, constraintTemplateFileName), template.Object); err != nil {
+				return err
+			}
+			if err := pkg.WriteObjToYamlFile(filepath.Join(destDir, constraintFileName), constraint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Oscal2Policy) newConstraint(checkID string, template *unstructured.Unstructured, parameter *extensions.Parameter) (*typegatekeeper.Constraint, error) {
+	kind, found, err := unstructured.NestedString(template.Object, "spec", "crd", "spec", "names", "kind")
+	if err != nil || !found || kind == "" {
+		return nil, fmt.Errorf("ConstraintTemplate %s does not define spec.crd.spec.names.kind", template.GetName())
+	}
+	parameters, err := c.mapParameters(template, parameter)
+	if err != nil {
+		return nil, err
+	}
+	return &typegatekeeper.Constraint{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: typegatekeeper.ConstraintsGroup + "/" + typegatekeeper.ConstraintsVersion,
+			Kind:       kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: pluginutil.ToDNSCompliant(checkID),
+			Annotations: map[string]string{
+				AnnotationCheckID: checkID,
+			},
+		},
+		Spec: typegatekeeper.ConstraintSpec{
+			EnforcementAction: c.enforcementAction,
+			Parameters:        parameters,
+		},
+	}, nil
+}
+
+// mapParameters maps the rule parameter to the Constraint spec.parameters. The parameter ID is used as
+// the parameter name and its value is converted to the type declared in the ConstraintTemplate schema.
+func (c *Oscal2Policy) mapParameters(template *unstructured.Unstructured, parameter *extensions.Parameter) (map[string]interface{}, error) {
+	if parameter == nil || parameter.Value == "" {
+		return nil, nil
+	}
+	properties, found, err := unstructured.NestedMap(template.Object, "spec", "crd", "spec", "validation", "openAPIV3Schema", "properties")
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema in ConstraintTemplate %s: %w", template.GetName(), err)
+	}
+	var schema map[string]interface{}
+	if found {
+		property, ok := properties[parameter.ID]
+		if !ok {
+			c.logger.Warn(fmt.Sprintf("parameter %s is not declared by ConstraintTemplate %s, skipping", parameter.ID, template.GetName()))
+			return nil, nil
+		}
+		schema, _ = property.(map[string]interface{})
+	}
+	value, err := convertParameter(parameter.Value, schema)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %s: %w", parameter.ID, err)
+	}
+	return map[string]interface{}{parameter.ID: value}, nil
+}
+
+// convertParameter converts a parameter value to the type of its OpenAPI schema.
+// Array values are comma-separated and object values are JSON.
+func convertParameter(value string, schema map[string]interface{}) (interface{}, error) {
+	schemaType, _ := schema["type"].(string)
+	switch schemaType {
+	case "integer":
+		return strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	case "number":
+		return strconv.ParseFloat(strings.TrimSpace(value), 64)
+	case "boolean":
+		return strconv.ParseBool(strings.TrimSpace(value))
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		values := []interface{}{}
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			converted, err := convertParameter(item, items)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, converted)
+		}
+		return values, nil
+	case "object":
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &object); err != nil {
+			return nil, fmt.Errorf("value is not a JSON object: %w", err)
+		}
+		return object, nil
+	default:
+		return value, nil
+	}
+}
+
+// loadConstraintTemplate returns the first ConstraintTemplate found in the YAML files of dir.
+func loadConstraintTemplate(dir string) (*unstructured.Unstructured, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isYaml(entry.Name()) || entry.Name() == matchFileName {
+			continue
+		}
+		objs, err := pkg.LoadYaml(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			logger.Warn(fmt.Sprintf("%s is not k8s object: %v", entry.Name(), err))
+			continue
+		}
+		for _, obj := range objs {
+			if obj.GetKind() == "ConstraintTemplate" && obj.GroupVersionKind().Group == "templates.gatekeeper.sh" {
+				return obj, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no ConstraintTemplate found in %s", dir)
+}
+
+// loadMatch returns the content of match.yaml in dir, if any.
+func loadMatch(dir string) (map[string]interface{}, error) {
+	path := filepath.Join(dir, matchFileName)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	var match map[string]interface{}
+	if err := pkg.LoadYamlFileToObject(path, &match); err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return match, nil
+}
+
+func isYaml(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
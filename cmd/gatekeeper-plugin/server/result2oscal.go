@@ -0,0 +1,255 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	typegatekeeper "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/gatekeeper"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+type ResultToOscal struct {
+	policy      policy.Policy
+	constraints []*typegatekeeper.Constraint
+}
+
+func NewResultToOscal(pl policy.Policy, constraints []*typegatekeeper.Constraint) *ResultToOscal {
+	return &ResultToOscal{
+		policy:      pl,
+		constraints: constraints,
+	}
+}
+
+func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
+	var observations []policy.ObservationByCheck
+	for _, rule := range r.policy {
+		for _, check := range rule.Checks {
+			observation := policy.ObservationByCheck{
+				Title:       rule.Rule.ID,
+				CheckID:     check.ID,
+				Description: fmt.Sprintf("Observation of check %s", check.ID),
+				Methods:     []string{"TEST-AUTOMATED"},
+				Props: []policy.Property{
+					pluginutil.MakeProp("assessment-rule-id", rule.Rule.ID),
+				},
+				Collected: time.Now(),
+				Subjects:  []policy.Subject{},
+			}
+			constraint := r.findConstraint(check.ID)
+			if constraint != nil {
+				observation.Props = append(observation.Props, pluginutil.MakeProp("constraint-kind", constraint.Kind))
+				if constraint.Spec.EnforcementAction != "" {
+					observation.Props = append(observation.Props, pluginutil.MakeProp("enforcement-action", constraint.Spec.EnforcementAction))
+				}
+				if constraint.Status.TotalViolations != nil {
+					observation.Props = append(observation.Props, pluginutil.MakeProp("total-violations", strconv.FormatInt(*constraint.Status.TotalViolations, 10)))
+				}
+				observation.Subjects = mapSubjects(constraint)
+				observation.RelevantEvidences = []policy.Link{
+					{
+						Description: fmt.Sprintf("Constraint %s/%s", constraint.Kind, constraint.Name),
+						Href:        constraintHref(constraint),
+					},
+				}
+			}
+			observations = append(observations, observation)
+		}
+	}
+	return policy.PVPResult{
+		ObservationsByCheck: observations,
+	}, nil
+}
+
+// findConstraint returns the Constraint generated for the check, matching by the check ID
+// annotation first and by name otherwise.
+func (r *ResultToOscal) findConstraint(checkID string) *typegatekeeper.Constraint {
+	for _, constraint := range r.constraints {
+		if constraint.Annotations[AnnotationCheckID] == checkID {
+			return constraint
+		}
+	}
+	for _, constraint := range r.constraints {
+		if constraint.Name == pluginutil.ToDNSCompliant(checkID) {
+			return constraint
+		}
+	}
+	return nil
+}
+
+// mapSubjects converts the audit status of a Constraint into subjects. Each listed violation becomes
+// a subject. A Constraint without listed violations is reported as a single subject for the Constraint.
+func mapSubjects(constraint *typegatekeeper.Constraint) []policy.Subject {
+	status := constraint.Status
+	if status.AuditTimestamp == "" {
+		return []policy.Subject{constraintSubject(constraint, policy.ResultError, "constraint has not been audited")}
+	}
+
+	evaluatedOn := mapTimestamp(status.AuditTimestamp)
+	if len(status.Violations) == 0 {
+		if status.TotalViolations != nil && *status.TotalViolations > 0 {
+			reason := fmt.Sprintf("%d violations found", *status.TotalViolations)
+			return []policy.Subject{constraintSubject(constraint, mapEnforcementAction(constraint.Spec.EnforcementAction), reason)}
+		}
+		return []policy.Subject{constraintSubject(constraint, policy.ResultPass, "no violations found")}
+	}
+
+	var subjects []policy.Subject
+	for _, violation := range status.Violations {
+		apiVersion := schema.GroupVersion{Group: violation.Group, Version: violation.Version}.String()
+		gvknsn := fmt.Sprintf("ApiVersion: %s, Kind: %s, Namespace: %s, Name: %s", apiVersion, violation.Kind, violation.Namespace, violation.Name)
+		subjects = append(subjects, policy.Subject{
+			Title:       gvknsn,
+			ResourceID:  resourceID(apiVersion, violation.Kind, violation.Namespace, violation.Name),
+			Type:        "resource",
+			Result:      mapEnforcementAction(violation.EnforcementAction),
+			EvaluatedOn: evaluatedOn,
+			Reason:      violation.Message,
+			Props: []policy.Property{
+				pluginutil.MakeProp("enforcement-action", violation.EnforcementAction),
+			},
+		})
+	}
+	return subjects
+}
+
+func constraintSubject(constraint *typegatekeeper.Constraint, result policy.Result, reason string) policy.Subject {
+	evaluatedOn := time.Now()
+	if constraint.Status.AuditTimestamp != "" {
+		evaluatedOn = mapTimestamp(constraint.Status.AuditTimestamp)
+	}
+	return policy.Subject{
+		Title:       fmt.Sprintf("ApiVersion: %s, Kind: %s, Name: %s", constraint.APIVersion, constraint.Kind, constraint.Name),
+		ResourceID:  resourceID(constraint.APIVersion, constraint.Kind, "", constraint.Name),
+		Type:        "resource",
+		Result:      result,
+		EvaluatedOn: evaluatedOn,
+		Reason:      reason,
+	}
+}
+
+// mapEnforcementAction maps the enforcement action of a violation to a policy.Result.
+// Violations of Constraints in warn mode do not block admission and are reported as warnings.
+func mapEnforcementAction(enforcementAction string) policy.Result {
+	switch enforcementAction {
+	case "warn":
+		return policy.ResultWarning
+	case "", "deny", "dryrun", "scoped":
+		return policy.ResultFail
+	default:
+		return policy.ResultInvalid
+	}
+}
+
+// mapTimestamp parses the audit timestamp. If it cannot be parsed, the current time is used.
+func mapTimestamp(timestamp string) time.Time {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Now()
+	}
+	return t.UTC()
+}
+
+func resourceID(apiVersion, kind, namespace, name string) string {
+	tokens := []string{apiVersion, kind}
+	if namespace != "" {
+		tokens = append(tokens, namespace)
+	}
+	tokens = append(tokens, name)
+	return strings.Join(tokens, "/")
+}
+
+// constraintHref returns the API path of the Constraint. Gatekeeper names the resource of
+// a Constraint CRD after its lowercase kind.
+func constraintHref(constraint *typegatekeeper.Constraint) string {
+	return fmt.Sprintf("/apis/%s/%s/%s", constraint.APIVersion, strings.ToLower(constraint.Kind), constraint.Name)
+}
+
+// LoadConstraintsFromDirectory loads the Constraints from the YAML files in dir, such as
+// the output of `kubectl get constraints -o yaml`.
+func LoadConstraintsFromDirectory(dir string) ([]*typegatekeeper.Constraint, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var constraints []*typegatekeeper.Constraint
+	for _, entry := range entries {
+		if entry.IsDir() || !isYaml(entry.Name()) {
+			continue
+		}
+		objs, err := pkg.LoadYaml(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", entry.Name(), err)
+		}
+		for _, obj := range objs {
+			if obj.IsList() {
+				if err := obj.EachListItem(func(item runtime.Object) error {
+					return appendConstraint(&constraints, item.(*unstructured.Unstructured))
+				}); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := appendConstraint(&constraints, obj); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return constraints, nil
+}
+
+// LoadConstraintsFromCluster lists the Constraints of all kinds served by the cluster.
+func LoadConstraintsFromCluster(discoveryClient discovery.DiscoveryInterface, dyClient dynamic.Interface) ([]*typegatekeeper.Constraint, error) {
+	groupVersion := schema.GroupVersion{Group: typegatekeeper.ConstraintsGroup, Version: typegatekeeper.ConstraintsVersion}
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(groupVersion.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover constraint kinds: %w", err)
+	}
+	var constraints []*typegatekeeper.Constraint
+	for _, resource := range resources.APIResources {
+		// skip subresources such as status
+		if strings.Contains(resource.Name, "/") {
+			continue
+		}
+		unstList, err := dyClient.Resource(groupVersion.WithResource(resource.Name)).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", resource.Name, err)
+		}
+		for idx := range unstList.Items {
+			if err := appendConstraint(&constraints, &unstList.Items[idx]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return constraints, nil
+}
+
+func appendConstraint(constraints *[]*typegatekeeper.Constraint, obj *unstructured.Unstructured) error {
+	if obj.GroupVersionKind().Group != typegatekeeper.ConstraintsGroup {
+		return nil
+	}
+	constraint := typegatekeeper.Constraint{}
+	if err := pkg.ToK8sTypedObject(obj, &constraint); err != nil {
+		return fmt.Errorf("failed to convert constraint %s: %w", obj.GetName(), err)
+	}
+	*constraints = append(*constraints, &constraint)
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/hashicorp/go-hclog"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
+	typegatekeeper "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/gatekeeper"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var (
+	_      policy.Provider = (*Plugin)(nil)
+	logger hclog.Logger    = logging.NewPluginLogger()
+)
+
+func Logger() hclog.Logger {
+	return logger
+}
+
+type Plugin struct {
+	config Config
+}
+
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Configure(m map[string]string) error {
+	var config Config
+	if err := mapstructure.Decode(m, &config); err != nil {
+		return errors.New("error decoding configuration")
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	p.config = config
+	return nil
+}
+
+func (p *Plugin) Generate(pl policy.Policy) error {
+	logger.Debug(fmt.Sprintf("Using constraint templates from %s", p.config.PoliciesDir))
+	composer := NewOscal2Policy(p.config.PoliciesDir, p.config.OutputDir, p.config.EnforcementAction)
+	return composer.Generate(pl)
+}
+
+func (p *Plugin) GetResults(pl policy.Policy) (policy.PVPResult, error) {
+	var constraints []*typegatekeeper.Constraint
+	var err error
+	if p.config.Kubeconfig != "" {
+		constraints, err = p.loadConstraintsFromCluster()
+	} else {
+		constraints, err = LoadConstraintsFromDirectory(p.config.PolicyResultsDir)
+	}
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	results := NewResultToOscal(pl, constraints)
+	return results.GenerateResults()
+}
+
+func (p *Plugin) loadConstraintsFromCluster() ([]*typegatekeeper.Constraint, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", p.config.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig %s: %w", p.config.Kubeconfig, err)
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+	dyClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return LoadConstraintsFromCluster(discoveryClient, dyClient)
+}
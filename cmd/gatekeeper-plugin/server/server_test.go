@@ -0,0 +1,282 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/oscal-compass/oscal-sdk-go/settings"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	typegatekeeper "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/gatekeeper"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin/plugintest"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestOscal2Policy(t *testing.T) {
+	policyDir := pkg.PathFromPkgDirectory("./testdata/gatekeeper/policy-resources")
+	tempDir := t.TempDir()
+
+	policyExample := createPolicy(t)
+	o2p := NewOscal2Policy(policyDir, tempDir, "dryrun")
+	require.NoError(t, o2p.Generate(policyExample))
+
+	tests := []struct {
+		checkID        string
+		wantKind       string
+		wantParameters map[string]interface{}
+	}{
+		{
+			checkID:        "required-labels",
+			wantKind:       "K8sRequiredLabels",
+			wantParameters: map[string]interface{}{"labels": []interface{}{"owner", "team"}},
+		},
+		{
+			checkID:        "allowed-repos",
+			wantKind:       "K8sAllowedRepos",
+			wantParameters: map[string]interface{}{"repos": []interface{}{"registry.example.com/"}},
+		},
+		{
+			checkID:        "replica-limits",
+			wantKind:       "K8sReplicaLimits",
+			wantParameters: map[string]interface{}{"max_replicas": int64(5)},
+		},
+	}
+	for _, c := range tests {
+		t.Run(c.checkID, func(t *testing.T) {
+			require.FileExists(t, filepath.Join(tempDir, c.checkID, constraintTemplateFileName))
+
+			objs, err := pkg.LoadYaml(filepath.Join(tempDir, c.checkID, constraintFileName))
+			require.NoError(t, err)
+			require.Len(t, objs, 1)
+			constraint := objs[0]
+			require.Equal(t, c.wantKind, constraint.GetKind())
+			require.Equal(t, c.checkID, constraint.GetName())
+			require.Equal(t, c.checkID, constraint.GetAnnotations()[AnnotationCheckID])
+
+			enforcementAction, _, _ := unstructured.NestedString(constraint.Object, "spec", "enforcementAction")
+			require.Equal(t, "dryrun", enforcementAction)
+			parameters, _, _ := unstructured.NestedMap(constraint.Object, "spec", "parameters")
+			require.Equal(t, c.wantParameters, parameters)
+			_, found, _ := unstructured.NestedSlice(constraint.Object, "spec", "match", "kinds")
+			require.True(t, found)
+		})
+	}
+
+	traversal := policy.Policy{
+		{
+			RuleSet: extensions.RuleSet{
+				Rule:   extensions.Rule{ID: "traversal"},
+				Checks: []extensions.Check{{ID: "../required-labels"}},
+			},
+		},
+	}
+	require.EqualError(t, o2p.Generate(traversal), "invalid check id \"../required-labels\": must be a file name")
+}
+
+func TestConvertParameter(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		schema  map[string]interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "String", value: "foo", schema: map[string]interface{}{"type": "string"}, want: "foo"},
+		{name: "NoSchema", value: "foo", want: "foo"},
+		{name: "Integer", value: " 3 ", schema: map[string]interface{}{"type": "integer"}, want: int64(3)},
+		{name: "Number", value: "1.5", schema: map[string]interface{}{"type": "number"}, want: 1.5},
+		{name: "Boolean", value: "true", schema: map[string]interface{}{"type": "boolean"}, want: true},
+		{
+			name:   "IntegerArray",
+			value:  "1, 2,",
+			schema: map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}},
+			want:   []interface{}{int64(1), int64(2)},
+		},
+		{name: "Object", value: `{"a":"b"}`, schema: map[string]interface{}{"type": "object"}, want: map[string]interface{}{"a": "b"}},
+		{name: "InvalidInteger", value: "five", schema: map[string]interface{}{"type": "integer"}, wantErr: true},
+		{name: "InvalidObject", value: "a=b", schema: map[string]interface{}{"type": "object"}, wantErr: true},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := convertParameter(c.value, c.schema)
+			if c.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestResult2Oscal(t *testing.T) {
+	constraints, err := LoadConstraintsFromDirectory(pkg.PathFromPkgDirectory("./testdata/gatekeeper/constraint-results"))
+	require.NoError(t, err)
+	require.Len(t, constraints, 3)
+
+	testPolicy := createPolicy(t)
+	reporter := NewResultToOscal(testPolicy, constraints)
+	results, err := reporter.GenerateResults()
+	require.NoError(t, err)
+	require.Len(t, results.ObservationsByCheck, 3)
+
+	observations := map[string]policy.ObservationByCheck{}
+	for _, observation := range results.ObservationsByCheck {
+		observations[observation.CheckID] = observation
+	}
+	auditTime := time.Date(2025, 3, 20, 10, 15, 30, 0, time.UTC)
+
+	requiredLabels := observations["required-labels"]
+	wantProps := []policy.Property{
+		{Name: "assessment-rule-id", Value: "required-labels"},
+		{Name: "constraint-kind", Value: "K8sRequiredLabels"},
+		{Name: "enforcement-action", Value: "dryrun"},
+		{Name: "total-violations", Value: "3"},
+	}
+	require.Equal(t, wantProps, requiredLabels.Props)
+	require.Equal(t, []policy.Link{
+		{
+			Description: "Constraint K8sRequiredLabels/required-labels",
+			Href:        "/apis/constraints.gatekeeper.sh/v1beta1/k8srequiredlabels/required-labels",
+		},
+	}, requiredLabels.RelevantEvidences)
+	require.Len(t, requiredLabels.Subjects, 2)
+	subject := requiredLabels.Subjects[0]
+	require.Equal(t, "ApiVersion: v1, Kind: Namespace, Namespace: , Name: default", subject.Title)
+	require.Equal(t, "v1/Namespace/default", subject.ResourceID)
+	require.Equal(t, policy.ResultFail, subject.Result)
+	require.Equal(t, auditTime, subject.EvaluatedOn)
+	require.Equal(t, `you must provide labels: {"owner", "team"}`, subject.Reason)
+
+	allowedRepos := observations["allowed-repos"]
+	require.Len(t, allowedRepos.Subjects, 1)
+	require.Equal(t, policy.ResultPass, allowedRepos.Subjects[0].Result)
+	require.Equal(t, "constraints.gatekeeper.sh/v1beta1/K8sAllowedRepos/allowed-repos", allowedRepos.Subjects[0].ResourceID)
+	require.Equal(t, auditTime, allowedRepos.Subjects[0].EvaluatedOn)
+
+	replicaLimits := observations["replica-limits"]
+	require.Len(t, replicaLimits.Subjects, 1)
+	require.Equal(t, policy.ResultError, replicaLimits.Subjects[0].Result)
+	require.Equal(t, "constraint has not been audited", replicaLimits.Subjects[0].Reason)
+}
+
+func TestMapSubjectsWithoutListedViolations(t *testing.T) {
+	total := int64(25)
+	constraint := &typegatekeeper.Constraint{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "constraints.gatekeeper.sh/v1beta1", Kind: "K8sAllowedRepos"},
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed-repos"},
+		Spec:       typegatekeeper.ConstraintSpec{EnforcementAction: "warn"},
+		Status: typegatekeeper.ConstraintStatus{
+			AuditTimestamp:  "2025-03-20T10:15:30Z",
+			TotalViolations: &total,
+		},
+	}
+	subjects := mapSubjects(constraint)
+	require.Len(t, subjects, 1)
+	require.Equal(t, policy.ResultWarning, subjects[0].Result)
+	require.Equal(t, "25 violations found", subjects[0].Reason)
+}
+
+func TestMapEnforcementAction(t *testing.T) {
+	tests := []struct {
+		enforcementAction string
+		want              policy.Result
+	}{
+		{enforcementAction: "", want: policy.ResultFail},
+		{enforcementAction: "deny", want: policy.ResultFail},
+		{enforcementAction: "dryrun", want: policy.ResultFail},
+		{enforcementAction: "warn", want: policy.ResultWarning},
+		{enforcementAction: "unknown", want: policy.ResultInvalid},
+	}
+	for _, c := range tests {
+		t.Run(c.enforcementAction, func(t *testing.T) {
+			require.Equal(t, c.want, mapEnforcementAction(c.enforcementAction))
+		})
+	}
+}
+
+func TestLoadConstraintsFromCluster(t *testing.T) {
+	constraint := &unstructured.Unstructured{}
+	constraint.SetAPIVersion("constraints.gatekeeper.sh/v1beta1")
+	constraint.SetKind("K8sRequiredLabels")
+	constraint.SetName("required-labels")
+	constraint.SetAnnotations(map[string]string{AnnotationCheckID: "required-labels"})
+
+	gvr := schema.GroupVersionResource{Group: typegatekeeper.ConstraintsGroup, Version: typegatekeeper.ConstraintsVersion, Resource: "k8srequiredlabels"}
+	dyClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvr: "K8sRequiredLabelsList",
+	})
+	_, err := dyClient.Resource(gvr).Create(context.TODO(), constraint, metav1.CreateOptions{})
+	require.NoError(t, err)
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "constraints.gatekeeper.sh/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "k8srequiredlabels", Kind: "K8sRequiredLabels"},
+				{Name: "k8srequiredlabels/status", Kind: "K8sRequiredLabels"},
+			},
+		},
+	}
+
+	constraints, err := LoadConstraintsFromCluster(discoveryClient, dyClient)
+	require.NoError(t, err)
+	require.Len(t, constraints, 1)
+	require.Equal(t, "required-labels", constraints[0].Name)
+	require.Equal(t, "K8sRequiredLabels", constraints[0].Kind)
+}
+
+func TestConfigure(t *testing.T) {
+	plugin := NewPlugin()
+	err := plugin.Configure(map[string]string{})
+	require.EqualError(t, err, "policy directory must be set\noutput directory must be set")
+
+	configuration := map[string]string{
+		"policy-dir": "not-exist",
+		"output-dir": t.TempDir(),
+	}
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "path \"not-exist\": stat not-exist: no such file or directory")
+
+	policyDir := pkg.PathFromPkgDirectory("./testdata/gatekeeper/policy-resources")
+	configuration["policy-dir"] = policyDir
+	configuration["enforcement-action"] = "dryrun"
+	err = plugin.Configure(configuration)
+	require.NoError(t, err)
+	require.Equal(t, "dryrun", plugin.config.EnforcementAction)
+
+	configuration["enforcement-action"] = "block"
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "invalid enforcement action \"block\": must be one of deny, dryrun, warn")
+	require.Equal(t, "dryrun", plugin.config.EnforcementAction)
+
+	// options of an earlier configuration are not kept
+	delete(configuration, "enforcement-action")
+	require.NoError(t, plugin.Configure(configuration))
+	require.Empty(t, plugin.config.EnforcementAction)
+}
+
+func createPolicy(t *testing.T) policy.Policy {
+	// apply the parameter values as the framework does before calling the plugin
+	testSettings := settings.NewSettings(
+		map[string]struct{}{"required-labels": {}, "allowed-repos": {}, "replica-limits": {}},
+		map[string]string{"labels": "owner,team", "repos": "registry.example.com/", "max_replicas": "5"},
+	)
+	return plugintest.LoadPolicy(t, pkg.PathFromPkgDirectory("./testdata/gatekeeper/component-definition.json"), "Gatekeeper", testSettings)
+}
@@ -0,0 +1,25 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	hplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/cmd/kube-bench-plugin/server"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+)
+
+func main() {
+	kubeBenchPlugin := server.NewPlugin()
+	plugins := map[string]hplugin.Plugin{
+		plugin.PVPPluginName: &plugin.PVPPlugin{Impl: kubeBenchPlugin},
+	}
+	config := plugin.ServeConfig{
+		PluginSet: plugins,
+		Logger:    server.Logger(),
+	}
+	plugin.Register(config)
+}
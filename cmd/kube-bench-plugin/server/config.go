@@ -0,0 +1,52 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+)
+
+type Config struct {
+	// PolicyResultsDir is a directory of kube-bench JSON results, one file per node named after the node.
+	PolicyResultsDir string `mapstructure:"policy-results-dir"`
+	OutputDir        string `mapstructure:"output-dir"`
+	// Benchmark is the kube-bench benchmark to run, such as cis-1.8. kube-bench detects it when it is not set.
+	Benchmark string `mapstructure:"benchmark"`
+	// CheckIDPrefix is prepended to the kube-bench test numbers to get the check IDs.
+	CheckIDPrefix string `mapstructure:"check-id-prefix"`
+	// Image is the kube-bench image of the generated Job. It defaults to DefaultImage.
+	Image string `mapstructure:"image"`
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if err := pluginutil.CheckRequired("policy results directory", c.PolicyResultsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.PolicyResultsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// CheckID returns the check ID of a kube-bench test number.
+func (c Config) CheckID(testNumber string) string {
+	return c.CheckIDPrefix + testNumber
+}
+
+// TestNumber returns the kube-bench test number of a check, or false if the check ID does not have the prefix.
+func (c Config) TestNumber(checkID string) (string, bool) {
+	if !strings.HasPrefix(checkID, c.CheckIDPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(checkID, c.CheckIDPrefix), true
+}
@@ -0,0 +1,233 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	runConfigFileName = "kube-bench-run.yaml"
+	jobFileName       = "kube-bench-job.yaml"
+	jobName           = "kube-bench"
+	// DefaultImage is the kube-bench image of the generated Job.
+	DefaultImage = "docker.io/aquasec/kube-bench:latest"
+)
+
+// targetsBySection are the kube-bench targets of the sections of the CIS Kubernetes Benchmarks.
+var targetsBySection = map[string]string{
+	"1": "master",
+	"2": "etcd",
+	"3": "controlplane",
+	"4": "node",
+	"5": "policies",
+}
+
+// hostPaths are the host directories kube-bench inspects, mounted read-only in the Job
+// as in the upstream kube-bench job.yaml.
+var hostPaths = []struct {
+	name      string
+	path      string
+	mountPath string
+}{
+	{name: "var-lib-etcd", path: "/var/lib/etcd"},
+	{name: "var-lib-kubelet", path: "/var/lib/kubelet"},
+	{name: "var-lib-kube-scheduler", path: "/var/lib/kube-scheduler"},
+	{name: "var-lib-kube-controller-manager", path: "/var/lib/kube-controller-manager"},
+	{name: "etc-systemd", path: "/etc/systemd"},
+	{name: "lib-systemd", path: "/lib/systemd"},
+	{name: "srv-kubernetes", path: "/srv/kubernetes"},
+	{name: "etc-kubernetes", path: "/etc/kubernetes"},
+	{name: "usr-bin", path: "/usr/bin", mountPath: "/usr/local/mount-from-host/bin"},
+	{name: "etc-cni-netd", path: "/etc/cni/net.d"},
+	{name: "opt-cni-bin", path: "/opt/cni/bin"},
+}
+
+// RunConfig selects the checks and targets of a kube-bench run. Args are the arguments of the kube-bench
+// command, for example for the container of a kube-bench Job.
+type RunConfig struct {
+	Benchmark string   `json:"benchmark,omitempty"`
+	Targets   []string `json:"targets"`
+	Checks    []string `json:"checks"`
+	Args      []string `json:"args"`
+}
+
+// Oscal2Policy generates the kube-bench run configuration and Job of the OSCAL checks.
+type Oscal2Policy struct {
+	config Config
+	logger hclog.Logger
+}
+
+func NewOscal2Policy(config Config) *Oscal2Policy {
+	return &Oscal2Policy{
+		config: config,
+		logger: logger.Named("composer"),
+	}
+}
+
+func (c *Oscal2Policy) Generate(pl policy.Policy) error {
+	runConfig, err := c.RunConfig(pl)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.config.OutputDir, 0o755); err != nil {
+		return err
+	}
+	if err := pkg.WriteObjToYamlFile(filepath.Join(c.config.OutputDir, runConfigFileName), runConfig); err != nil {
+		return err
+	}
+	return pkg.WriteObjToYamlFile(filepath.Join(c.config.OutputDir, jobFileName), c.Job(runConfig))
+}
+
+// Job returns the Job that runs kube-bench with the arguments of the run configuration
+// on a node. The JSON results are written to the logs of the Job.
+func (c *Oscal2Policy) Job(runConfig *RunConfig) *batchv1.Job {
+	image := c.config.Image
+	if image == "" {
+		image = DefaultImage
+	}
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	for _, hostPath := range hostPaths {
+		mountPath := hostPath.mountPath
+		if mountPath == "" {
+			mountPath = hostPath.path
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: hostPath.name,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: hostPath.path},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      hostPath.name,
+			MountPath: mountPath,
+			ReadOnly:  true,
+		})
+	}
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: jobName,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					HostPID:       true,
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:         jobName,
+							Image:        image,
+							Command:      []string{"kube-bench"},
+							Args:         runConfig.Args,
+							VolumeMounts: volumeMounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+}
+
+// RunConfig returns the run configuration of the policy. The targets are the ones of the
+// sections of the selected checks.
+func (c *Oscal2Policy) RunConfig(pl policy.Policy) (*RunConfig, error) {
+	checks := map[string]struct{}{}
+	targets := map[string]struct{}{}
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			c.logger.Debug(fmt.Sprintf("processing check %s for rule %s", check.ID, ruleSet.Rule.ID))
+			testNumber, ok := c.config.TestNumber(check.ID)
+			if !ok {
+				return nil, fmt.Errorf("check %s does not have the prefix %q", check.ID, c.config.CheckIDPrefix)
+			}
+			section := strings.SplitN(testNumber, ".", 2)[0]
+			target, ok := targetsBySection[section]
+			if !ok {
+				return nil, fmt.Errorf("check %s: unknown section %s of test number %s", check.ID, section, testNumber)
+			}
+			checks[testNumber] = struct{}{}
+			targets[target] = struct{}{}
+		}
+	}
+
+	runConfig := &RunConfig{
+		Benchmark: c.config.Benchmark,
+		Targets:   sortedTargets(targets),
+		Checks:    sortedTestNumbers(checks),
+	}
+	runConfig.Args = []string{"run"}
+	if runConfig.Benchmark != "" {
+		runConfig.Args = append(runConfig.Args, "--benchmark", runConfig.Benchmark)
+	}
+	runConfig.Args = append(runConfig.Args,
+		"--targets", strings.Join(runConfig.Targets, ","),
+		"--check", strings.Join(runConfig.Checks, ","),
+		"--json",
+	)
+	return runConfig, nil
+}
+
+// sortedTargets returns the targets in the order of the benchmark sections.
+func sortedTargets(targets map[string]struct{}) []string {
+	var sections []string
+	for section, target := range targetsBySection {
+		if _, ok := targets[target]; ok {
+			sections = append(sections, section)
+		}
+	}
+	sort.Strings(sections)
+	result := []string{}
+	for _, section := range sections {
+		result = append(result, targetsBySection[section])
+	}
+	return result
+}
+
+// sortedTestNumbers sorts test numbers by their numeric components, so that 1.2.10 comes after 1.2.9.
+func sortedTestNumbers(testNumbers map[string]struct{}) []string {
+	result := []string{}
+	for testNumber := range testNumbers {
+		result = append(result, testNumber)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return compareTestNumbers(result[i], result[j]) < 0
+	})
+	return result
+}
+
+func compareTestNumbers(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for idx := 0; idx < len(as) && idx < len(bs); idx++ {
+		an, aErr := strconv.Atoi(as[idx])
+		bn, bErr := strconv.Atoi(bs[idx])
+		switch {
+		case aErr == nil && bErr == nil && an != bn:
+			return an - bn
+		case (aErr != nil || bErr != nil) && as[idx] != bs[idx]:
+			return strings.Compare(as[idx], bs[idx])
+		}
+	}
+	return len(as) - len(bs)
+}
@@ -0,0 +1,247 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	typekubebench "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/kubebench"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// NodeResult is the kube-bench output of a node.
+type NodeResult struct {
+	Node string
+	// Path is the path of the results file relative to the results directory.
+	Path    string
+	ModTime time.Time
+	Output  typekubebench.Output
+}
+
+type ResultToOscal struct {
+	policy      policy.Policy
+	nodeResults []NodeResult
+	config      Config
+}
+
+func NewResultToOscal(pl policy.Policy, nodeResults []NodeResult, config Config) *ResultToOscal {
+	return &ResultToOscal{
+		policy:      pl,
+		nodeResults: nodeResults,
+		config:      config,
+	}
+}
+
+func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
+	var inventoryItems []policy.InventoryItem
+	for _, nodeResult := range r.nodeResults {
+		inventoryItems = append(inventoryItems, newNodeInventoryItem(nodeResult))
+	}
+
+	var observations []policy.ObservationByCheck
+	for _, rule := range r.policy {
+		for _, check := range rule.Checks {
+			logger.Debug(fmt.Sprintf("processing check %s for rule %s", check.ID, rule.Rule.ID))
+			observation := policy.ObservationByCheck{
+				Title:       rule.Rule.ID,
+				CheckID:     check.ID,
+				Description: fmt.Sprintf("Observation of check %s", check.ID),
+				Methods:     []string{"TEST-AUTOMATED"},
+				Props: []policy.Property{
+					pluginutil.MakeProp("assessment-rule-id", rule.Rule.ID),
+				},
+				Collected: time.Now(),
+				Subjects:  []policy.Subject{},
+			}
+			testNumber, ok := r.config.TestNumber(check.ID)
+			if !ok {
+				logger.Warn(fmt.Sprintf("check %s does not have the prefix %q, skipping", check.ID, r.config.CheckIDPrefix))
+				observations = append(observations, observation)
+				continue
+			}
+			observation.Props = append(observation.Props, pluginutil.MakeProp("kube-bench-test-number", testNumber))
+			for _, nodeResult := range r.nodeResults {
+				nodeType, kubeBenchCheck := findCheck(nodeResult.Output, testNumber)
+				if kubeBenchCheck == nil {
+					continue
+				}
+				observation.Subjects = append(observation.Subjects, newNodeSubject(nodeResult, nodeType, kubeBenchCheck))
+				observation.RelevantEvidences = append(observation.RelevantEvidences, evidence(nodeResult))
+			}
+			observations = append(observations, observation)
+		}
+	}
+	return policy.PVPResult{
+		ObservationsByCheck: observations,
+		InventoryItems:      inventoryItems,
+	}, nil
+}
+
+func newNodeSubject(nodeResult NodeResult, nodeType string, check *typekubebench.Check) policy.Subject {
+	props := []policy.Property{
+		pluginutil.MakeProp("status", check.Status),
+		pluginutil.MakeProp("scored", strconv.FormatBool(check.Scored)),
+	}
+	optionalProps := []policy.Property{
+		pluginutil.MakeProp("node-type", nodeType),
+		pluginutil.MakeProp("actual-value", check.ActualValue),
+		pluginutil.MakeProp("expected-result", check.ExpectedResult),
+		pluginutil.MakeProp("remediation", strings.TrimSpace(check.Remediation)),
+		pluginutil.MakeProp("audit", strings.TrimSpace(check.Audit)),
+		pluginutil.MakeProp("audit-config", strings.TrimSpace(check.AuditConfig)),
+		pluginutil.MakeProp("audit-env", strings.TrimSpace(check.AuditEnv)),
+	}
+	for _, prop := range optionalProps {
+		if prop.Value != "" {
+			props = append(props, prop)
+		}
+	}
+
+	reason := strings.TrimSpace(check.Reason)
+	if reason == "" {
+		reason = fmt.Sprintf("kube-bench status: %s", check.Status)
+	}
+	return policy.Subject{
+		Title:       nodeResult.Node,
+		ResourceID:  nodeResult.Node,
+		Type:        "inventory-item",
+		Result:      mapStatus(check.Status),
+		EvaluatedOn: nodeResult.ModTime,
+		Reason:      reason,
+		Props:       props,
+	}
+}
+
+// newNodeInventoryItem returns the inventory item of a node. Node subjects reference it by the node name.
+func newNodeInventoryItem(nodeResult NodeResult) policy.InventoryItem {
+	props := []policy.Property{
+		pluginutil.MakeProp("asset-type", "operating-system"),
+	}
+	seen := map[string]struct{}{}
+	for _, controls := range nodeResult.Output.Controls {
+		if _, ok := seen[controls.NodeType]; ok || controls.NodeType == "" {
+			continue
+		}
+		seen[controls.NodeType] = struct{}{}
+		props = append(props, pluginutil.MakeProp("node-type", controls.NodeType))
+	}
+	if len(nodeResult.Output.Controls) > 0 {
+		controls := nodeResult.Output.Controls[0]
+		if controls.Version != "" {
+			props = append(props, pluginutil.MakeProp("benchmark", controls.Version))
+		}
+		if controls.DetectedVersion != "" {
+			props = append(props, pluginutil.MakeProp("kubernetes-version", controls.DetectedVersion))
+		}
+	}
+	return policy.InventoryItem{
+		ID:          nodeResult.Node,
+		Description: fmt.Sprintf("Kubernetes node %s", nodeResult.Node),
+		Props:       props,
+	}
+}
+
+func evidence(nodeResult NodeResult) policy.Link {
+	link := policy.Link{
+		Description: fmt.Sprintf("kube-bench results of node %s", nodeResult.Node),
+		Href:        nodeResult.Path,
+	}
+	if len(nodeResult.Output.Controls) > 0 && nodeResult.Output.Controls[0].Version != "" {
+		link.Props = append(link.Props, pluginutil.MakeProp("benchmark", nodeResult.Output.Controls[0].Version))
+	}
+	return link
+}
+
+// findCheck returns the result of a test number and the node type of its controls.
+func findCheck(output typekubebench.Output, testNumber string) (string, *typekubebench.Check) {
+	for _, controls := range output.Controls {
+		for _, group := range controls.Groups {
+			for idx := range group.Checks {
+				if group.Checks[idx].ID == testNumber {
+					return controls.NodeType, &group.Checks[idx]
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+// mapStatus maps the status of a kube-bench check to a policy.Result.
+// Manual checks are reported by kube-bench as WARN.
+func mapStatus(status string) policy.Result {
+	switch status {
+	case typekubebench.StatusPass, typekubebench.StatusInfo:
+		return policy.ResultPass
+	case typekubebench.StatusFail:
+		return policy.ResultFail
+	case typekubebench.StatusWarn:
+		return policy.ResultWarning
+	default:
+		return policy.ResultInvalid
+	}
+}
+
+// LoadNodeResults loads the kube-bench JSON results in dir and its subdirectories. Each file holds the
+// results of the node it is named after. Results are sorted by node.
+func LoadNodeResults(dir string) ([]NodeResult, error) {
+	var nodeResults []NodeResult
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		output, err := loadOutput(path)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", relPath, err)
+		}
+		nodeResults = append(nodeResults, NodeResult{
+			Node:    strings.TrimSuffix(d.Name(), ".json"),
+			Path:    relPath,
+			ModTime: info.ModTime().UTC(),
+			Output:  output,
+		})
+		return nil
+	})
+	sort.SliceStable(nodeResults, func(i, j int) bool {
+		return nodeResults[i].Node < nodeResults[j].Node
+	})
+	return nodeResults, err
+}
+
+// loadOutput loads the output of kube-bench. Versions before 0.6 write the list of controls without totals.
+func loadOutput(path string) (typekubebench.Output, error) {
+	output := typekubebench.Output{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return output, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err = json.Unmarshal(data, &output.Controls)
+	} else {
+		err = json.Unmarshal(data, &output)
+	}
+	return output, err
+}
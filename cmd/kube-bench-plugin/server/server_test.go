@@ -0,0 +1,234 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/oscal-compass/oscal-sdk-go/settings"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin/plugintest"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestOscal2Policy(t *testing.T) {
+	tempDir := t.TempDir()
+	config := Config{
+		OutputDir:     tempDir,
+		Benchmark:     "cis-1.8",
+		CheckIDPrefix: "cis-",
+	}
+	require.NoError(t, NewOscal2Policy(config).Generate(createPolicy(t)))
+
+	runConfig := RunConfig{}
+	require.NoError(t, pkg.LoadYamlFileToObject(filepath.Join(tempDir, runConfigFileName), &runConfig))
+	require.Equal(t, RunConfig{
+		Benchmark: "cis-1.8",
+		Targets:   []string{"master", "node"},
+		Checks:    []string{"1.2.1", "1.2.10", "4.2.1"},
+		Args:      []string{"run", "--benchmark", "cis-1.8", "--targets", "master,node", "--check", "1.2.1,1.2.10,4.2.1", "--json"},
+	}, runConfig)
+
+	job := batchv1.Job{}
+	require.NoError(t, pkg.LoadYamlFileToObject(filepath.Join(tempDir, jobFileName), &job))
+	require.Equal(t, "Job", job.Kind)
+	podSpec := job.Spec.Template.Spec
+	require.True(t, podSpec.HostPID)
+	require.Len(t, podSpec.Containers, 1)
+	require.Equal(t, DefaultImage, podSpec.Containers[0].Image)
+	require.Equal(t, []string{"kube-bench"}, podSpec.Containers[0].Command)
+	require.Equal(t, runConfig.Args, podSpec.Containers[0].Args)
+	require.Len(t, podSpec.Containers[0].VolumeMounts, len(podSpec.Volumes))
+
+	config.Image = "registry.example.com/kube-bench:v0.10.0"
+	require.Equal(t, config.Image, NewOscal2Policy(config).Job(&runConfig).Spec.Template.Spec.Containers[0].Image)
+
+	// checks without the prefix cannot be mapped to test numbers
+	config.CheckIDPrefix = "cis-k8s-"
+	_, err := NewOscal2Policy(config).RunConfig(createPolicy(t))
+	require.ErrorContains(t, err, "does not have the prefix \"cis-k8s-\"")
+}
+
+func TestCompareTestNumbers(t *testing.T) {
+	testNumbers := map[string]struct{}{"1.2.10": {}, "1.2.9": {}, "1.1.1": {}, "4.2.1": {}, "1.2": {}, "5.1.1a": {}, "5.1.1": {}}
+	require.Equal(t, []string{"1.1.1", "1.2", "1.2.9", "1.2.10", "4.2.1", "5.1.1", "5.1.1a"}, sortedTestNumbers(testNumbers))
+}
+
+func TestLoadNodeResults(t *testing.T) {
+	nodeResults, err := LoadNodeResults(pkg.PathFromPkgDirectory("./testdata/kube-bench/results"))
+	require.NoError(t, err)
+	require.Len(t, nodeResults, 2)
+
+	require.Equal(t, "control-plane-1", nodeResults[0].Node)
+	require.Equal(t, "control-plane-1.json", nodeResults[0].Path)
+	require.Len(t, nodeResults[0].Output.Controls, 2)
+	require.Equal(t, 2, nodeResults[0].Output.Totals.Pass)
+	require.Equal(t, 1, nodeResults[0].Output.Controls[0].Warn)
+
+	// the list of controls written by older versions
+	require.Equal(t, "worker-1", nodeResults[1].Node)
+	require.Len(t, nodeResults[1].Output.Controls, 1)
+	require.Equal(t, "node", nodeResults[1].Output.Controls[0].NodeType)
+}
+
+func TestResult2Oscal(t *testing.T) {
+	nodeResults, err := LoadNodeResults(pkg.PathFromPkgDirectory("./testdata/kube-bench/results"))
+	require.NoError(t, err)
+
+	reporter := NewResultToOscal(createPolicy(t), nodeResults, Config{CheckIDPrefix: "cis-"})
+	results, err := reporter.GenerateResults()
+	require.NoError(t, err)
+
+	require.Equal(t, []policy.InventoryItem{
+		{
+			ID:          "control-plane-1",
+			Description: "Kubernetes node control-plane-1",
+			Props: []policy.Property{
+				{Name: "asset-type", Value: "operating-system"},
+				{Name: "node-type", Value: "master"},
+				{Name: "node-type", Value: "node"},
+				{Name: "benchmark", Value: "cis-1.8"},
+				{Name: "kubernetes-version", Value: "1.27"},
+			},
+		},
+		{
+			ID:          "worker-1",
+			Description: "Kubernetes node worker-1",
+			Props: []policy.Property{
+				{Name: "asset-type", Value: "operating-system"},
+				{Name: "node-type", Value: "node"},
+				{Name: "benchmark", Value: "cis-1.8"},
+				{Name: "kubernetes-version", Value: "1.27"},
+			},
+		},
+	}, results.InventoryItems)
+
+	type nodeResult struct {
+		node   string
+		result policy.Result
+		reason string
+	}
+	wantResults := map[string][]nodeResult{
+		"cis-1.2.1": {
+			{node: "control-plane-1", result: policy.ResultPass, reason: "kube-bench status: PASS"},
+		},
+		"cis-1.2.10": {
+			{node: "control-plane-1", result: policy.ResultWarning, reason: "Test marked as a manual test"},
+		},
+		"cis-4.2.1": {
+			{node: "control-plane-1", result: policy.ResultPass, reason: "kube-bench status: PASS"},
+			{node: "worker-1", result: policy.ResultFail, reason: "kube-bench status: FAIL"},
+		},
+	}
+	require.Len(t, results.ObservationsByCheck, 3)
+	observations := map[string]policy.ObservationByCheck{}
+	for _, observation := range results.ObservationsByCheck {
+		observations[observation.CheckID] = observation
+		t.Run(observation.CheckID, func(t *testing.T) {
+			var got []nodeResult
+			for _, subject := range observation.Subjects {
+				require.Equal(t, "inventory-item", subject.Type)
+				require.Equal(t, subject.Title, subject.ResourceID)
+				got = append(got, nodeResult{node: subject.ResourceID, result: subject.Result, reason: subject.Reason})
+			}
+			require.Equal(t, wantResults[observation.CheckID], got)
+			require.Len(t, observation.RelevantEvidences, len(got))
+		})
+	}
+
+	observation := observations["cis-4.2.1"]
+	require.Equal(t, []policy.Property{
+		{Name: "assessment-rule-id", Value: "kubelet-anonymous-auth"},
+		{Name: "kube-bench-test-number", Value: "4.2.1"},
+	}, observation.Props)
+	require.Equal(t, policy.Link{
+		Description: "kube-bench results of node worker-1",
+		Href:        "worker-1.json",
+		Props:       []policy.Property{{Name: "benchmark", Value: "cis-1.8"}},
+	}, observation.RelevantEvidences[1])
+	require.Equal(t, []policy.Property{
+		{Name: "status", Value: "FAIL"},
+		{Name: "scored", Value: "true"},
+		{Name: "node-type", Value: "node"},
+		{Name: "actual-value", Value: "true"},
+		{Name: "expected-result", Value: "'{.authentication.anonymous.enabled}' is equal to 'false'"},
+		{Name: "remediation", Value: "If using a Kubelet config file, edit the file to set `authentication: anonymous: enabled` to `false`."},
+		{Name: "audit", Value: "/bin/ps -fC kubelet"},
+		{Name: "audit-config", Value: "/bin/cat /var/lib/kubelet/config.yaml"},
+	}, observation.Subjects[1].Props)
+}
+
+func TestMapStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   policy.Result
+	}{
+		{status: "PASS", want: policy.ResultPass},
+		{status: "INFO", want: policy.ResultPass},
+		{status: "FAIL", want: policy.ResultFail},
+		{status: "WARN", want: policy.ResultWarning},
+		{status: "SKIP", want: policy.ResultInvalid},
+	}
+	for _, c := range tests {
+		t.Run(c.status, func(t *testing.T) {
+			require.Equal(t, c.want, mapStatus(c.status))
+		})
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	plugin := NewPlugin()
+	err := plugin.Configure(map[string]string{})
+	require.EqualError(t, err, "policy results directory must be set")
+
+	configuration := map[string]string{
+		"policy-results-dir": "not-exist",
+	}
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "path \"not-exist\": stat not-exist: no such file or directory")
+
+	configuration["policy-results-dir"] = pkg.PathFromPkgDirectory("./testdata/kube-bench/results")
+	configuration["check-id-prefix"] = "cis-"
+	err = plugin.Configure(configuration)
+	require.NoError(t, err)
+	require.Equal(t, "cis-1.1.1", plugin.config.CheckID("1.1.1"))
+}
+
+func TestConformance(t *testing.T) {
+	outputDir := t.TempDir()
+	plugintest.Run(t, NewPlugin(), plugintest.Options{
+		Manifest: plugin.Manifest{
+			Configuration: []plugin.ConfigurationOption{
+				{Name: "policy-results-dir", Required: true},
+				{Name: "output-dir", Default: &outputDir},
+				{Name: "benchmark"},
+				{Name: "check-id-prefix"},
+			},
+		},
+		Configuration: map[string]string{
+			"policy-results-dir": pkg.PathFromPkgDirectory("./testdata/kube-bench/results"),
+			"output-dir":         outputDir,
+			"check-id-prefix":    "cis-",
+		},
+		InvalidConfiguration: map[string]string{"policy-results-dir": "not-exist"},
+		Policy:               createPolicy(t),
+		Results:              []policy.Result{policy.ResultPass, policy.ResultFail, policy.ResultWarning},
+	})
+}
+
+func createPolicy(t *testing.T) policy.Policy {
+	// apply the parameter values as the framework does before calling the plugin
+	testSettings := settings.NewSettings(
+		map[string]struct{}{"api-server-anonymous-auth": {}, "api-server-event-rate-limit": {}, "kubelet-anonymous-auth": {}},
+		map[string]string{},
+	)
+	return plugintest.LoadPolicy(t, pkg.PathFromPkgDirectory("./testdata/kube-bench/component-definition.json"), "kube-bench", testSettings)
+}
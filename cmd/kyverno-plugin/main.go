@@ -26,11 +26,12 @@ import (
 func main() {
 	kyvernoPlugin := server.NewPlugin()
 	plugins := map[string]hplugin.Plugin{
-		plugin.PVPPluginName: &plugin.PVPPlugin{Impl: kyvernoPlugin},
+		plugin.PVPPluginName:       &plugin.PVPPlugin{Impl: kyvernoPlugin},
+		plugin.CollectorPluginName: &plugin.CollectorPlugin{Impl: kyvernoPlugin},
 	}
 	config := plugin.ServeConfig{
 		PluginSet: plugins,
 		Logger:    server.Logger(),
 	}
-	plugin.Register(config)
+	plugin.Run(config)
 }
@@ -7,9 +7,8 @@ package server
 
 import (
 	"errors"
-	"fmt"
-	"os"
-	"path/filepath"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
 )
 
 type Config struct {
@@ -21,29 +20,17 @@ type Config struct {
 
 func (c Config) Validate() error {
 	var errs []error
-	if err := checkPath(&c.PoliciesDir); err != nil {
+	if err := pluginutil.CheckPath(c.PoliciesDir); err != nil {
 		errs = append(errs, err)
 	}
-	if err := checkPath(&c.PolicyResultsDir); err != nil {
+	if err := pluginutil.CheckPath(c.PolicyResultsDir); err != nil {
 		errs = append(errs, err)
 	}
-	if err := checkPath(&c.TempDir); err != nil {
+	if err := pluginutil.CheckPath(c.TempDir); err != nil {
 		errs = append(errs, err)
 	}
-	if err := checkPath(&c.OutputDir); err != nil {
+	if err := pluginutil.CheckPath(c.OutputDir); err != nil {
 		errs = append(errs, err)
 	}
 	return errors.Join(errs...)
 }
-
-func checkPath(path *string) error {
-	if path != nil && *path != "" {
-		cleanedPath := filepath.Clean(*path)
-		path = &cleanedPath
-		_, err := os.Stat(*path)
-		if err != nil {
-			return fmt.Errorf("path %q: %w", *path, err)
-		}
-	}
-	return nil
-}
@@ -18,18 +18,37 @@ package server
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"time"
+	"unicode"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	typepolr "sigs.k8s.io/wg-policy-prototypes/policy-report/pkg/api/wgpolicyk8s.io/v1beta1"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
+// The exported resources the results are generated from.
+const (
+	policiesFile             = "policies.kyverno.io.yaml"
+	clusterPoliciesFile      = "clusterpolicies.kyverno.io.yaml"
+	policyReportsFile        = "policyreports.wgpolicyk8s.io.yaml"
+	clusterPolicyReportsFile = "clusterpolicyreports.wgpolicyk8s.io.yaml"
+)
+
+var policyResultFiles = []string{policiesFile, clusterPoliciesFile, policyReportsFile, clusterPolicyReportsFile}
+
 type ResultToOscal struct {
 	policy                  policy.Policy
 	policyResultsDir        string
+	evidence                map[string]policy.Evidence
 	policyReportList        *typepolr.PolicyReportList
 	clusterPolicyReportList *typepolr.ClusterPolicyReportList
 	policyList              *kyvernov1.PolicyList
@@ -58,6 +77,44 @@ func NewResultToOscal(pl policy.Policy, policyResultsDir string) *ResultToOscal
 	return &r
 }
 
+// NewResultToOscalFromEvidence returns a ResultToOscal that generates the results from the exported
+// resources collected as evidence instead of reading them from the policy results directory.
+// The evidence of the first collector that collected all the exported resources is used.
+func NewResultToOscalFromEvidence(pl policy.Policy, evidence []policy.Evidence) *ResultToOscal {
+	r := NewResultToOscal(pl, "")
+	r.evidence = make(map[string]policy.Evidence)
+	var collectors []string
+	byCollector := make(map[string]map[string]policy.Evidence)
+	for _, e := range evidence {
+		named, ok := byCollector[e.Collector]
+		if !ok {
+			named = make(map[string]policy.Evidence)
+			byCollector[e.Collector] = named
+			collectors = append(collectors, e.Collector)
+		}
+		if _, ok := named[e.Name]; !ok {
+			named[e.Name] = e
+		}
+	}
+	for _, collector := range collectors {
+		if hasPolicyResultFiles(byCollector[collector]) {
+			r.evidence = byCollector[collector]
+			break
+		}
+	}
+	return r
+}
+
+// hasPolicyResultFiles returns whether the evidence includes all the exported resources.
+func hasPolicyResultFiles(evidence map[string]policy.Evidence) bool {
+	for _, name := range policyResultFiles {
+		if _, ok := evidence[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *ResultToOscal) retrievePolicyReportResults(name string) []*typepolr.PolicyReportResult {
 	prrs := []*typepolr.PolicyReportResult{}
 	for _, polr := range r.policyReportList.Items {
@@ -71,39 +128,55 @@ func (r *ResultToOscal) retrievePolicyReportResults(name string) []*typepolr.Pol
 	return prrs
 }
 
-func (r *ResultToOscal) loadData(path string, out interface{}) error {
-	if err := pkg.LoadYamlFileToK8sTypedObject(r.policyResultsDir+"/"+path, &out); err != nil {
+func (r *ResultToOscal) loadData(name string, out interface{}) error {
+	if r.evidence != nil {
+		evidence, ok := r.evidence[name]
+		if !ok {
+			return fmt.Errorf("missing evidence %s: %w", name, plugin.ErrEvaluationNotSupported)
+		}
+		return pkg.LoadByteToK8sTypedObject(evidence.Content, &out)
+	}
+	if err := pkg.LoadYamlFileToK8sTypedObject(filepath.Join(r.policyResultsDir, name), &out); err != nil {
 		return err
 	}
 	return nil
 }
 
-func makeProp(name string, value string) policy.Property {
-	return policy.Property{
-		Name:  name,
-		Value: value,
+// relevantEvidences returns links to the collected policy reports
+// the results are generated from.
+func (r *ResultToOscal) relevantEvidences() []policy.Link {
+	var links []policy.Link
+	for _, name := range []string{policyReportsFile, clusterPolicyReportsFile} {
+		if evidence, ok := r.evidence[name]; ok && evidence.Href != "" {
+			links = append(links, policy.Link{
+				Description: fmt.Sprintf("Collected %s", name),
+				Href:        evidence.Href,
+				Props:       []policy.Property{pluginutil.MakeProp("digest", evidence.Digest)},
+			})
+		}
 	}
+	return links
 }
 
 func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
 	var polList kyvernov1.PolicyList
-	if err := r.loadData("/policies.kyverno.io.yaml", &polList); err != nil {
+	if err := r.loadData(policiesFile, &polList); err != nil {
 		return policy.PVPResult{}, err
 	}
 
 	var cpolList kyvernov1.ClusterPolicyList
-	if err := r.loadData("/clusterpolicies.kyverno.io.yaml", &cpolList); err != nil {
+	if err := r.loadData(clusterPoliciesFile, &cpolList); err != nil {
 		return policy.PVPResult{}, err
 	}
 
 	var polrList typepolr.PolicyReportList
-	if err := r.loadData("/policyreports.wgpolicyk8s.io.yaml", &polrList); err != nil {
+	if err := r.loadData(policyReportsFile, &polrList); err != nil {
 		return policy.PVPResult{}, err
 	}
 	r.policyReportList = &polrList
 
 	var cpolrList typepolr.ClusterPolicyReportList
-	if err := r.loadData("/clusterpolicyreports.wgpolicyk8s.io.yaml", &cpolrList); err != nil {
+	if err := r.loadData(clusterPolicyReportsFile, &cpolrList); err != nil {
 		return policy.PVPResult{}, err
 	}
 
@@ -118,10 +191,11 @@ func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
 				Description: fmt.Sprintf("Observation of check %s", name),
 				Methods:     []string{"TEST-AUTOMATED"},
 				Props: []policy.Property{
-					makeProp("assessment-rule-id", rule.Rule.ID),
+					pluginutil.MakeProp("assessment-rule-id", rule.Rule.ID),
 				},
-				Collected: time.Now(),
-				Subjects:  []policy.Subject{},
+				Collected:         time.Now(),
+				Subjects:          []policy.Subject{},
+				RelevantEvidences: r.relevantEvidences(),
 			}
 			for _, prr := range prrs {
 				for _, resource := range prr.Subjects {
@@ -131,8 +205,9 @@ func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
 						ResourceID:  string(resource.UID),
 						Type:        "resource",
 						Result:      mapResults(prr.Result),
-						EvaluatedOn: time.Now(),
+						EvaluatedOn: mapTimestamp(prr.Timestamp),
 						Reason:      prr.Description,
+						Props:       mapProps(prr),
 					}
 					observation.Subjects = append(observation.Subjects, subject)
 				}
@@ -147,15 +222,69 @@ func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
 	return result, nil
 }
 
+// mapResults maps a PolicyReport result to a policy.Result.
 func mapResults(result typepolr.PolicyResult) policy.Result {
 	switch result {
 	case "pass":
 		return policy.ResultPass
-	case "fail", "warn":
+	case "fail":
 		return policy.ResultFail
+	case "warn":
+		return policy.ResultWarning
 	case "error":
 		return policy.ResultError
+	case "skip":
+		return policy.ResultSkipped
 	default:
 		return policy.ResultInvalid
 	}
 }
+
+// mapTimestamp returns the time the PolicyReport result was produced. If the
+// report does not carry a timestamp, the current time is used.
+func mapTimestamp(timestamp metav1.Timestamp) time.Time {
+	if timestamp.Seconds == 0 && timestamp.Nanos == 0 {
+		return time.Now()
+	}
+	return time.Unix(timestamp.Seconds, int64(timestamp.Nanos)).UTC()
+}
+
+// mapProps converts the PolicyReport result metadata and rule properties
+// into subject properties.
+func mapProps(prr *typepolr.PolicyReportResult) []policy.Property {
+	var props []policy.Property
+	if prr.Rule != "" {
+		props = append(props, pluginutil.MakeProp("policy-rule", prr.Rule))
+	}
+	if prr.Severity != "" {
+		props = append(props, pluginutil.MakeProp("severity", string(prr.Severity)))
+	}
+	if prr.Category != "" {
+		props = append(props, pluginutil.MakeProp("category", prr.Category))
+	}
+	props = append(props, pluginutil.MakeProp("scored", strconv.FormatBool(prr.Scored)))
+
+	keys := make([]string, 0, len(prr.Properties))
+	for key := range prr.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		props = append(props, pluginutil.MakeProp(toPropName(key), prr.Properties[key]))
+	}
+	return props
+}
+
+// invalidPropNameChars matches characters that are not allowed
+// in an OSCAL property name.
+var invalidPropNameChars = regexp.MustCompile(`[^\p{L}\p{N}._-]`)
+
+// toPropName sanitizes a PolicyReport property key so it can be
+// used as an OSCAL property name.
+func toPropName(key string) string {
+	name := invalidPropNameChars.ReplaceAllString(key, "_")
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "_" + name
+	}
+	return name
+}
@@ -8,6 +8,8 @@ package server
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/hashicorp/go-hclog"
@@ -18,8 +20,10 @@ import (
 )
 
 var (
-	_      policy.Provider = (*Plugin)(nil)
-	logger hclog.Logger    = logging.NewPluginLogger()
+	_      policy.Provider  = (*Plugin)(nil)
+	_      policy.Evaluator = (*Plugin)(nil)
+	_      policy.Collector = (*Plugin)(nil)
+	logger hclog.Logger     = logging.NewPluginLogger()
 )
 
 func Logger() hclog.Logger {
@@ -62,3 +66,26 @@ func (p *Plugin) GetResults(pl policy.Policy) (policy.PVPResult, error) {
 	results := NewResultToOscal(pl, p.config.PolicyResultsDir)
 	return results.GenerateResults()
 }
+
+// Collect returns the exported Kyverno policies and policy reports in the
+// policy results directory as evidence.
+func (p *Plugin) Collect(pl policy.Policy) ([]policy.Evidence, error) {
+	var evidence []policy.Evidence
+	for _, name := range policyResultFiles {
+		content, err := os.ReadFile(filepath.Join(p.config.PolicyResultsDir, name))
+		if err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, policy.NewEvidence(name, "application/yaml", content))
+	}
+	return evidence, nil
+}
+
+// Evaluate generates the results from the exported Kyverno policies and
+// policy reports collected as evidence. It returns plugin.ErrEvaluationNotSupported
+// when no collector collected all of them, so the results are read from the
+// policy results directory instead.
+func (p *Plugin) Evaluate(pl policy.Policy, evidence []policy.Evidence) (policy.PVPResult, error) {
+	results := NewResultToOscalFromEvidence(pl, evidence)
+	return results.GenerateResults()
+}
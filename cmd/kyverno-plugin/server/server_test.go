@@ -9,16 +9,19 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
-	"github.com/oscal-compass/oscal-sdk-go/extensions"
 	"github.com/oscal-compass/oscal-sdk-go/models"
 	"github.com/oscal-compass/oscal-sdk-go/models/components"
 	"github.com/oscal-compass/oscal-sdk-go/rules"
 	"github.com/oscal-compass/oscal-sdk-go/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	typepolr "sigs.k8s.io/wg-policy-prototypes/policy-report/pkg/api/wgpolicyk8s.io/v1beta1"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	c2pplugin "github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 func TestOscal2Policy(t *testing.T) {
@@ -35,6 +38,102 @@ func TestOscal2Policy(t *testing.T) {
 	assert.NoError(t, err, "Should not happen")
 }
 
+func TestResult2Oscal(t *testing.T) {
+	policyResultsDir := pkg.PathFromPkgDirectory("./testdata/kyverno/policy-reports")
+	testPolicy := createPolicy(t)
+	reporter := NewResultToOscal(testPolicy, policyResultsDir)
+	results, err := reporter.GenerateResults()
+	require.NoError(t, err)
+	require.Len(t, results.ObservationsByCheck, 1)
+
+	observation := results.ObservationsByCheck[0]
+	require.Equal(t, "allowed-base-images", observation.CheckID)
+	require.Len(t, observation.Subjects, 42)
+
+	subject := observation.Subjects[0]
+	require.Equal(t, policy.ResultFail, subject.Result)
+	require.Equal(t, time.Unix(1697608494, 0).UTC(), subject.EvaluatedOn)
+	wantProps := []policy.Property{
+		{Name: "policy-rule", Value: "allowed-base-images"},
+		{Name: "severity", Value: "medium"},
+		{Name: "category", Value: "Other"},
+		{Name: "scored", Value: "true"},
+	}
+	require.Equal(t, wantProps, subject.Props)
+}
+
+func TestCollectAndEvaluate(t *testing.T) {
+	plugin := NewPlugin()
+	policyResultsDir := pkg.PathFromPkgDirectory("./testdata/kyverno/policy-reports")
+	require.NoError(t, plugin.Configure(map[string]string{"policy-results-dir": policyResultsDir}))
+
+	testPolicy := createPolicy(t)
+	evidence, err := plugin.Collect(testPolicy)
+	require.NoError(t, err)
+	require.Len(t, evidence, 4)
+	for i := range evidence {
+		require.NoError(t, evidence[i].Verify())
+		evidence[i].Href = "evidence/kyverno/" + evidence[i].Name
+	}
+
+	results, err := plugin.Evaluate(testPolicy, evidence)
+	require.NoError(t, err)
+	require.Len(t, results.ObservationsByCheck, 1)
+	observation := results.ObservationsByCheck[0]
+	require.Len(t, observation.Subjects, 42)
+	require.Len(t, observation.RelevantEvidences, 2)
+	require.Equal(t, "evidence/kyverno/policyreports.wgpolicyk8s.io.yaml", observation.RelevantEvidences[0].Href)
+	require.Equal(t, []policy.Property{{Name: "digest", Value: evidence[2].Digest}}, observation.RelevantEvidences[0].Props)
+
+	_, err = plugin.Evaluate(testPolicy, evidence[:2])
+	require.ErrorIs(t, err, c2pplugin.ErrEvaluationNotSupported)
+
+	other := policy.NewEvidence(policyReportsFile, "application/yaml", []byte("items: []"))
+	other.Collector = "other"
+	for i := range evidence {
+		evidence[i].Collector = "kyverno"
+	}
+	results, err = plugin.Evaluate(testPolicy, append([]policy.Evidence{other}, evidence...))
+	require.NoError(t, err)
+	require.Len(t, results.ObservationsByCheck[0].Subjects, 42)
+}
+
+func TestMapResults(t *testing.T) {
+	tests := []struct {
+		result typepolr.PolicyResult
+		want   policy.Result
+	}{
+		{result: "pass", want: policy.ResultPass},
+		{result: "fail", want: policy.ResultFail},
+		{result: "warn", want: policy.ResultWarning},
+		{result: "error", want: policy.ResultError},
+		{result: "skip", want: policy.ResultSkipped},
+		{result: "unknown", want: policy.ResultInvalid},
+	}
+	for _, c := range tests {
+		t.Run(string(c.result), func(t *testing.T) {
+			require.Equal(t, c.want, mapResults(c.result))
+		})
+	}
+}
+
+func TestMapProps(t *testing.T) {
+	prr := &typepolr.PolicyReportResult{
+		Severity: "high",
+		Properties: map[string]string{
+			"process":                 "background scan",
+			"policies.kyverno.io/foo": "bar",
+		},
+	}
+	wantProps := []policy.Property{
+		{Name: "severity", Value: "high"},
+		{Name: "scored", Value: "false"},
+		{Name: "policies.kyverno.io_foo", Value: "bar"},
+		{Name: "process", Value: "background scan"},
+	}
+	require.Equal(t, wantProps, mapProps(prr))
+}
+
 func TestConfigure(t *testing.T) {
 	plugin := NewPlugin()
 	configuration := map[string]string{
@@ -49,7 +148,7 @@ func TestConfigure(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func createPolicy(t *testing.T) []extensions.RuleSet {
+func createPolicy(t *testing.T) policy.Policy {
 	cdPath := pkg.PathFromPkgDirectory("./testdata/kyverno/component-definition.json")
 
 	file, err := os.Open(cdPath)
@@ -72,5 +171,5 @@ func createPolicy(t *testing.T) []extensions.RuleSet {
 
 	ruleSets, err := store.FindByComponent(context.TODO(), "Kyverno")
 	require.NoError(t, err)
-	return ruleSets
+	return policy.FromRuleSets(ruleSets)
 }
@@ -32,5 +32,5 @@ func main() {
 		PluginSet: plugins,
 		Logger:    server.Logger(),
 	}
-	plugin.Register(config)
+	plugin.Run(config)
 }
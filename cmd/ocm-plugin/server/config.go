@@ -8,8 +8,32 @@ package server
 import (
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+)
+
+const (
+	// PlacementKindPlacement selects clusters with a cluster.open-cluster-management.io Placement.
+	PlacementKindPlacement = "placement"
+	// PlacementKindPlacementRule selects clusters with a legacy apps.open-cluster-management.io PlacementRule.
+	PlacementKindPlacementRule = "placement-rule"
+
+	// OutputModeFlat writes every generated resource to a file in the output directory.
+	OutputModeFlat = "flat"
+	// OutputModeKustomize writes the generated resources of a PolicySet to a kustomize-ready
+	// directory in the output directory.
+	OutputModeKustomize = "kustomize"
+)
+
+var (
+	defaultClusterSelectors = "env=dev"
+	defaultNamespaceInclude = []string{"*"}
+	defaultNamespaceExclude = []string{"kube-system", "open-cluster-management", "open-cluster-management-agent", "open-cluster-management-agent-addon"}
+	remediationActions      = []string{"inform", "enforce"}
+	severities              = []string{"low", "medium", "high", "critical"}
 )
 
 type Config struct {
@@ -19,38 +43,140 @@ type Config struct {
 	OutputDir        string `mapstructure:"output-dir"`
 	Namespace        string `mapstructure:"namespace"`
 	PolicySetName    string `mapstructure:"policy-set-name"`
-	// TODO: support with most complex configuration options
-	clusterSelectors map[string]string `mapstructure:"cluster-selectors"`
+	// ClusterSelectors is a Kubernetes label selector (e.g. "env=dev,region in (us-east,us-west)")
+	// used to select the managed clusters the policies are placed on.
+	ClusterSelectors string `mapstructure:"cluster-selectors"`
+	// PlacementKind is either "placement" or "placement-rule".
+	PlacementKind string `mapstructure:"placement-kind"`
+	// NamespaceInclude is a comma-separated list of namespaces the configuration policies apply to.
+	NamespaceInclude string `mapstructure:"namespace-include"`
+	// NamespaceExclude is a comma-separated list of namespaces the configuration policies ignore.
+	NamespaceExclude string `mapstructure:"namespace-exclude"`
+	// RemediationAction overrides the remediation action of all policies ("inform" or "enforce").
+	RemediationAction string `mapstructure:"remediation-action"`
+	// Severity overrides the severity of all policies.
+	Severity string `mapstructure:"severity"`
+	// SeverityOverrides is a comma-separated list of check-id=severity pairs
+	// that override the severity for specific policies.
+	SeverityOverrides string `mapstructure:"severity-overrides"`
+	// Kubeconfig is the path to a kubeconfig for the hub cluster. When set, results are
+	// read from the hub instead of from the policy results directory.
+	Kubeconfig string `mapstructure:"kubeconfig"`
+	// OutputMode is either "flat" or "kustomize".
+	OutputMode string `mapstructure:"output-mode"`
 }
 
 func (c Config) Validate() error {
 	var errs []error
-	if c.PolicySetName == "" {
-		errs = append(errs, errors.New("policy set name must be set"))
+	if err := pluginutil.CheckRequired("policy set name", c.PolicySetName); err != nil {
+		errs = append(errs, err)
+	}
+	if c.PolicyResultsDir == "" && c.Kubeconfig == "" {
+		errs = append(errs, errors.New("policy results directory or kubeconfig must be set"))
+	}
+	if err := pluginutil.CheckPath(c.PoliciesDir); err != nil {
+		errs = append(errs, err)
 	}
-	if err := checkPath(&c.PoliciesDir); err != nil {
+	if err := pluginutil.CheckPath(c.PolicyResultsDir); err != nil {
 		errs = append(errs, err)
 	}
-	if err := checkPath(&c.PolicyResultsDir); err != nil {
+	if err := pluginutil.CheckPath(c.TempDir); err != nil {
 		errs = append(errs, err)
 	}
-	if err := checkPath(&c.TempDir); err != nil {
+	if err := pluginutil.CheckPath(c.OutputDir); err != nil {
 		errs = append(errs, err)
 	}
-	if err := checkPath(&c.OutputDir); err != nil {
+	if err := pluginutil.CheckPath(c.Kubeconfig); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.ClusterSelector(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.PlacementKind != "" && c.PlacementKind != PlacementKindPlacement && c.PlacementKind != PlacementKindPlacementRule {
+		errs = append(errs, fmt.Errorf("invalid placement kind %q: must be one of %q, %q", c.PlacementKind, PlacementKindPlacement, PlacementKindPlacementRule))
+	}
+	if err := pluginutil.CheckOneOf("output mode", c.OutputMode, []string{OutputModeFlat, OutputModeKustomize}); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckOneOf("remediation action", c.RemediationAction, remediationActions); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckOneOf("severity", c.Severity, severities); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := c.SeverityByCheck(); err != nil {
 		errs = append(errs, err)
 	}
 	return errors.Join(errs...)
 }
 
-func checkPath(path *string) error {
-	if path != nil && *path != "" {
-		cleanedPath := filepath.Clean(*path)
-		path = &cleanedPath
-		_, err := os.Stat(*path)
-		if err != nil {
-			return fmt.Errorf("path %q: %w", *path, err)
+// ClusterSelector returns the parsed cluster label selector. If no selectors are
+// configured, the default "env=dev" selector is returned.
+func (c Config) ClusterSelector() (*metav1.LabelSelector, error) {
+	selectors := strings.TrimSpace(c.ClusterSelectors)
+	if selectors == "" {
+		selectors = defaultClusterSelectors
+	}
+	selector, err := metav1.ParseToLabelSelector(selectors)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster selectors %q: %w", c.ClusterSelectors, err)
+	}
+	return selector, nil
+}
+
+// Placement returns the configured placement kind, defaulting to "placement".
+func (c Config) Placement() string {
+	if c.PlacementKind == "" {
+		return PlacementKindPlacement
+	}
+	return c.PlacementKind
+}
+
+// Output returns the configured output mode, defaulting to "flat".
+func (c Config) Output() string {
+	if c.OutputMode == "" {
+		return OutputModeFlat
+	}
+	return c.OutputMode
+}
+
+// NamespaceSelection returns the namespaces the configuration policies include and exclude.
+func (c Config) NamespaceSelection() (include []string, exclude []string) {
+	include = splitList(c.NamespaceInclude)
+	if len(include) == 0 {
+		include = defaultNamespaceInclude
+	}
+	exclude = splitList(c.NamespaceExclude)
+	if len(exclude) == 0 {
+		exclude = defaultNamespaceExclude
+	}
+	return include, exclude
+}
+
+// SeverityByCheck returns the parsed per-check severity overrides.
+func (c Config) SeverityByCheck() (map[string]string, error) {
+	severityByCheck := make(map[string]string)
+	for _, pair := range splitList(c.SeverityOverrides) {
+		checkID, severity, found := strings.Cut(pair, "=")
+		checkID, severity = strings.TrimSpace(checkID), strings.TrimSpace(severity)
+		if !found || checkID == "" || severity == "" {
+			return nil, fmt.Errorf("invalid severity override %q: must be in the form check-id=severity", pair)
+		}
+		if err := pluginutil.CheckOneOf("severity", severity, severities); err != nil {
+			return nil, fmt.Errorf("invalid severity override for %s: %w", checkID, err)
+		}
+		severityByCheck[checkID] = severity
+	}
+	return severityByCheck, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
 		}
 	}
-	return nil
+	return items
 }
@@ -0,0 +1,152 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	typekustomize "sigs.k8s.io/kustomize/api/types"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+)
+
+const (
+	kustomizationFileName = "kustomization.yaml"
+	// indexFileName is the manifest index of a PolicySet directory. It records the files
+	// owned by the plugin so that files no longer generated can be removed on the next run.
+	indexFileName = "c2p-index.yaml"
+	indexKind     = "C2PManifestIndex"
+)
+
+// generatedResource is a resource generated by the PolicyGenerator.
+type generatedResource interface {
+	GetKind() string
+	GetNamespace() string
+	GetName() string
+	AsYAML() ([]byte, error)
+}
+
+// ManifestIndex lists the files generated for a PolicySet.
+type ManifestIndex struct {
+	Kind      string       `json:"kind"`
+	PolicySet string       `json:"policySet"`
+	Files     []IndexEntry `json:"files"`
+}
+
+// IndexEntry identifies the resource written to a file of a PolicySet directory.
+type IndexEntry struct {
+	Path      string `json:"path"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// writeKustomizeLayout writes the resources of a PolicySet to a directory named after the PolicySet
+// in outputDir, together with a kustomization.yaml and the manifest index. Files recorded in the
+// index of a previous run that are no longer generated are removed.
+func writeKustomizeLayout(outputDir, policySetName string, resources []generatedResource) error {
+	dir := filepath.Join(outputDir, pluginutil.ToDNSCompliant(policySetName))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	previous, err := loadIndex(dir)
+	if err != nil {
+		return err
+	}
+
+	index := ManifestIndex{
+		Kind:      indexKind,
+		PolicySet: policySetName,
+		Files:     []IndexEntry{},
+	}
+	for _, resource := range resources {
+		yamlByte, err := resource.AsYAML()
+		if err != nil {
+			return err
+		}
+		entry := IndexEntry{
+			Path:      resourceFileName(resource),
+			Kind:      resource.GetKind(),
+			Namespace: resource.GetNamespace(),
+			Name:      resource.GetName(),
+		}
+		if err := os.WriteFile(filepath.Join(dir, entry.Path), yamlByte, 0o644); err != nil {
+			return err
+		}
+		index.Files = append(index.Files, entry)
+	}
+	sort.Slice(index.Files, func(i, j int) bool {
+		return index.Files[i].Path < index.Files[j].Path
+	})
+
+	kustomization := typekustomize.Kustomization{
+		TypeMeta: typekustomize.TypeMeta{
+			APIVersion: typekustomize.KustomizationVersion,
+			Kind:       typekustomize.KustomizationKind,
+		},
+	}
+	for _, entry := range index.Files {
+		kustomization.Resources = append(kustomization.Resources, entry.Path)
+	}
+	if err := pkg.WriteObjToYamlFile(filepath.Join(dir, kustomizationFileName), kustomization); err != nil {
+		return err
+	}
+	if err := pkg.WriteObjToYamlFile(filepath.Join(dir, indexFileName), index); err != nil {
+		return err
+	}
+	return removeStaleFiles(dir, previous, index)
+}
+
+// resourceFileName returns a filename for the resource that is stable across runs.
+func resourceFileName(resource generatedResource) string {
+	tokens := []string{strings.ToLower(resource.GetKind())}
+	if resource.GetNamespace() != "" {
+		tokens = append(tokens, resource.GetNamespace())
+	}
+	tokens = append(tokens, resource.GetName())
+	return strings.Join(tokens, ".") + ".yaml"
+}
+
+func loadIndex(dir string) (ManifestIndex, error) {
+	var index ManifestIndex
+	path := filepath.Join(dir, indexFileName)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	}
+	if err := pkg.LoadYamlFileToObject(path, &index); err != nil {
+		return index, fmt.Errorf("failed to load manifest index %s: %w", path, err)
+	}
+	if index.Kind != indexKind {
+		return index, fmt.Errorf("%s is not a manifest index generated by the ocm plugin", path)
+	}
+	return index, nil
+}
+
+// removeStaleFiles removes the files of the previous index that are not in the current index.
+// Only plain filenames are considered so that nothing outside of dir is removed.
+func removeStaleFiles(dir string, previous, current ManifestIndex) error {
+	generated := map[string]bool{}
+	for _, entry := range current.Files {
+		generated[entry.Path] = true
+	}
+	for _, entry := range previous.Files {
+		if generated[entry.Path] || entry.Path != filepath.Base(entry.Path) {
+			continue
+		}
+		logger.Debug(fmt.Sprintf("removing stale file %s", entry.Path))
+		if err := os.Remove(filepath.Join(dir, entry.Path)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package server
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,13 +31,18 @@ import (
 	"sigs.k8s.io/kustomize/kyaml/resid"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/policygenerator"
+	typeplacement "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/placement"
+	typeplacements "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/placements"
 	pgtype "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/policygenerator"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 var DummyNamespace string = "dummy-namespace-c2p"
 
+const placementFileName = "placement.yaml"
+
 type Composer struct {
 	policiesDir string
 	tempDir     pkg.TempDirectory
@@ -62,8 +68,24 @@ func (c *Composer) ComposeByPolicies(pl policy.Policy, config Config) error {
 }
 
 func (c *Composer) Compose(pl policy.Policy, config Config) error {
-	if config.clusterSelectors == nil {
-		config.clusterSelectors = map[string]string{"env": "dev"}
+	severityByCheck, err := config.SeverityByCheck()
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Start composing placement")
+	placementName, err := c.writePlacement(config)
+	if err != nil {
+		return err
+	}
+	placementConfig := pgtype.PlacementConfig{}
+	policyPlacementConfig := pgtype.PlacementConfig{}
+	if config.Placement() == PlacementKindPlacementRule {
+		placementConfig.PlacementRulePath = "./" + placementFileName
+		policyPlacementConfig.PlacementRuleName = placementName
+	} else {
+		placementConfig.PlacementPath = "./" + placementFileName
+		policyPlacementConfig.PlacementName = placementName
 	}
 
 	logger.Info("Start composing policySets")
@@ -93,7 +115,7 @@ func (c *Composer) Compose(pl policy.Policy, config Config) error {
 				return err
 			}
 			policyGeneratorManifest.PolicyDefaults.Namespace = config.Namespace
-			policyGeneratorManifest.PolicyDefaults.PolicyOptions.Placement.ClusterSelectors = config.clusterSelectors
+			policyGeneratorManifest.PolicyDefaults.PolicyOptions.Placement = policyPlacementConfig
 			if err := pkg.WriteObjToYamlFileByGoYaml(policyGeneratorManifestPath, policyGeneratorManifest); err != nil {
 				return err
 			}
@@ -122,7 +144,7 @@ func (c *Composer) Compose(pl policy.Policy, config Config) error {
 			suffix = fmt.Sprintf("-%d", idx)
 		}
 		policySetConfig := pgtype.PolicySetConfig{
-			Name:     toDNSCompliant(config.PolicySetName + suffix),
+			Name:     pluginutil.ToDNSCompliant(config.PolicySetName + suffix),
 			Policies: policyListPerControlImple,
 		}
 		policySets = append(policySets, policySetConfig)
@@ -135,24 +157,27 @@ func (c *Composer) Compose(pl policy.Policy, config Config) error {
 		policySetPatches = append(policySetPatches, policySetPatch)
 	}
 
+	include, exclude := config.NamespaceSelection()
 	policyDefaults := pgtype.PolicyDefaults{
 		Namespace: config.Namespace,
 		PolicyOptions: pgtype.PolicyOptions{
-			Placement: pgtype.PlacementConfig{
-				LabelSelector: config.clusterSelectors,
-			},
+			Placement: placementConfig,
 		},
 		ConfigurationPolicyOptions: pgtype.ConfigurationPolicyOptions{
 			NamespaceSelector: pgtype.NamespaceSelector{
-				Exclude: []string{"kube-system", "open-cluster-management", "open-cluster-management-agent", "open-cluster-management-agent-addon"},
-				Include: []string{"*"},
+				Exclude: exclude,
+				Include: include,
 			},
 		},
 	}
 	policyConfigs := []pgtype.PolicyConfig{}
-	for _, policyConfig := range policyConfigMap {
+	for policyId, policyConfig := range policyConfigMap {
+		applyOverrides(&policyConfig, config.RemediationAction, severityOverride(config, severityByCheck, policyId))
 		policyConfigs = append(policyConfigs, policyConfig)
 	}
+	sort.Slice(policyConfigs, func(i, j int) bool {
+		return policyConfigs[i].Name < policyConfigs[j].Name
+	})
 	policySetGeneratorManifest := policygenerator.BuildPolicyGeneratorManifest("policy-set", policyDefaults, policyConfigs)
 	policySetGeneratorManifest.PlacementBindingDefaults.Name = "policy-set"
 	policySetGeneratorManifest.PolicySets = policySets
@@ -196,6 +221,89 @@ func (c *Composer) Compose(pl policy.Policy, config Config) error {
 	return nil
 }
 
+// writePlacement writes the Placement or PlacementRule selecting the managed clusters
+// to the temporary directory and returns its name.
+func (c *Composer) writePlacement(config Config) (string, error) {
+	selector, err := config.ClusterSelector()
+	if err != nil {
+		return "", err
+	}
+	name := pluginutil.ToDNSCompliant("placement-" + config.PolicySetName)
+	namespace := config.Namespace
+	if namespace == "" {
+		namespace = DummyNamespace
+	}
+	objectMeta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+	}
+
+	var placement interface{}
+	if config.Placement() == PlacementKindPlacementRule {
+		placement = typeplacements.PlacementRule{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "PlacementRule",
+				APIVersion: "apps.open-cluster-management.io/v1",
+			},
+			ObjectMeta: objectMeta,
+			Spec: typeplacements.PlacementRuleSpec{
+				GenericPlacementFields: typeplacements.GenericPlacementFields{
+					ClusterSelector: selector,
+				},
+			},
+		}
+	} else {
+		placement = typeplacement.Placement{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Placement",
+				APIVersion: "cluster.open-cluster-management.io/v1beta1",
+			},
+			ObjectMeta: objectMeta,
+			Spec: typeplacement.PlacementSpec{
+				Predicates: []typeplacement.ClusterPredicate{
+					{
+						RequiredClusterSelector: typeplacement.ClusterSelector{
+							LabelSelector: *selector,
+						},
+					},
+				},
+			},
+		}
+	}
+	if err := pkg.WriteObjToYamlFile(c.tempDir.GetTempDir()+"/"+placementFileName, placement); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// severityOverride returns the severity override for a check, preferring
+// the per-check override over the global one.
+func severityOverride(config Config, severityByCheck map[string]string, checkId string) string {
+	if severity, ok := severityByCheck[checkId]; ok {
+		return severity
+	}
+	return config.Severity
+}
+
+// applyOverrides sets the remediation action and severity on the policy. Since manifest-level
+// options take precedence in the PolicyGenerator, they are cleared when overridden.
+func applyOverrides(policyConfig *pgtype.PolicyConfig, remediationAction, severity string) {
+	if remediationAction != "" {
+		policyConfig.RemediationAction = remediationAction
+	}
+	if severity != "" {
+		policyConfig.Severity = severity
+	}
+	for idx := range policyConfig.Manifests {
+		if remediationAction != "" {
+			policyConfig.Manifests[idx].RemediationAction = ""
+		}
+		if severity != "" {
+			policyConfig.Manifests[idx].Severity = ""
+		}
+	}
+}
+
 func (c *Composer) CopyAllTo(destDir string) error {
 	if _, err := pkg.MakeDir(destDir); err != nil {
 		return err
@@ -223,13 +331,6 @@ func (c *Composer) GeneratePolicySet() (*resmap.ResMap, error) {
 	return &generatedManifests, nil
 }
 
-func toDNSCompliant(name string) string {
-	var result string
-	result = strings.ToLower(name)
-	result = strings.ReplaceAll(result, " ", "-")
-	return result
-}
-
 func appendUnique(slice []string, elems ...string) []string {
 	a := append(slice, elems...)
 	return sets.List[string](sets.New[string](a...))
@@ -17,23 +17,22 @@ limitations under the License.
 package server
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/defenseunicorns/go-oscal/src/pkg/uuid"
-	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-2"
-	"github.com/oscal-compass/oscal-sdk-go/extensions"
-	sigyaml "sigs.k8s.io/yaml"
-
-	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
-	provider "github.com/oscal-compass/compliance-to-policy-go/v2/policy"
-
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/oscal-compass/compliance-to-policy-go/v2/controllers/utils/ocmk8sclients"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
 	typeplacementdecision "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/placementdecision"
 	typepolicy "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/policy"
 	typeutils "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/utils"
+	provider "github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 type ResultToOscal struct {
@@ -44,12 +43,14 @@ type ResultToOscal struct {
 	namespace          string
 	policySetName      string
 	placementDecisions []*typeplacementdecision.PlacementDecision
+	hubClients         *ocmk8sclients.OcmK8ResourceInterfaceSetType
 }
 
 type Reason struct {
-	ClusterName     string                         `json:"clusterName,omitempty" yaml:"clusterName,omitempty"`
-	ComplianceState typepolicy.ComplianceState     `json:"complianceState,omitempty" yaml:"complianceState,omitempty"`
-	Messages        []typepolicy.ComplianceHistory `json:"messages,omitempty" yaml:"messages,omitempty"`
+	ClusterName     string                          `json:"clusterName,omitempty" yaml:"clusterName,omitempty"`
+	ComplianceState typepolicy.ComplianceState      `json:"complianceState,omitempty" yaml:"complianceState,omitempty"`
+	Messages        []typepolicy.ComplianceHistory  `json:"messages,omitempty" yaml:"messages,omitempty"`
+	Details         []typepolicy.DetailsPerTemplate `json:"details,omitempty" yaml:"details,omitempty"`
 }
 
 type GenerationType string
@@ -72,30 +73,23 @@ func NewResultToOscal(pl provider.Policy, policyResultsDir, namespace, policySet
 	return &r
 }
 
-func (r *ResultToOscal) GenerateResults() (provider.PVPResult, error) {
-
-	var policyList typepolicy.PolicyList
-	if err := r.loadData("policies.policy.open-cluster-management.io.yaml", &policyList); err != nil {
-		return provider.PVPResult{}, err
-	}
-	for idx := range policyList.Items {
-		r.policies = append(r.policies, &policyList.Items[idx])
-	}
+// WithHubClients makes the results be read from the Policies, PolicySets and PlacementDecisions
+// on a hub cluster instead of from the dumps in the policy results directory.
+func (r *ResultToOscal) WithHubClients(hubClients ocmk8sclients.OcmK8ResourceInterfaceSetType) *ResultToOscal {
+	r.hubClients = &hubClients
+	return r
+}
 
-	var policySetList typepolicy.PolicySetList
-	if err := r.loadData("policysets.policy.open-cluster-management.io.yaml", &policySetList); err != nil {
-		return provider.PVPResult{}, err
-	}
-	for idx := range policySetList.Items {
-		r.policySets = append(r.policySets, &policySetList.Items[idx])
-	}
+func (r *ResultToOscal) GenerateResults() (provider.PVPResult, error) {
 
-	var placementDecisionLost typeplacementdecision.PlacementDecisionList
-	if err := r.loadData("placementdecisions.cluster.open-cluster-management.io.yaml", &placementDecisionLost); err != nil {
-		return provider.PVPResult{}, err
-	}
-	for idx := range placementDecisionLost.Items {
-		r.placementDecisions = append(r.placementDecisions, &placementDecisionLost.Items[idx])
+	if r.hubClients != nil {
+		if err := r.loadFromHub(); err != nil {
+			return provider.PVPResult{}, err
+		}
+	} else {
+		if err := r.loadFromDirectory(); err != nil {
+			return provider.PVPResult{}, err
+		}
 	}
 
 	policySets := typeutils.FilterByAnnotation(r.policySets, pkg.ANNOTATION_COMPONENT_TITLE, r.policySetName)
@@ -117,7 +111,7 @@ func (r *ResultToOscal) GenerateResults() (provider.PVPResult, error) {
 		}
 	}
 
-	inventories := []oscalTypes.InventoryItem{}
+	var inventoryItems []provider.InventoryItem
 	clusternameIndex := map[string]bool{}
 	for _, policy := range r.policies {
 		if policy.Namespace == r.namespace {
@@ -125,17 +119,7 @@ func (r *ResultToOscal) GenerateResults() (provider.PVPResult, error) {
 				_, exist := clusternameIndex[s.ClusterName]
 				if !exist {
 					clusternameIndex[s.ClusterName] = true
-					item := oscalTypes.InventoryItem{
-						UUID: uuid.NewUUID(),
-						Props: &[]oscalTypes.Property{
-							{
-								Name:  "cluster-name",
-								Value: s.ClusterName,
-								Ns:    extensions.TrestleNameSpace,
-							},
-						},
-					}
-					inventories = append(inventories, item)
+					inventoryItems = append(inventoryItems, newClusterInventoryItem(s))
 				}
 			}
 		}
@@ -154,36 +138,20 @@ func (r *ResultToOscal) GenerateResults() (provider.PVPResult, error) {
 			}
 
 			var subjects []provider.Subject
+			var evidences []provider.Link
 			if policy != nil {
+				evidences = append(evidences, provider.Link{
+					Description: fmt.Sprintf("Policy %s/%s", policy.Namespace, policy.Name),
+					Href:        policyHref(policy.Namespace, policy.Name),
+				})
+				templateTypes := templateTypesByName(*policy)
 				reasons := r.GenerateReasonsFromRawPolicies(*policy)
 				for _, reason := range reasons {
-					clusterName := "N/A"
-					inventoryUuid := ""
-					for _, inventory := range inventories {
-						prop, ok := extensions.GetTrestleProp("cluster-name", *inventory.Props)
-						if ok && prop.Value == reason.ClusterName {
-							clusterName = prop.Value
-							inventoryUuid = inventory.UUID
-							break
-						}
-					}
-					if inventoryUuid != "" {
-						var message string
-						if messageByte, err := sigyaml.Marshal(reason.Messages); err == nil {
-							message = string(messageByte)
-						} else {
-							message = err.Error()
-						}
-						subject := provider.Subject{
-							Type:        "resource",
-							Title:       "Cluster Name: " + clusterName,
-							ResourceID:  inventoryUuid,
-							Result:      mapToPolicyResult(reason.ComplianceState),
-							Reason:      strings.ReplaceAll(message, "\n", "\\n"),
-							EvaluatedOn: time.Now(),
-						}
-						subjects = append(subjects, subject)
+					if !clusternameIndex[reason.ClusterName] {
+						continue
 					}
+					subjects = append(subjects, newClusterSubject(reason))
+					evidences = append(evidences, newHistoryEvidences(reason, templateTypes)...)
 				}
 			}
 
@@ -195,13 +163,14 @@ func (r *ResultToOscal) GenerateResults() (provider.PVPResult, error) {
 			}
 
 			observation := provider.ObservationByCheck{
-				Title:       rule.Rule.ID,
-				CheckID:     policyId,
-				Description: fmt.Sprintf("Observation of policy %s", policyId),
-				Methods:     []string{"TEST-AUTOMATED"},
-				Props:       props,
-				Subjects:    subjects,
-				Collected:   time.Now(),
+				Title:             rule.Rule.ID,
+				CheckID:           policyId,
+				Description:       fmt.Sprintf("Observation of policy %s", policyId),
+				Methods:           []string{"TEST-AUTOMATED"},
+				Props:             props,
+				Subjects:          subjects,
+				Collected:         time.Now(),
+				RelevantEvidences: evidences,
 			}
 			observations = append(observations, observation)
 		}
@@ -209,6 +178,7 @@ func (r *ResultToOscal) GenerateResults() (provider.PVPResult, error) {
 
 	result := provider.PVPResult{
 		ObservationsByCheck: observations,
+		InventoryItems:      inventoryItems,
 	}
 
 	return result, nil
@@ -223,24 +193,218 @@ func (r *ResultToOscal) GenerateReasonsFromRawPolicies(policy typepolicy.Policy)
 			continue
 		}
 		messages := []typepolicy.ComplianceHistory{}
+		details := []typepolicy.DetailsPerTemplate{}
 		for _, detail := range policyPerCluster.Status.Details {
+			if detail == nil {
+				continue
+			}
 			if len(detail.History) > 0 {
 				messages = append(messages, detail.History[0])
 			}
+			details = append(details, *detail)
 		}
 		reasons = append(reasons, Reason{
 			ClusterName:     clusterName,
 			ComplianceState: status.ComplianceState,
 			Messages:        messages,
+			Details:         details,
 		})
 	}
 	return reasons
 
 }
 
+func (r *ResultToOscal) loadFromDirectory() error {
+	var policyList typepolicy.PolicyList
+	if err := r.loadData("policies.policy.open-cluster-management.io.yaml", &policyList); err != nil {
+		return err
+	}
+	for idx := range policyList.Items {
+		r.policies = append(r.policies, &policyList.Items[idx])
+	}
+
+	var policySetList typepolicy.PolicySetList
+	if err := r.loadData("policysets.policy.open-cluster-management.io.yaml", &policySetList); err != nil {
+		return err
+	}
+	for idx := range policySetList.Items {
+		r.policySets = append(r.policySets, &policySetList.Items[idx])
+	}
+
+	var placementDecisionList typeplacementdecision.PlacementDecisionList
+	if err := r.loadData("placementdecisions.cluster.open-cluster-management.io.yaml", &placementDecisionList); err != nil {
+		return err
+	}
+	for idx := range placementDecisionList.Items {
+		r.placementDecisions = append(r.placementDecisions, &placementDecisionList.Items[idx])
+	}
+	return nil
+}
+
+// loadFromHub lists the Policies in all namespaces, since the per-cluster copies of the
+// root policies live in the managed cluster namespaces, and the PolicySets and
+// PlacementDecisions in the configured namespace.
+func (r *ResultToOscal) loadFromHub() error {
+	if r.hubClients.PolicySet == nil || r.hubClients.PlacementDecision == nil {
+		return errors.New("the hub does not serve PolicySets and PlacementDecisions")
+	}
+	var err error
+	policyClient := ocmk8sclients.NewPolicyClient(r.hubClients.Policy)
+	if r.policies, err = policyClient.List(metav1.NamespaceAll); err != nil {
+		return fmt.Errorf("failed to list policies: %w", err)
+	}
+	policySetClient := ocmk8sclients.NewPolicySetClient(r.hubClients.PolicySet)
+	if r.policySets, err = policySetClient.List(r.namespace); err != nil {
+		return fmt.Errorf("failed to list policy sets in namespace %s: %w", r.namespace, err)
+	}
+	placementDecisionClient := ocmk8sclients.NewPlacementDecisionClient(r.hubClients.PlacementDecision)
+	if r.placementDecisions, err = placementDecisionClient.List(r.namespace); err != nil {
+		return fmt.Errorf("failed to list placement decisions in namespace %s: %w", r.namespace, err)
+	}
+	return nil
+}
+
 func (r *ResultToOscal) loadData(path string, out interface{}) error {
 	if err := pkg.LoadYamlFileToK8sTypedObject(r.policyResultsDir+"/"+path, &out); err != nil {
 		return err
 	}
 	return nil
 }
+
+var defaultTemplateType = metav1.TypeMeta{
+	APIVersion: "policy.open-cluster-management.io/v1",
+	Kind:       "ConfigurationPolicy",
+}
+
+// newClusterInventoryItem returns the inventory item of a managed cluster. Cluster subjects
+// reference it by the cluster name.
+func newClusterInventoryItem(status *typepolicy.CompliancePerClusterStatus) provider.InventoryItem {
+	props := []provider.Property{
+		{
+			Name:  "cluster-name",
+			Value: status.ClusterName,
+		},
+	}
+	if status.ClusterNamespace != "" {
+		props = append(props, provider.Property{
+			Name:  "cluster-namespace",
+			Value: status.ClusterNamespace,
+		})
+	}
+	return provider.InventoryItem{
+		ID:          status.ClusterName,
+		Description: fmt.Sprintf("Managed cluster %s", status.ClusterName),
+		Props:       props,
+	}
+}
+
+// newClusterSubject returns the subject of a managed cluster with its compliance state for a policy.
+func newClusterSubject(reason Reason) provider.Subject {
+	evaluatedOn := time.Time{}
+	messages := []string{}
+	for _, message := range reason.Messages {
+		messages = append(messages, message.Message)
+		if message.LastTimestamp.After(evaluatedOn) {
+			evaluatedOn = message.LastTimestamp.UTC()
+		}
+	}
+	if evaluatedOn.IsZero() {
+		evaluatedOn = time.Now()
+	}
+	return provider.Subject{
+		Type:        "cluster",
+		Title:       reason.ClusterName,
+		ResourceID:  reason.ClusterName,
+		Result:      mapToPolicyResult(reason.ComplianceState),
+		Reason:      strings.Join(messages, "; "),
+		EvaluatedOn: evaluatedOn,
+		Props: []provider.Property{
+			{
+				Name:  "cluster-name",
+				Value: reason.ClusterName,
+			},
+			{
+				Name:  "compliance-state",
+				Value: string(reason.ComplianceState),
+			},
+		},
+	}
+}
+
+// newHistoryEvidences returns an evidence for each compliance history entry of the policy
+// templates on a managed cluster, linking to the template object.
+func newHistoryEvidences(reason Reason, templateTypes map[string]metav1.TypeMeta) []provider.Link {
+	var evidences []provider.Link
+	for _, detail := range reason.Details {
+		templateName := detail.TemplateMeta.Name
+		templateNamespace := detail.TemplateMeta.Namespace
+		if templateNamespace == "" {
+			templateNamespace = reason.ClusterName
+		}
+		templateType, ok := templateTypes[templateName]
+		if !ok {
+			templateType = defaultTemplateType
+		}
+		for _, history := range detail.History {
+			evidences = append(evidences, provider.Link{
+				Description: history.Message,
+				Href:        templateHref(templateType, templateNamespace, templateName),
+				Props: []provider.Property{
+					{
+						Name:  "cluster-name",
+						Value: reason.ClusterName,
+					},
+					{
+						Name:  "template-kind",
+						Value: templateType.Kind,
+					},
+					{
+						Name:  "template-name",
+						Value: templateName,
+					},
+					{
+						Name:  "compliance-state",
+						Value: string(detail.ComplianceState),
+					},
+					{
+						Name:  "event-name",
+						Value: history.EventName,
+					},
+					{
+						Name:  "last-timestamp",
+						Value: history.LastTimestamp.UTC().Format(time.RFC3339),
+					},
+				},
+			})
+		}
+	}
+	return evidences
+}
+
+// templateTypesByName returns the type of each policy template of a policy by the template name.
+func templateTypesByName(policy typepolicy.Policy) map[string]metav1.TypeMeta {
+	types := map[string]metav1.TypeMeta{}
+	for _, template := range policy.Spec.PolicyTemplates {
+		if template == nil {
+			continue
+		}
+		var obj metav1.PartialObjectMetadata
+		if err := json.Unmarshal(template.ObjectDefinition.Raw, &obj); err != nil {
+			logger.Debug(fmt.Sprintf("failed to read policy template of policy %s: %v", policy.Name, err))
+			continue
+		}
+		if obj.Name != "" && obj.Kind != "" && obj.APIVersion != "" {
+			types[obj.Name] = obj.TypeMeta
+		}
+	}
+	return types
+}
+
+func policyHref(namespace, name string) string {
+	return fmt.Sprintf("/apis/policy.open-cluster-management.io/v1/namespaces/%s/policies/%s", namespace, name)
+}
+
+func templateHref(templateType metav1.TypeMeta, namespace, name string) string {
+	resource, _ := meta.UnsafeGuessKindToResource(templateType.GroupVersionKind())
+	return fmt.Sprintf("/apis/%s/namespaces/%s/%s/%s", templateType.APIVersion, namespace, resource.Resource, name)
+}
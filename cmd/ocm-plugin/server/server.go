@@ -7,12 +7,17 @@ package server
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/hashicorp/go-hclog"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/oscal-compass/compliance-to-policy-go/v2/controllers/utils/ocmk8sclients"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
@@ -56,19 +61,29 @@ func (p *Plugin) Generate(pl policy.Policy) error {
 		return err
 	}
 
-	for _, resource := range (*policySet).Resources() {
-		name := resource.GetName()
-		kind := resource.GetKind()
-		namespace := resource.GetNamespace()
-		yamlByte, err := resource.AsYAML()
-		if err != nil {
-			return err
+	if p.config.Output() == OutputModeKustomize {
+		var resources []generatedResource
+		for _, resource := range (*policySet).Resources() {
+			resources = append(resources, resource)
 		}
-		fnamesTokens := []string{kind, namespace, name}
-		fname := strings.Join(fnamesTokens, ".") + ".yaml"
-		if err := os.WriteFile(p.config.OutputDir+"/"+fname, yamlByte, os.ModePerm); err != nil {
+		if err := writeKustomizeLayout(p.config.OutputDir, p.config.PolicySetName, resources); err != nil {
 			return err
 		}
+	} else {
+		for _, resource := range (*policySet).Resources() {
+			name := resource.GetName()
+			kind := resource.GetKind()
+			namespace := resource.GetNamespace()
+			yamlByte, err := resource.AsYAML()
+			if err != nil {
+				return err
+			}
+			fnamesTokens := []string{kind, namespace, name}
+			fname := strings.Join(fnamesTokens, ".") + ".yaml"
+			if err := os.WriteFile(p.config.OutputDir+"/"+fname, yamlByte, os.ModePerm); err != nil {
+				return err
+			}
+		}
 	}
 
 	if p.policyGeneratorDir != "" {
@@ -81,5 +96,24 @@ func (p *Plugin) Generate(pl policy.Policy) error {
 
 func (p *Plugin) GetResults(pl policy.Policy) (policy.PVPResult, error) {
 	results := NewResultToOscal(pl, p.config.PolicyResultsDir, p.config.Namespace, p.config.PolicySetName)
+	if p.config.Kubeconfig != "" {
+		restConfig, err := clientcmd.BuildConfigFromFlags("", p.config.Kubeconfig)
+		if err != nil {
+			return policy.PVPResult{}, fmt.Errorf("failed to load kubeconfig %s: %w", p.config.Kubeconfig, err)
+		}
+		dyClient, err := dynamic.NewForConfig(restConfig)
+		if err != nil {
+			return policy.PVPResult{}, fmt.Errorf("failed to create hub client: %w", err)
+		}
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+		if err != nil {
+			return policy.PVPResult{}, fmt.Errorf("failed to create hub discovery client: %w", err)
+		}
+		hubClients, err := ocmk8sclients.NewOcmK8sClientSet(discoveryClient, dyClient)
+		if err != nil {
+			return policy.PVPResult{}, fmt.Errorf("failed to create hub clients: %w", err)
+		}
+		results.WithHubClients(hubClients)
+	}
 	return results.GenerateResults()
 }
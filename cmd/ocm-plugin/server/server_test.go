@@ -8,19 +8,29 @@ package server
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
-	"github.com/oscal-compass/oscal-sdk-go/extensions"
 	"github.com/oscal-compass/oscal-sdk-go/models"
 	"github.com/oscal-compass/oscal-sdk-go/models/components"
 	"github.com/oscal-compass/oscal-sdk-go/rules"
 	"github.com/oscal-compass/oscal-sdk-go/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	typekustomize "sigs.k8s.io/kustomize/api/types"
 
+	"github.com/oscal-compass/compliance-to-policy-go/v2/controllers/utils/ocmk8sclients"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	pgtype "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/policygenerator"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
@@ -62,18 +72,24 @@ func TestResult2Oscal(t *testing.T) {
 				Methods:     []string{"TEST-AUTOMATED"},
 				Subjects: []policy.Subject{
 					{
-						Title:      "Cluster Name: cluster1",
-						Type:       "resource",
-						ResourceID: "322b6a68-006e-11f0-a98b-88a4c2f0e4d9",
+						Title:      "cluster1",
+						Type:       "cluster",
+						ResourceID: "cluster1",
 						Result:     policy.ResultFail,
-						Reason:     "",
+						Props: []policy.Property{
+							{Name: "cluster-name", Value: "cluster1"},
+							{Name: "compliance-state", Value: "NonCompliant"},
+						},
 					},
 					{
-						Title:      "Cluster Name: cluster2",
-						Type:       "resource",
-						ResourceID: "322b6a68-006e-11f0-a98b-88a4c2f0e4d9",
+						Title:      "cluster2",
+						Type:       "cluster",
+						ResourceID: "cluster2",
 						Result:     policy.ResultFail,
-						Reason:     "",
+						Props: []policy.Property{
+							{Name: "cluster-name", Value: "cluster2"},
+							{Name: "compliance-state", Value: "NonCompliant"},
+						},
 					},
 				},
 				Props: []policy.Property{
@@ -90,18 +106,24 @@ func TestResult2Oscal(t *testing.T) {
 				Methods:     []string{"TEST-AUTOMATED"},
 				Subjects: []policy.Subject{
 					{
-						Title:      "Cluster Name: cluster1",
-						Type:       "resource",
-						ResourceID: "322b6a68-006e-11f0-a98b-88a4c2f0e4d9",
+						Title:      "cluster1",
+						Type:       "cluster",
+						ResourceID: "cluster1",
 						Result:     policy.ResultFail,
-						Reason:     "ddd",
+						Props: []policy.Property{
+							{Name: "cluster-name", Value: "cluster1"},
+							{Name: "compliance-state", Value: "NonCompliant"},
+						},
 					},
 					{
-						Title:      "Cluster Name: cluster2",
-						Type:       "resource",
-						ResourceID: "322b6a68-006e-11f0-a98b-88a4c2f0e4d9",
+						Title:      "cluster2",
+						Type:       "cluster",
+						ResourceID: "cluster2",
 						Result:     policy.ResultFail,
-						Reason:     "",
+						Props: []policy.Property{
+							{Name: "cluster-name", Value: "cluster2"},
+							{Name: "compliance-state", Value: "NonCompliant"},
+						},
 					},
 				},
 				Props: []policy.Property{
@@ -118,34 +140,168 @@ func TestResult2Oscal(t *testing.T) {
 				Methods:     []string{"TEST-AUTOMATED"},
 				Subjects: []policy.Subject{
 					{
-						Title:      "Cluster Name: cluster1",
-						Type:       "resource",
-						ResourceID: "322b6a68-006e-11f0-a98b-88a4c2f0e4d9",
+						Title:      "cluster1",
+						Type:       "cluster",
+						ResourceID: "cluster1",
 						Result:     policy.ResultPass,
-						Reason:     "",
+						Props: []policy.Property{
+							{Name: "cluster-name", Value: "cluster1"},
+							{Name: "compliance-state", Value: "Compliant"},
+						},
 					},
 					{
-						Title:      "Cluster Name: cluster2",
-						Type:       "resource",
-						ResourceID: "322b6a68-006e-11f0-a98b-88a4c2f0e4d9",
+						Title:      "cluster2",
+						Type:       "cluster",
+						ResourceID: "cluster2",
 						Result:     policy.ResultPass,
-						Reason:     "",
+						Props: []policy.Property{
+							{Name: "cluster-name", Value: "cluster2"},
+							{Name: "compliance-state", Value: "Compliant"},
+						},
 					},
 				},
 				Props: []policy.Property{
 					{Name: "assessment-rule-id", Value: "test_rbac_check"}}},
 		},
+		InventoryItems: []policy.InventoryItem{
+			{
+				ID:          "cluster1",
+				Description: "Managed cluster cluster1",
+				Props: []policy.Property{
+					{Name: "cluster-name", Value: "cluster1"},
+					{Name: "cluster-namespace", Value: "cluster1"},
+				},
+			},
+			{
+				ID:          "cluster2",
+				Description: "Managed cluster cluster2",
+				Props: []policy.Property{
+					{Name: "cluster-name", Value: "cluster2"},
+					{Name: "cluster-namespace", Value: "cluster2"},
+				},
+			},
+		},
 	}
 	diff := cmp.Diff(expected, results,
-		cmpopts.IgnoreFields(policy.ObservationByCheck{}, "Collected"),
+		cmpopts.IgnoreFields(policy.ObservationByCheck{}, "Collected", "RelevantEvidences"),
 		cmpopts.IgnoreFields(policy.Subject{}, "EvaluatedOn"),
-		cmpopts.IgnoreFields(policy.Subject{}, "ResourceID"),
 		cmpopts.IgnoreFields(policy.Subject{}, "Reason"),
 		cmpopts.SortSlices(func(i, j policy.ObservationByCheck) bool {
 			return i.Title < j.Title
 		}),
 	)
 	require.Equal(t, diff, "")
+
+	for _, observation := range results.ObservationsByCheck {
+		for _, subject := range observation.Subjects {
+			require.False(t, subject.EvaluatedOn.IsZero())
+		}
+		require.NotEmpty(t, observation.RelevantEvidences)
+		policyEvidence := observation.RelevantEvidences[0]
+		require.Equal(t, "/apis/policy.open-cluster-management.io/v1/namespaces/c2p/policies/"+observation.CheckID, policyEvidence.Href)
+		for _, evidence := range observation.RelevantEvidences[1:] {
+			require.Contains(t, evidence.Href, "/configurationpolicies/")
+			require.Len(t, evidence.Props, 6)
+			require.Equal(t, "cluster-name", evidence.Props[0].Name)
+		}
+	}
+}
+
+func TestResult2OscalFromHub(t *testing.T) {
+	policyResultsDir := pkg.PathFromPkgDirectory("./testdata/ocm/policy-results")
+	testPolicy := createPolicy(t)
+
+	expected, err := NewResultToOscal(testPolicy, policyResultsDir, "c2p", "Managed Kubernetes").GenerateResults()
+	require.NoError(t, err)
+
+	var objects []runtime.Object
+	for _, fname := range []string{
+		"policies.policy.open-cluster-management.io.yaml",
+		"policysets.policy.open-cluster-management.io.yaml",
+		"placementdecisions.cluster.open-cluster-management.io.yaml",
+	} {
+		var list unstructured.UnstructuredList
+		require.NoError(t, pkg.LoadYamlFileToK8sTypedObject(filepath.Join(policyResultsDir, fname), &list))
+		for idx := range list.Items {
+			objects = append(objects, &list.Items[idx])
+		}
+	}
+	dyClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "policy.open-cluster-management.io", Version: "v1", Resource: "policies"}:                 "PolicyList",
+		{Group: "policy.open-cluster-management.io", Version: "v1beta1", Resource: "policysets"}:          "PolicySetList",
+		{Group: "cluster.open-cluster-management.io", Version: "v1beta1", Resource: "placementdecisions"}: "PlacementDecisionList",
+	}, objects...)
+	hubResources := []*metav1.APIResourceList{
+		{
+			GroupVersion: "policy.open-cluster-management.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "policies", Kind: "Policy", Namespaced: true},
+				{Name: "placementbindings", Kind: "PlacementBinding", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: "policy.open-cluster-management.io/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "policysets", Kind: "PolicySet", Namespaced: true}},
+		},
+		{
+			GroupVersion: "apps.open-cluster-management.io/v1",
+			APIResources: []metav1.APIResource{{Name: "placementrules", Kind: "PlacementRule", Namespaced: true}},
+		},
+		{
+			GroupVersion: "cluster.open-cluster-management.io/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "placementdecisions", Kind: "PlacementDecision", Namespaced: true}},
+		},
+	}
+	hubClients, err := ocmk8sclients.NewOcmK8sClientSet(&discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: hubResources}}, dyClient)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		policySetName string
+		wantSubjects  bool
+	}{
+		{
+			name:          "Valid/MatchingPolicySet",
+			policySetName: "Managed Kubernetes",
+			wantSubjects:  true,
+		},
+		{
+			name:          "Valid/NoMatchingPolicySet",
+			policySetName: "Unknown Component",
+			wantSubjects:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := NewResultToOscal(testPolicy, "", "c2p", tt.policySetName).WithHubClients(hubClients)
+			results, err := reporter.GenerateResults()
+			require.NoError(t, err)
+			require.Len(t, results.ObservationsByCheck, len(expected.ObservationsByCheck))
+
+			if !tt.wantSubjects {
+				for _, observation := range results.ObservationsByCheck {
+					require.Empty(t, observation.Subjects)
+				}
+				return
+			}
+			diff := cmp.Diff(expected, results,
+				cmpopts.IgnoreFields(policy.ObservationByCheck{}, "Collected"),
+				cmpopts.IgnoreFields(policy.Subject{}, "EvaluatedOn"),
+				cmpopts.IgnoreFields(policy.Subject{}, "ResourceID"),
+				cmpopts.SortSlices(func(i, j policy.Subject) bool {
+					return i.Title < j.Title
+				}),
+			)
+			require.Empty(t, diff)
+		})
+	}
+
+	// Results cannot be read from a hub that does not serve PlacementDecisions
+	hubClients, err = ocmk8sclients.NewOcmK8sClientSet(&discoveryfake.FakeDiscovery{Fake: &clienttesting.Fake{Resources: hubResources[:3]}}, dyClient)
+	require.NoError(t, err)
+	require.Nil(t, hubClients.PlacementDecision)
+	_, err = NewResultToOscal(testPolicy, "", "c2p", "Managed Kubernetes").WithHubClients(hubClients).GenerateResults()
+	require.EqualError(t, err, "the hub does not serve PolicySets and PlacementDecisions")
 }
 
 func TestConfigure(t *testing.T) {
@@ -155,9 +311,11 @@ func TestConfigure(t *testing.T) {
 		"policy-dir": policyDir,
 	}
 	err := plugin.Configure(configuration)
-	require.EqualError(t, err, "policy set name must be set")
+	require.ErrorContains(t, err, "policy set name must be set")
+	require.ErrorContains(t, err, "policy results directory or kubeconfig must be set")
 
 	configuration["policy-set-name"] = "set"
+	configuration["policy-results-dir"] = pkg.PathFromPkgDirectory("./testdata/ocm/policy-results")
 
 	configuration["policy-dir"] = "not-exist"
 	err = plugin.Configure(configuration)
@@ -166,9 +324,133 @@ func TestConfigure(t *testing.T) {
 	configuration["policy-dir"] = policyDir
 	err = plugin.Configure(configuration)
 	require.NoError(t, err)
+
+	configuration["cluster-selectors"] = "env in (dev"
+	configuration["placement-kind"] = "cluster-set"
+	configuration["remediation-action"] = "delete"
+	configuration["severity-overrides"] = "policy-high-scan"
+	configuration["kubeconfig"] = "not-exist-kubeconfig"
+	configuration["output-mode"] = "tree"
+	err = plugin.Configure(configuration)
+	require.ErrorContains(t, err, "invalid cluster selectors \"env in (dev\"")
+	require.ErrorContains(t, err, "invalid placement kind \"cluster-set\"")
+	require.ErrorContains(t, err, "invalid remediation action \"delete\": must be one of inform, enforce")
+	require.ErrorContains(t, err, "invalid severity override \"policy-high-scan\"")
+	require.ErrorContains(t, err, "path \"not-exist-kubeconfig\"")
+	require.ErrorContains(t, err, "invalid output mode \"tree\": must be one of flat, kustomize")
+}
+
+func TestCompose(t *testing.T) {
+	policyDir := pkg.PathFromPkgDirectory("./testdata/ocm/policies")
+	testPolicy := createPolicy(t)
+
+	tests := []struct {
+		name          string
+		config        Config
+		wantPlacement string
+		assertFunc    func(*testing.T, pgtype.PolicyGenerator)
+	}{
+		{
+			name: "Valid/Defaults",
+			config: Config{
+				Namespace:     "c2p",
+				PolicySetName: "Managed Kubernetes",
+			},
+			wantPlacement: `apiVersion: cluster.open-cluster-management.io/v1beta1
+kind: Placement
+metadata:
+  creationTimestamp: null
+  name: placement-managed-kubernetes
+  namespace: c2p
+spec:
+  predicates:
+  - requiredClusterSelector:
+      labelSelector:
+        matchLabels:
+          env: dev
+`,
+			assertFunc: func(t *testing.T, generator pgtype.PolicyGenerator) {
+				require.Equal(t, "./placement.yaml", generator.PolicyDefaults.Placement.PlacementPath)
+				require.Equal(t, []string{"*"}, generator.PolicyDefaults.NamespaceSelector.Include)
+				require.Contains(t, generator.PolicyDefaults.NamespaceSelector.Exclude, "kube-system")
+				for _, policyConfig := range generator.Policies {
+					if policyConfig.Name == "policy-deployment" {
+						require.Equal(t, "inform", policyConfig.RemediationAction)
+						require.Equal(t, "low", policyConfig.Severity)
+					}
+				}
+			},
+		},
+		{
+			name: "Valid/WithOverrides",
+			config: Config{
+				Namespace:         "c2p",
+				PolicySetName:     "Managed Kubernetes",
+				ClusterSelectors:  "vendor=OpenShift,region in (us-east,us-west)",
+				PlacementKind:     PlacementKindPlacementRule,
+				NamespaceInclude:  "app-*, web",
+				NamespaceExclude:  "kube-*",
+				RemediationAction: "enforce",
+				Severity:          "medium",
+				SeverityOverrides: "policy-high-scan=critical",
+			},
+			wantPlacement: `apiVersion: apps.open-cluster-management.io/v1
+kind: PlacementRule
+metadata:
+  creationTimestamp: null
+  name: placement-managed-kubernetes
+  namespace: c2p
+spec:
+  clusterSelector:
+    matchExpressions:
+    - key: region
+      operator: In
+      values:
+      - us-east
+      - us-west
+    matchLabels:
+      vendor: OpenShift
+status: {}
+`,
+			assertFunc: func(t *testing.T, generator pgtype.PolicyGenerator) {
+				require.Equal(t, "./placement.yaml", generator.PolicyDefaults.Placement.PlacementRulePath)
+				require.Equal(t, "./placement.yaml", generator.PolicySetDefaults.Placement.PlacementRulePath)
+				require.Equal(t, []string{"app-*", "web"}, generator.PolicyDefaults.NamespaceSelector.Include)
+				require.Equal(t, []string{"kube-*"}, generator.PolicyDefaults.NamespaceSelector.Exclude)
+				for _, policyConfig := range generator.Policies {
+					require.Equal(t, "enforce", policyConfig.RemediationAction)
+					if policyConfig.Name == "policy-high-scan" {
+						require.Equal(t, "critical", policyConfig.Severity)
+					} else {
+						require.Equal(t, "medium", policyConfig.Severity)
+					}
+					for _, manifest := range policyConfig.Manifests {
+						require.Empty(t, manifest.RemediationAction)
+						require.Empty(t, manifest.Severity)
+					}
+				}
+			},
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			tempDir := pkg.NewTempDirectory(t.TempDir())
+			composer := NewComposerByTempDirectory(policyDir, tempDir)
+			require.NoError(t, composer.Compose(testPolicy, c.config))
+
+			placement, err := os.ReadFile(filepath.Join(tempDir.GetTempDir(), "placement.yaml"))
+			require.NoError(t, err)
+			require.Equal(t, c.wantPlacement, string(placement))
+
+			var generator pgtype.PolicyGenerator
+			require.NoError(t, pkg.LoadYamlFileToObject(filepath.Join(tempDir.GetTempDir(), "policy-generator.yaml"), &generator))
+			c.assertFunc(t, generator)
+		})
+	}
 }
 
-func createPolicy(t *testing.T) []extensions.RuleSet {
+func createPolicy(t *testing.T) policy.Policy {
 	cdPath := pkg.PathFromPkgDirectory("./testdata/ocm/component-definition.json")
 
 	file, err := os.Open(cdPath)
@@ -191,5 +473,68 @@ func createPolicy(t *testing.T) []extensions.RuleSet {
 
 	ruleSets, err := store.FindByComponent(context.TODO(), "Managed Kubernetes")
 	require.NoError(t, err)
-	return ruleSets
+	return policy.FromRuleSets(ruleSets)
+}
+
+type fakeResource struct {
+	kind, namespace, name string
+}
+
+func (f fakeResource) GetKind() string      { return f.kind }
+func (f fakeResource) GetNamespace() string { return f.namespace }
+func (f fakeResource) GetName() string      { return f.name }
+func (f fakeResource) AsYAML() ([]byte, error) {
+	return []byte("kind: " + f.kind + "\nmetadata:\n  name: " + f.name + "\n"), nil
+}
+
+func TestWriteKustomizeLayout(t *testing.T) {
+	outputDir := t.TempDir()
+	setDir := filepath.Join(outputDir, "managed-kubernetes")
+
+	firstRun := []generatedResource{
+		fakeResource{kind: "Policy", namespace: "c2p", name: "policy-high-scan"},
+		fakeResource{kind: "Policy", namespace: "c2p", name: "policy-deployment"},
+		fakeResource{kind: "ConfigMap", namespace: "c2p", name: "c2p-parameters"},
+		fakeResource{kind: "PolicySet", namespace: "c2p", name: "managed-kubernetes"},
+	}
+	require.NoError(t, writeKustomizeLayout(outputDir, "Managed Kubernetes", firstRun))
+
+	// A file not owned by the plugin must survive the cleanup
+	userFile := filepath.Join(setDir, "README.md")
+	require.NoError(t, os.WriteFile(userFile, []byte("docs"), 0600))
+
+	secondRun := []generatedResource{
+		fakeResource{kind: "Policy", namespace: "c2p", name: "policy-high-scan"},
+		fakeResource{kind: "ConfigMap", namespace: "c2p", name: "c2p-parameters"},
+		fakeResource{kind: "PolicySet", namespace: "c2p", name: "managed-kubernetes"},
+		fakeResource{kind: "Placement", name: "placement-managed-kubernetes"},
+	}
+	require.NoError(t, writeKustomizeLayout(outputDir, "Managed Kubernetes", secondRun))
+
+	expectedFiles := []string{
+		"configmap.c2p.c2p-parameters.yaml",
+		"placement.placement-managed-kubernetes.yaml",
+		"policy.c2p.policy-high-scan.yaml",
+		"policyset.c2p.managed-kubernetes.yaml",
+	}
+	for _, fname := range expectedFiles {
+		require.FileExists(t, filepath.Join(setDir, fname))
+	}
+	require.NoFileExists(t, filepath.Join(setDir, "policy.c2p.policy-deployment.yaml"))
+	require.FileExists(t, userFile)
+
+	var kustomization typekustomize.Kustomization
+	require.NoError(t, pkg.LoadYamlFileToObject(filepath.Join(setDir, kustomizationFileName), &kustomization))
+	require.Equal(t, typekustomize.KustomizationKind, kustomization.Kind)
+	require.Equal(t, expectedFiles, kustomization.Resources)
+
+	index, err := loadIndex(setDir)
+	require.NoError(t, err)
+	require.Equal(t, "Managed Kubernetes", index.PolicySet)
+	require.Len(t, index.Files, 4)
+	require.Equal(t, IndexEntry{Path: "placement.placement-managed-kubernetes.yaml", Kind: "Placement", Name: "placement-managed-kubernetes"}, index.Files[1])
+
+	// Refuse to clean up a directory whose index was not generated by the plugin
+	require.NoError(t, os.WriteFile(filepath.Join(setDir, indexFileName), []byte("kind: Other\n"), 0600))
+	require.ErrorContains(t, writeKustomizeLayout(outputDir, "Managed Kubernetes", secondRun), "is not a manifest index")
 }
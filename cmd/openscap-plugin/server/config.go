@@ -0,0 +1,92 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+)
+
+const (
+	defaultRuleIDPrefix      = "xccdf_org.ssgproject.content_rule_"
+	defaultValueIDPrefix     = "xccdf_org.ssgproject.content_value_"
+	defaultTailoredProfileID = "xccdf_compliance-to-policy_profile_tailored"
+	xccdfIDPrefix            = "xccdf_"
+)
+
+// profileIDPattern is the format of XCCDF 1.2 profile IDs.
+var profileIDPattern = regexp.MustCompile(`^xccdf_[^_]+_profile_.+$`)
+
+type Config struct {
+	// PolicyResultsDir is a directory of XCCDF result files and ARF reports.
+	PolicyResultsDir string `mapstructure:"policy-results-dir"`
+	OutputDir        string `mapstructure:"output-dir"`
+	// Profile is the ID of the benchmark profile extended by the tailored profile.
+	Profile string `mapstructure:"profile"`
+	// BenchmarkHref is the location of the benchmark or data stream the tailoring file applies to.
+	BenchmarkHref string `mapstructure:"benchmark-href"`
+	// TailoredProfileID is the ID of the profile defined by the tailoring file.
+	TailoredProfileID string `mapstructure:"tailored-profile-id"`
+	// RuleIDPrefix is prepended to check IDs to get the XCCDF rule IDs.
+	RuleIDPrefix string `mapstructure:"rule-id-prefix"`
+	// ValueIDPrefix is prepended to parameter IDs to get the XCCDF value IDs.
+	ValueIDPrefix string `mapstructure:"value-id-prefix"`
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if err := pluginutil.CheckRequired("policy results directory", c.PolicyResultsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckRequired("output directory", c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.PolicyResultsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Profile != "" && !profileIDPattern.MatchString(c.Profile) {
+		errs = append(errs, fmt.Errorf("invalid profile %q: must match %s", c.Profile, profileIDPattern))
+	}
+	if c.TailoredProfileID != "" && !profileIDPattern.MatchString(c.TailoredProfileID) {
+		errs = append(errs, fmt.Errorf("invalid tailored profile id %q: must match %s", c.TailoredProfileID, profileIDPattern))
+	}
+	return errors.Join(errs...)
+}
+
+// ProfileID returns the ID of the tailored profile.
+func (c Config) ProfileID() string {
+	if c.TailoredProfileID == "" {
+		return defaultTailoredProfileID
+	}
+	return c.TailoredProfileID
+}
+
+// RuleID returns the XCCDF rule ID of a check. Check IDs that are XCCDF IDs are used as is.
+func (c Config) RuleID(checkID string) string {
+	return withPrefix(c.RuleIDPrefix, defaultRuleIDPrefix, checkID)
+}
+
+// ValueID returns the XCCDF value ID of a parameter. Parameter IDs that are XCCDF IDs are used as is.
+func (c Config) ValueID(parameterID string) string {
+	return withPrefix(c.ValueIDPrefix, defaultValueIDPrefix, parameterID)
+}
+
+func withPrefix(prefix, defaultPrefix, id string) string {
+	if strings.HasPrefix(id, xccdfIDPrefix) {
+		return id
+	}
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	return prefix + id
+}
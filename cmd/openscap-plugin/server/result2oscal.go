@@ -0,0 +1,326 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	typexccdf "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/xccdf"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	FormatXCCDF = "xccdf"
+	FormatARF   = "arf"
+)
+
+// ScanResult is a TestResult loaded from a results file.
+type ScanResult struct {
+	// Path is the path of the results file relative to the results directory.
+	Path string
+	// Format is the format of the results file, FormatXCCDF or FormatARF.
+	Format     string
+	TestResult typexccdf.TestResult
+}
+
+// Host returns the name of the scanned host.
+func (s ScanResult) Host() string {
+	for _, target := range s.TestResult.Target {
+		if target = strings.TrimSpace(target); target != "" {
+			return target
+		}
+	}
+	if fqdn := s.fact(typexccdf.FactFQDN); fqdn != "" {
+		return fqdn
+	}
+	return s.TestResult.ID
+}
+
+func (s ScanResult) fact(name string) string {
+	for _, fact := range s.TestResult.TargetFacts {
+		if fact.Name == name {
+			return strings.TrimSpace(fact.Value)
+		}
+	}
+	return ""
+}
+
+type ResultToOscal struct {
+	policy      policy.Policy
+	scanResults []ScanResult
+	config      Config
+}
+
+func NewResultToOscal(pl policy.Policy, scanResults []ScanResult, config Config) *ResultToOscal {
+	return &ResultToOscal{
+		policy:      pl,
+		scanResults: scanResults,
+		config:      config,
+	}
+}
+
+func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
+	scans := latestByHost(r.scanResults)
+
+	var inventoryItems []policy.InventoryItem
+	for _, scan := range scans {
+		inventoryItems = append(inventoryItems, newHostInventoryItem(scan))
+	}
+
+	var observations []policy.ObservationByCheck
+	for _, rule := range r.policy {
+		for _, check := range rule.Checks {
+			ruleID := r.config.RuleID(check.ID)
+			logger.Debug(fmt.Sprintf("processing check %s (%s) for rule %s", check.ID, ruleID, rule.Rule.ID))
+			observation := policy.ObservationByCheck{
+				Title:       rule.Rule.ID,
+				CheckID:     check.ID,
+				Description: fmt.Sprintf("Observation of check %s", check.ID),
+				Methods:     []string{"TEST-AUTOMATED"},
+				Props: []policy.Property{
+					pluginutil.MakeProp("assessment-rule-id", rule.Rule.ID),
+					pluginutil.MakeProp("xccdf-rule-id", ruleID),
+				},
+				Collected: time.Now(),
+				Subjects:  []policy.Subject{},
+			}
+			for _, scan := range scans {
+				ruleResult := findRuleResult(scan.TestResult, ruleID)
+				if ruleResult == nil {
+					continue
+				}
+				observation.Subjects = append(observation.Subjects, newHostSubject(scan, ruleResult))
+				observation.RelevantEvidences = append(observation.RelevantEvidences, policy.Link{
+					Description: fmt.Sprintf("XCCDF TestResult %s for host %s", scan.TestResult.ID, scan.Host()),
+					Href:        scan.Path,
+					Props: []policy.Property{
+						pluginutil.MakeProp("report-format", scan.Format),
+					},
+				})
+			}
+			observations = append(observations, observation)
+		}
+	}
+	return policy.PVPResult{
+		ObservationsByCheck: observations,
+		InventoryItems:      inventoryItems,
+	}, nil
+}
+
+func newHostSubject(scan ScanResult, ruleResult *typexccdf.RuleResult) policy.Subject {
+	host := scan.Host()
+	props := []policy.Property{
+		pluginutil.MakeProp("xccdf-result", ruleResult.Result),
+		pluginutil.MakeProp("test-result-id", scan.TestResult.ID),
+	}
+	if ruleResult.Severity != "" {
+		props = append(props, pluginutil.MakeProp("severity", ruleResult.Severity))
+	}
+	if scan.TestResult.Profile != nil && scan.TestResult.Profile.IDRef != "" {
+		props = append(props, pluginutil.MakeProp("profile", scan.TestResult.Profile.IDRef))
+	}
+	for _, ident := range ruleResult.Idents {
+		props = append(props, pluginutil.MakeProp("ident", strings.TrimSpace(ident.Value)))
+	}
+
+	var messages []string
+	for _, message := range ruleResult.Messages {
+		if m := strings.TrimSpace(message.Value); m != "" {
+			messages = append(messages, m)
+		}
+	}
+	reason := strings.Join(messages, "; ")
+	if reason == "" {
+		reason = fmt.Sprintf("xccdf result: %s", ruleResult.Result)
+	}
+
+	evaluatedOn := scanTime(ruleResult.Time)
+	if evaluatedOn.IsZero() {
+		evaluatedOn = scanTime(scan.TestResult.EndTime)
+	}
+	if evaluatedOn.IsZero() {
+		evaluatedOn = time.Now()
+	}
+
+	return policy.Subject{
+		Title:       host,
+		ResourceID:  host,
+		Type:        "inventory-item",
+		Result:      mapResult(ruleResult.Result),
+		EvaluatedOn: evaluatedOn,
+		Reason:      reason,
+		Props:       props,
+	}
+}
+
+// newHostInventoryItem returns the inventory item of a scanned host. Host subjects
+// reference it by the host name.
+func newHostInventoryItem(scan ScanResult) policy.InventoryItem {
+	host := scan.Host()
+	props := []policy.Property{
+		pluginutil.MakeProp("asset-type", "operating-system"),
+	}
+	if fqdn := scan.fact(typexccdf.FactFQDN); fqdn != "" {
+		props = append(props, pluginutil.MakeProp("fqdn", fqdn))
+	}
+	for _, address := range scan.TestResult.TargetAddress {
+		ip := net.ParseIP(strings.TrimSpace(address))
+		if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+			continue
+		}
+		if ip.To4() != nil {
+			props = append(props, pluginutil.MakeProp("ipv4-address", ip.String()))
+		} else {
+			props = append(props, pluginutil.MakeProp("ipv6-address", ip.String()))
+		}
+	}
+	for _, fact := range scan.TestResult.TargetFacts {
+		mac := strings.TrimSpace(fact.Value)
+		if fact.Name == typexccdf.FactMAC && mac != "" && mac != "00:00:00:00:00:00" {
+			props = append(props, pluginutil.MakeProp("mac-address", mac))
+		}
+	}
+	return policy.InventoryItem{
+		ID:          host,
+		Description: fmt.Sprintf("Host %s", host),
+		Props:       props,
+	}
+}
+
+// mapResult maps an XCCDF 1.2 rule result to a policy.Result.
+func mapResult(result string) policy.Result {
+	switch result {
+	case "pass", "fixed", "informational":
+		return policy.ResultPass
+	case "fail":
+		return policy.ResultFail
+	case "error", "unknown":
+		return policy.ResultError
+	case "notapplicable", "notchecked", "notselected":
+		return policy.ResultSkipped
+	default:
+		return policy.ResultInvalid
+	}
+}
+
+func findRuleResult(testResult typexccdf.TestResult, ruleID string) *typexccdf.RuleResult {
+	for idx := range testResult.RuleResults {
+		if testResult.RuleResults[idx].IDRef == ruleID {
+			return &testResult.RuleResults[idx]
+		}
+	}
+	return nil
+}
+
+// latestByHost returns the most recent scan of each host, sorted by host name.
+func latestByHost(scanResults []ScanResult) []ScanResult {
+	latest := map[string]ScanResult{}
+	for _, scan := range scanResults {
+		host := scan.Host()
+		current, ok := latest[host]
+		if !ok || scanTime(scan.TestResult.EndTime).After(scanTime(current.TestResult.EndTime)) {
+			latest[host] = scan
+		}
+	}
+	scans := make([]ScanResult, 0, len(latest))
+	for _, scan := range latest {
+		scans = append(scans, scan)
+	}
+	sort.Slice(scans, func(i, j int) bool {
+		return scans[i].Host() < scans[j].Host()
+	})
+	return scans
+}
+
+// scanTime parses an XCCDF timestamp, which may omit the time zone. It returns the zero time if the
+// timestamp cannot be parsed.
+func scanTime(timestamp string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(timestamp)); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// LoadTestResults loads the XCCDF 1.2 TestResults from the XML files in dir and its subdirectories.
+// Both XCCDF result files and ARF reports are supported.
+func LoadTestResults(dir string) ([]ScanResult, error) {
+	var scanResults []ScanResult
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".xml") {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		results, err := loadTestResultsFromFile(path, relPath)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", relPath, err)
+		}
+		if len(results) == 0 {
+			logger.Debug(fmt.Sprintf("no TestResult found in %s", relPath))
+		}
+		scanResults = append(scanResults, results...)
+		return nil
+	})
+	return scanResults, err
+}
+
+func loadTestResultsFromFile(path, relPath string) ([]ScanResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var scanResults []ScanResult
+	format := ""
+	decoder := xml.NewDecoder(file)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if format == "" {
+			format = FormatXCCDF
+			if start.Name.Space == typexccdf.ARFNamespace {
+				format = FormatARF
+			}
+		}
+		if start.Name.Space != typexccdf.Namespace || start.Name.Local != "TestResult" {
+			continue
+		}
+		testResult := typexccdf.TestResult{}
+		if err := decoder.DecodeElement(&testResult, &start); err != nil {
+			return nil, err
+		}
+		scanResults = append(scanResults, ScanResult{Path: relPath, Format: format, TestResult: testResult})
+	}
+	return scanResults, nil
+}
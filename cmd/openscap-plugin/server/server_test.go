@@ -0,0 +1,237 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oscal-compass/oscal-sdk-go/settings"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	typexccdf "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/xccdf"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin/plugintest"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestTailoringGenerator(t *testing.T) {
+	tempDir := t.TempDir()
+	config := Config{
+		OutputDir:     tempDir,
+		Profile:       "xccdf_org.ssgproject.content_profile_cis",
+		BenchmarkHref: "/usr/share/xml/scap/ssg/content/ssg-rhel9-ds.xml",
+	}
+	generator := NewTailoringGenerator(config)
+	generator.now = func() time.Time {
+		return time.Date(2025, 3, 20, 10, 0, 0, 0, time.UTC)
+	}
+	require.NoError(t, generator.Generate(createPolicy(t)))
+
+	data, err := os.ReadFile(filepath.Join(tempDir, TailoringFileName))
+	require.NoError(t, err)
+	tailoring := typexccdf.Tailoring{}
+	require.NoError(t, xml.Unmarshal(data, &tailoring))
+
+	require.Equal(t, typexccdf.Namespace, tailoring.XMLName.Space)
+	require.Equal(t, "xccdf_compliance-to-policy_tailoring_tailored", tailoring.ID)
+	require.Equal(t, &typexccdf.BenchmarkRef{Href: "/usr/share/xml/scap/ssg/content/ssg-rhel9-ds.xml"}, tailoring.Benchmark)
+	require.Equal(t, typexccdf.TailoringVersion{Time: "2025-03-20T10:00:00Z", Value: "1"}, tailoring.Version)
+	require.Len(t, tailoring.Profiles, 1)
+
+	profile := tailoring.Profiles[0]
+	require.Equal(t, "xccdf_compliance-to-policy_profile_tailored", profile.ID)
+	require.Equal(t, "xccdf_org.ssgproject.content_profile_cis", profile.Extends)
+	wantSelects := []typexccdf.Select{
+		{IDRef: "xccdf_org.ssgproject.content_rule_accounts_tmout", Selected: true},
+		{IDRef: "xccdf_org.ssgproject.content_rule_partition_for_tmp", Selected: true},
+		{IDRef: "xccdf_org.ssgproject.content_rule_sshd_disable_root_login", Selected: true},
+	}
+	require.ElementsMatch(t, wantSelects, profile.Selects)
+	wantSetValues := []typexccdf.SetValue{
+		{IDRef: "xccdf_org.ssgproject.content_value_var_accounts_tmout", Value: "600"},
+	}
+	require.Equal(t, wantSetValues, profile.SetValues)
+}
+
+func TestLoadTestResults(t *testing.T) {
+	scanResults, err := LoadTestResults(pkg.PathFromPkgDirectory("./testdata/openscap/results"))
+	require.NoError(t, err)
+	require.Len(t, scanResults, 3)
+
+	formats := map[string]string{}
+	for _, scan := range scanResults {
+		formats[scan.Path] = scan.Format
+	}
+	require.Equal(t, map[string]string{
+		"db-01-arf.xml":                     FormatARF,
+		"web-01-xccdf-results.xml":          FormatXCCDF,
+		"web-01-xccdf-results-previous.xml": FormatXCCDF,
+	}, formats)
+}
+
+func TestResult2Oscal(t *testing.T) {
+	scanResults, err := LoadTestResults(pkg.PathFromPkgDirectory("./testdata/openscap/results"))
+	require.NoError(t, err)
+
+	reporter := NewResultToOscal(createPolicy(t), scanResults, Config{})
+	results, err := reporter.GenerateResults()
+	require.NoError(t, err)
+
+	wantInventoryItems := []policy.InventoryItem{
+		{
+			ID:          "db-01",
+			Description: "Host db-01",
+			Props: []policy.Property{
+				{Name: "asset-type", Value: "operating-system"},
+				{Name: "fqdn", Value: "db-01.example.com"},
+				{Name: "ipv4-address", Value: "10.0.0.21"},
+				{Name: "ipv6-address", Value: "fd00::21"},
+			},
+		},
+		{
+			ID:          "web-01",
+			Description: "Host web-01",
+			Props: []policy.Property{
+				{Name: "asset-type", Value: "operating-system"},
+				{Name: "fqdn", Value: "web-01.example.com"},
+				{Name: "ipv4-address", Value: "10.0.0.11"},
+				{Name: "mac-address", Value: "52:54:00:12:34:56"},
+			},
+		},
+	}
+	require.Equal(t, wantInventoryItems, results.InventoryItems)
+
+	type hostResult struct {
+		host   string
+		result policy.Result
+		reason string
+	}
+	wantResults := map[string][]hostResult{
+		"accounts_tmout": {
+			{host: "db-01", result: policy.ResultFail, reason: "xccdf result: fail"},
+			// the previous scan of web-01 is superseded by the latest one
+			{host: "web-01", result: policy.ResultPass, reason: "xccdf result: pass"},
+		},
+		"sshd_disable_root_login": {
+			{host: "db-01", result: policy.ResultPass, reason: "xccdf result: pass"},
+			{host: "web-01", result: policy.ResultFail, reason: "PermitRootLogin is set to yes in /etc/ssh/sshd_config"},
+		},
+		"partition_for_tmp": {
+			{host: "db-01", result: policy.ResultError, reason: "Unable to read /proc/mounts"},
+			{host: "web-01", result: policy.ResultSkipped, reason: "xccdf result: notapplicable"},
+		},
+	}
+	require.Len(t, results.ObservationsByCheck, 3)
+	observations := map[string]policy.ObservationByCheck{}
+	for _, observation := range results.ObservationsByCheck {
+		observations[observation.CheckID] = observation
+		t.Run(observation.CheckID, func(t *testing.T) {
+			var got []hostResult
+			for _, subject := range observation.Subjects {
+				require.Equal(t, "inventory-item", subject.Type)
+				require.Equal(t, subject.Title, subject.ResourceID)
+				got = append(got, hostResult{host: subject.ResourceID, result: subject.Result, reason: subject.Reason})
+			}
+			require.Equal(t, wantResults[observation.CheckID], got)
+			require.Len(t, observation.RelevantEvidences, 2)
+		})
+	}
+
+	observation := observations["accounts_tmout"]
+	require.Equal(t, []policy.Property{
+		{Name: "assessment-rule-id", Value: "accounts_tmout"},
+		{Name: "xccdf-rule-id", Value: "xccdf_org.ssgproject.content_rule_accounts_tmout"},
+	}, observation.Props)
+	require.Equal(t, policy.Link{
+		Description: "XCCDF TestResult xccdf_org.open-scap_testresult_xccdf_org.ssgproject.content_profile_cis for host db-01",
+		Href:        "db-01-arf.xml",
+		Props:       []policy.Property{{Name: "report-format", Value: FormatARF}},
+	}, observation.RelevantEvidences[0])
+
+	subject := observation.Subjects[1]
+	require.Equal(t, time.Date(2025, 3, 20, 10, 1, 0, 0, time.UTC), subject.EvaluatedOn)
+	require.Equal(t, []policy.Property{
+		{Name: "xccdf-result", Value: "pass"},
+		{Name: "test-result-id", Value: "xccdf_org.open-scap_testresult_xccdf_org.ssgproject.content_profile_cis"},
+		{Name: "severity", Value: "medium"},
+		{Name: "profile", Value: "xccdf_org.ssgproject.content_profile_cis"},
+		{Name: "ident", Value: "CCE-83633-4"},
+	}, subject.Props)
+}
+
+func TestMapResult(t *testing.T) {
+	tests := []struct {
+		result string
+		want   policy.Result
+	}{
+		{result: "pass", want: policy.ResultPass},
+		{result: "fixed", want: policy.ResultPass},
+		{result: "informational", want: policy.ResultPass},
+		{result: "fail", want: policy.ResultFail},
+		{result: "error", want: policy.ResultError},
+		{result: "unknown", want: policy.ResultError},
+		{result: "notapplicable", want: policy.ResultSkipped},
+		{result: "notchecked", want: policy.ResultSkipped},
+		{result: "notselected", want: policy.ResultSkipped},
+		{result: "bogus", want: policy.ResultInvalid},
+	}
+	for _, c := range tests {
+		t.Run(c.result, func(t *testing.T) {
+			require.Equal(t, c.want, mapResult(c.result))
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	config := Config{}
+	require.Equal(t, "xccdf_org.ssgproject.content_rule_accounts_tmout", config.RuleID("accounts_tmout"))
+	require.Equal(t, "xccdf_com.example_rule_custom", config.RuleID("xccdf_com.example_rule_custom"))
+	require.Equal(t, "xccdf_org.ssgproject.content_value_var_accounts_tmout", config.ValueID("var_accounts_tmout"))
+
+	config.RuleIDPrefix = "xccdf_com.example_rule_"
+	require.Equal(t, "xccdf_com.example_rule_accounts_tmout", config.RuleID("accounts_tmout"))
+}
+
+func TestConfigure(t *testing.T) {
+	plugin := NewPlugin()
+	err := plugin.Configure(map[string]string{})
+	require.EqualError(t, err, "policy results directory must be set\noutput directory must be set")
+
+	configuration := map[string]string{
+		"policy-results-dir": "not-exist",
+		"output-dir":         t.TempDir(),
+	}
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "path \"not-exist\": stat not-exist: no such file or directory")
+
+	configuration["policy-results-dir"] = pkg.PathFromPkgDirectory("./testdata/openscap/results")
+	configuration["profile"] = "xccdf_org.ssgproject.content_profile_cis"
+	err = plugin.Configure(configuration)
+	require.NoError(t, err)
+
+	configuration["profile"] = "cis"
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "invalid profile \"cis\": must match ^xccdf_[^_]+_profile_.+$")
+	require.Equal(t, "xccdf_org.ssgproject.content_profile_cis", plugin.config.Profile)
+
+	// options of an earlier configuration are not kept
+	delete(configuration, "profile")
+	require.NoError(t, plugin.Configure(configuration))
+	require.Empty(t, plugin.config.Profile)
+}
+
+func createPolicy(t *testing.T) policy.Policy {
+	// apply the parameter values as the framework does before calling the plugin
+	testSettings := settings.NewSettings(
+		map[string]struct{}{"accounts_tmout": {}, "sshd_disable_root_login": {}, "partition_for_tmp": {}},
+		map[string]string{"var_accounts_tmout": "600"},
+	)
+	return plugintest.LoadPolicy(t, pkg.PathFromPkgDirectory("./testdata/openscap/component-definition.json"), "OpenSCAP", testSettings)
+}
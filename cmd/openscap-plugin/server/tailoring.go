@@ -0,0 +1,107 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	typexccdf "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/xccdf"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// TailoringFileName is the name of the tailoring file written to the output directory.
+const TailoringFileName = "tailoring.xml"
+
+// TailoringGenerator writes an XCCDF 1.2 tailoring file with a profile that selects the rule of each
+// OSCAL check and sets the XCCDF values from the rule parameters. The file can be passed to
+// `oscap xccdf eval --tailoring-file tailoring.xml --profile <tailored-profile-id>`.
+type TailoringGenerator struct {
+	config Config
+	now    func() time.Time
+	logger hclog.Logger
+}
+
+func NewTailoringGenerator(config Config) *TailoringGenerator {
+	return &TailoringGenerator{
+		config: config,
+		now:    time.Now,
+		logger: logger.Named("tailoring"),
+	}
+}
+
+func (g *TailoringGenerator) Generate(pl policy.Policy) error {
+	tailoring := g.Tailoring(pl)
+	data, err := xml.MarshalIndent(tailoring, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tailoring file: %w", err)
+	}
+	outputDir := g.config.OutputDir
+	if outputDir == "" {
+		outputDir = "."
+	}
+	path := filepath.Join(outputDir, TailoringFileName)
+	g.logger.Debug(fmt.Sprintf("writing tailoring file %s", path))
+	return os.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0o644)
+}
+
+// Tailoring returns the tailoring document for the policy.
+func (g *TailoringGenerator) Tailoring(pl policy.Policy) typexccdf.Tailoring {
+	profileID := g.config.ProfileID()
+	profile := typexccdf.TailoredProfile{
+		ID:      profileID,
+		Extends: g.config.Profile,
+		Title:   "Profile tailored by compliance-to-policy",
+	}
+
+	selected := map[string]bool{}
+	values := map[string]bool{}
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			ruleID := g.config.RuleID(check.ID)
+			if selected[ruleID] {
+				continue
+			}
+			selected[ruleID] = true
+			g.logger.Debug(fmt.Sprintf("selecting %s for rule %s", ruleID, ruleSet.Rule.ID))
+			profile.Selects = append(profile.Selects, typexccdf.Select{IDRef: ruleID, Selected: true})
+		}
+		parameter := ruleSet.Rule.Parameter
+		if parameter == nil || parameter.Value == "" {
+			continue
+		}
+		valueID := g.config.ValueID(parameter.ID)
+		if values[valueID] {
+			continue
+		}
+		values[valueID] = true
+		profile.SetValues = append(profile.SetValues, typexccdf.SetValue{IDRef: valueID, Value: parameter.Value})
+	}
+
+	tailoring := typexccdf.Tailoring{
+		ID: tailoringID(profileID),
+		Version: typexccdf.TailoringVersion{
+			Time:  g.now().UTC().Format(time.RFC3339),
+			Value: "1",
+		},
+		Profiles: []typexccdf.TailoredProfile{profile},
+	}
+	if g.config.BenchmarkHref != "" {
+		tailoring.Benchmark = &typexccdf.BenchmarkRef{Href: g.config.BenchmarkHref}
+	}
+	return tailoring
+}
+
+// tailoringID derives the ID of the tailoring document from the ID of its profile.
+func tailoringID(profileID string) string {
+	return strings.Replace(profileID, "_profile_", "_tailoring_", 1)
+}
@@ -0,0 +1,43 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+)
+
+type Config struct {
+	// PolicyResultsDir is a directory of SARIF 2.1.0 files.
+	PolicyResultsDir string `mapstructure:"policy-results-dir"`
+	OutputDir        string `mapstructure:"output-dir"`
+	// RuleMapping is the path to a YAML file that maps the rule IDs of the scanners to check IDs.
+	// Rule IDs that are not mapped are used as check IDs.
+	RuleMapping string `mapstructure:"rule-mapping"`
+	// Scanner is the scanner a configuration file is generated for, one of the scannerConfigs.
+	Scanner string `mapstructure:"scanner"`
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if err := pluginutil.CheckRequired("policy results directory", c.PolicyResultsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.PolicyResultsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.RuleMapping); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckOneOf("scanner", c.Scanner, scanners()); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+)
+
+// RuleMapping maps a rule of a scanner to an OSCAL check.
+type RuleMapping struct {
+	// Tool is the name of the scanner as reported in the tool driver of the SARIF runs, such as Checkov.
+	// A mapping without tool applies to the rules of all scanners.
+	Tool    string `json:"tool,omitempty"`
+	RuleID  string `json:"rule-id"`
+	CheckID string `json:"check-id"`
+}
+
+// RuleMappings is the mapping table between scanner rule IDs and check IDs.
+type RuleMappings []RuleMapping
+
+// LoadRuleMappings loads the mapping table from a YAML file.
+func LoadRuleMappings(path string) (RuleMappings, error) {
+	if path == "" {
+		return nil, nil
+	}
+	var mappings RuleMappings
+	if err := pkg.LoadYamlFileToObject(path, &mappings); err != nil {
+		return nil, fmt.Errorf("failed to load rule mapping %s: %w", path, err)
+	}
+	for idx, mapping := range mappings {
+		if mapping.RuleID == "" || mapping.CheckID == "" {
+			return nil, fmt.Errorf("rule mapping %d in %s must have a rule-id and a check-id", idx, path)
+		}
+	}
+	return mappings, nil
+}
+
+// CheckIDs returns the IDs of the checks a rule of a tool is mapped to. A rule that is not mapped is
+// assumed to have the ID of its check.
+func (m RuleMappings) CheckIDs(tool, ruleID string) []string {
+	var checkIDs []string
+	mapped := false
+	for _, mapping := range m {
+		if mapping.RuleID != ruleID || !matchesTool(mapping.Tool, tool) {
+			continue
+		}
+		mapped = true
+		checkIDs = append(checkIDs, mapping.CheckID)
+	}
+	if !mapped {
+		return []string{ruleID}
+	}
+	return checkIDs
+}
+
+// RuleIDs returns the IDs of the rules of a tool that are mapped to a check. A check that is not mapped
+// for any tool is assumed to have the ID of its rule.
+func (m RuleMappings) RuleIDs(tool, checkID string) []string {
+	var ruleIDs []string
+	mapped := false
+	for _, mapping := range m {
+		if mapping.CheckID != checkID {
+			continue
+		}
+		mapped = true
+		if matchesTool(mapping.Tool, tool) {
+			ruleIDs = append(ruleIDs, mapping.RuleID)
+		}
+	}
+	if !mapped {
+		return []string{checkID}
+	}
+	return ruleIDs
+}
+
+func matchesTool(mappingTool, tool string) bool {
+	return mappingTool == "" || strings.EqualFold(mappingTool, tool)
+}
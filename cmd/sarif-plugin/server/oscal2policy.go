@@ -0,0 +1,110 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// scannerConfig describes the configuration file of a scanner that restricts its scan to a list of rules.
+type scannerConfig struct {
+	// tool is the name of the scanner in its SARIF runs, used to look up its rule mappings.
+	tool     string
+	fileName string
+	build    func(ruleIDs []string) map[string]interface{}
+}
+
+var scannerConfigs = map[string]scannerConfig{
+	"checkov": {
+		tool:     "checkov",
+		fileName: ".checkov.yaml",
+		build: func(ruleIDs []string) map[string]interface{} {
+			return map[string]interface{}{"check": ruleIDs}
+		},
+	},
+	"kube-linter": {
+		tool:     "kube-linter",
+		fileName: ".kube-linter.yaml",
+		build: func(ruleIDs []string) map[string]interface{} {
+			return map[string]interface{}{
+				"checks": map[string]interface{}{
+					"doNotAutoAddDefaults": true,
+					"include":              ruleIDs,
+				},
+			}
+		},
+	},
+}
+
+func scanners() []string {
+	var names []string
+	for name := range scannerConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Oscal2Policy generates the configuration file of a scanner that enables the rules mapped to the OSCAL checks.
+type Oscal2Policy struct {
+	outputDir string
+	scanner   string
+	mappings  RuleMappings
+	logger    hclog.Logger
+}
+
+func NewOscal2Policy(outputDir, scanner string, mappings RuleMappings) *Oscal2Policy {
+	return &Oscal2Policy{
+		outputDir: outputDir,
+		scanner:   scanner,
+		mappings:  mappings,
+		logger:    logger.Named("composer"),
+	}
+}
+
+func (c *Oscal2Policy) Generate(pl policy.Policy) error {
+	if c.scanner == "" {
+		c.logger.Info("no scanner configured, skipping generation of the scanner configuration")
+		return nil
+	}
+	config, ok := scannerConfigs[c.scanner]
+	if !ok {
+		return fmt.Errorf("unsupported scanner %s", c.scanner)
+	}
+	ruleIDs := c.RuleIDs(pl, config.tool)
+	if err := os.MkdirAll(c.outputDir, 0o755); err != nil {
+		return err
+	}
+	return pkg.WriteObjToYamlFile(filepath.Join(c.outputDir, config.fileName), config.build(ruleIDs))
+}
+
+// RuleIDs returns the sorted rule IDs of a tool for the checks of the policy.
+func (c *Oscal2Policy) RuleIDs(pl policy.Policy, tool string) []string {
+	seen := map[string]struct{}{}
+	ruleIDs := []string{}
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			c.logger.Debug(fmt.Sprintf("processing check %s for rule %s", check.ID, ruleSet.Rule.ID))
+			for _, ruleID := range c.mappings.RuleIDs(tool, check.ID) {
+				if _, ok := seen[ruleID]; ok {
+					continue
+				}
+				seen[ruleID] = struct{}{}
+				ruleIDs = append(ruleIDs, ruleID)
+			}
+		}
+	}
+	sort.Strings(ruleIDs)
+	return ruleIDs
+}
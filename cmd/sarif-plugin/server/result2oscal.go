@@ -0,0 +1,334 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	typesarif "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/sarif"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// SARIFLog is a SARIF log loaded from a results file.
+type SARIFLog struct {
+	// Path is the path of the results file relative to the results directory.
+	Path    string
+	ModTime time.Time
+	Log     typesarif.Log
+}
+
+type ResultToOscal struct {
+	policy   policy.Policy
+	logs     []SARIFLog
+	mappings RuleMappings
+}
+
+func NewResultToOscal(pl policy.Policy, logs []SARIFLog, mappings RuleMappings) *ResultToOscal {
+	return &ResultToOscal{
+		policy:   pl,
+		logs:     logs,
+		mappings: mappings,
+	}
+}
+
+func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
+	var observations []policy.ObservationByCheck
+	for _, rule := range r.policy {
+		for _, check := range rule.Checks {
+			logger.Debug(fmt.Sprintf("processing check %s for rule %s", check.ID, rule.Rule.ID))
+			observation := policy.ObservationByCheck{
+				Title:       rule.Rule.ID,
+				CheckID:     check.ID,
+				Description: fmt.Sprintf("Observation of check %s", check.ID),
+				Methods:     []string{"TEST-AUTOMATED"},
+				Props: []policy.Property{
+					pluginutil.MakeProp("assessment-rule-id", rule.Rule.ID),
+				},
+				Collected: time.Now(),
+				Subjects:  []policy.Subject{},
+			}
+			for _, log := range r.logs {
+				for _, run := range log.Log.Runs {
+					subjects := r.subjects(check.ID, log, run)
+					if len(subjects) == 0 {
+						continue
+					}
+					observation.Subjects = append(observation.Subjects, subjects...)
+					observation.RelevantEvidences = append(observation.RelevantEvidences, evidence(log, run))
+				}
+			}
+			observations = append(observations, observation)
+		}
+	}
+	return policy.PVPResult{
+		ObservationsByCheck: observations,
+	}, nil
+}
+
+// subjects returns the subjects of the results of a run for a check. Many scanners only report failed
+// rules, so a rule declared by the tool without results is reported as passed.
+func (r *ResultToOscal) subjects(checkID string, log SARIFLog, run typesarif.Run) []policy.Subject {
+	tool := run.Tool.Driver.Name
+	var subjects []policy.Subject
+	reported := map[string]struct{}{}
+	for _, result := range run.Results {
+		ruleID := resolveRuleID(run, result)
+		if !contains(r.mappings.CheckIDs(tool, ruleID), checkID) {
+			continue
+		}
+		reported[ruleID] = struct{}{}
+		subjects = append(subjects, resultSubjects(log, run, ruleID, result)...)
+	}
+	for _, descriptor := range run.Tool.Driver.Rules {
+		if _, ok := reported[descriptor.ID]; ok || !contains(r.mappings.CheckIDs(tool, descriptor.ID), checkID) {
+			continue
+		}
+		subjects = append(subjects, policy.Subject{
+			Title:       fmt.Sprintf("Tool: %s, Rule: %s", tool, descriptor.ID),
+			ResourceID:  fmt.Sprintf("%s/%s", tool, descriptor.ID),
+			Type:        "resource",
+			Result:      policy.ResultPass,
+			EvaluatedOn: log.ModTime,
+			Reason:      fmt.Sprintf("no results reported for rule %s", descriptor.ID),
+			Props: []policy.Property{
+				pluginutil.MakeProp("tool", tool),
+				pluginutil.MakeProp("rule-id", descriptor.ID),
+			},
+		})
+	}
+	return subjects
+}
+
+// resultSubjects returns a subject for each location of a result. A result without location is reported
+// as a single subject for its rule.
+func resultSubjects(log SARIFLog, run typesarif.Run, ruleID string, result typesarif.Result) []policy.Subject {
+	tool := run.Tool.Driver.Name
+	kind := result.Kind
+	if kind == "" {
+		kind = typesarif.KindFail
+	}
+	props := []policy.Property{
+		pluginutil.MakeProp("tool", tool),
+		pluginutil.MakeProp("rule-id", ruleID),
+		pluginutil.MakeProp("kind", kind),
+	}
+	level := ""
+	if kind == typesarif.KindFail {
+		level = effectiveLevel(run, result)
+		props = append(props, pluginutil.MakeProp("level", level))
+	}
+	reason := strings.TrimSpace(result.Message.Text)
+	if reason == "" {
+		reason = strings.TrimSpace(result.Message.Markdown)
+	}
+	if reason == "" {
+		reason = fmt.Sprintf("%s result of rule %s", kind, ruleID)
+	}
+	subject := policy.Subject{
+		Type:        "resource",
+		Result:      mapResult(kind, level),
+		EvaluatedOn: log.ModTime,
+		Reason:      reason,
+	}
+
+	if len(result.Locations) == 0 {
+		subject.Title = fmt.Sprintf("Tool: %s, Rule: %s", tool, ruleID)
+		subject.ResourceID = fmt.Sprintf("%s/%s", tool, ruleID)
+		subject.Props = props
+		return []policy.Subject{subject}
+	}
+	var subjects []policy.Subject
+	for _, location := range result.Locations {
+		locationSubject := subject
+		locationSubject.Props = append(append([]policy.Property{}, props...), locationProps(location)...)
+		locationSubject.Title, locationSubject.ResourceID = locationTitle(tool, ruleID, location)
+		subjects = append(subjects, locationSubject)
+	}
+	return subjects
+}
+
+func locationProps(location typesarif.Location) []policy.Property {
+	var props []policy.Property
+	if physical := location.PhysicalLocation; physical != nil {
+		if physical.ArtifactLocation != nil && physical.ArtifactLocation.URI != "" {
+			props = append(props, pluginutil.MakeProp("file", physical.ArtifactLocation.URI))
+		}
+		if physical.Region != nil && physical.Region.StartLine > 0 {
+			props = append(props, pluginutil.MakeProp("line", fmt.Sprint(physical.Region.StartLine)))
+			if physical.Region.EndLine > physical.Region.StartLine {
+				props = append(props, pluginutil.MakeProp("end-line", fmt.Sprint(physical.Region.EndLine)))
+			}
+		}
+	}
+	for _, logical := range location.LogicalLocations {
+		if name := logicalName(logical); name != "" {
+			props = append(props, pluginutil.MakeProp("logical-location", name))
+		}
+	}
+	return props
+}
+
+// locationTitle returns the title and resource ID of the subject of a location.
+func locationTitle(tool, ruleID string, location typesarif.Location) (string, string) {
+	if physical := location.PhysicalLocation; physical != nil && physical.ArtifactLocation != nil && physical.ArtifactLocation.URI != "" {
+		uri := physical.ArtifactLocation.URI
+		if physical.Region != nil && physical.Region.StartLine > 0 {
+			return fmt.Sprintf("File: %s, Line: %d", uri, physical.Region.StartLine), fmt.Sprintf("%s:%d", uri, physical.Region.StartLine)
+		}
+		return fmt.Sprintf("File: %s", uri), uri
+	}
+	for _, logical := range location.LogicalLocations {
+		if name := logicalName(logical); name != "" {
+			return fmt.Sprintf("Location: %s", name), name
+		}
+	}
+	return fmt.Sprintf("Tool: %s, Rule: %s", tool, ruleID), fmt.Sprintf("%s/%s", tool, ruleID)
+}
+
+func logicalName(logical typesarif.LogicalLocation) string {
+	if logical.FullyQualifiedName != "" {
+		return logical.FullyQualifiedName
+	}
+	return logical.Name
+}
+
+func evidence(log SARIFLog, run typesarif.Run) policy.Link {
+	driver := run.Tool.Driver
+	link := policy.Link{
+		Description: fmt.Sprintf("SARIF log of %s", driver.Name),
+		Href:        log.Path,
+	}
+	version := driver.Version
+	if version == "" {
+		version = driver.SemanticVersion
+	}
+	if version != "" {
+		link.Props = append(link.Props, pluginutil.MakeProp("tool-version", version))
+	}
+	return link
+}
+
+// resolveRuleID returns the rule ID of a result, which may be given by reference to the rules of the tool.
+func resolveRuleID(run typesarif.Run, result typesarif.Result) string {
+	if result.RuleID != "" {
+		return result.RuleID
+	}
+	if result.Rule != nil && result.Rule.ID != "" {
+		return result.Rule.ID
+	}
+	if descriptor := findRule(run, result); descriptor != nil {
+		return descriptor.ID
+	}
+	return ""
+}
+
+func findRule(run typesarif.Run, result typesarif.Result) *typesarif.ReportingDescriptor {
+	rules := run.Tool.Driver.Rules
+	index := result.RuleIndex
+	if index == nil && result.Rule != nil {
+		index = result.Rule.Index
+	}
+	if index != nil && *index >= 0 && *index < len(rules) {
+		return &rules[*index]
+	}
+	ruleID := result.RuleID
+	if ruleID == "" && result.Rule != nil {
+		ruleID = result.Rule.ID
+	}
+	for idx := range rules {
+		if rules[idx].ID == ruleID {
+			return &rules[idx]
+		}
+	}
+	return nil
+}
+
+// effectiveLevel returns the level of a failed result. It defaults to the level configured for its rule,
+// then to warning.
+func effectiveLevel(run typesarif.Run, result typesarif.Result) string {
+	if result.Level != "" {
+		return result.Level
+	}
+	if descriptor := findRule(run, result); descriptor != nil && descriptor.DefaultConfiguration != nil && descriptor.DefaultConfiguration.Level != "" {
+		return descriptor.DefaultConfiguration.Level
+	}
+	return typesarif.LevelWarning
+}
+
+// mapResult maps the kind and level of a SARIF result to a policy.Result. Failed results with level error
+// are failures and those with a lower level are warnings. Results that need review are warnings too.
+func mapResult(kind, level string) policy.Result {
+	switch kind {
+	case typesarif.KindPass, typesarif.KindInformational:
+		return policy.ResultPass
+	case typesarif.KindNotApplicable:
+		return policy.ResultSkipped
+	case typesarif.KindReview, typesarif.KindOpen:
+		return policy.ResultWarning
+	case typesarif.KindFail:
+		switch level {
+		case typesarif.LevelError:
+			return policy.ResultFail
+		case typesarif.LevelWarning, typesarif.LevelNote:
+			return policy.ResultWarning
+		default:
+			return policy.ResultInvalid
+		}
+	default:
+		return policy.ResultInvalid
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadSARIFLogs loads the SARIF 2.1.0 logs from the .sarif and .json files in dir and its subdirectories.
+func LoadSARIFLogs(dir string) ([]SARIFLog, error) {
+	var logs []SARIFLog
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !(strings.HasSuffix(d.Name(), ".sarif") || strings.HasSuffix(d.Name(), ".json")) {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		log := typesarif.Log{}
+		if err := json.Unmarshal(data, &log); err != nil {
+			return fmt.Errorf("failed to load %s: %w", relPath, err)
+		}
+		if log.Version != typesarif.Version {
+			return fmt.Errorf("failed to load %s: unsupported SARIF version %q", relPath, log.Version)
+		}
+		logs = append(logs, SARIFLog{Path: relPath, ModTime: info.ModTime().UTC(), Log: log})
+		return nil
+	})
+	return logs, err
+}
@@ -0,0 +1,69 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/logging"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var (
+	_      policy.Provider = (*Plugin)(nil)
+	logger hclog.Logger    = logging.NewPluginLogger()
+)
+
+func Logger() hclog.Logger {
+	return logger
+}
+
+type Plugin struct {
+	config Config
+}
+
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Configure(m map[string]string) error {
+	var config Config
+	if err := mapstructure.Decode(m, &config); err != nil {
+		return errors.New("error decoding configuration")
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	p.config = config
+	return nil
+}
+
+func (p *Plugin) Generate(pl policy.Policy) error {
+	logger.Debug(fmt.Sprintf("Writing %s configuration to %s", p.config.Scanner, p.config.OutputDir))
+	mappings, err := LoadRuleMappings(p.config.RuleMapping)
+	if err != nil {
+		return err
+	}
+	composer := NewOscal2Policy(p.config.OutputDir, p.config.Scanner, mappings)
+	return composer.Generate(pl)
+}
+
+func (p *Plugin) GetResults(pl policy.Policy) (policy.PVPResult, error) {
+	mappings, err := LoadRuleMappings(p.config.RuleMapping)
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	logs, err := LoadSARIFLogs(p.config.PolicyResultsDir)
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	results := NewResultToOscal(pl, logs, mappings)
+	return results.GenerateResults()
+}
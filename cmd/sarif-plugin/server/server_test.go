@@ -0,0 +1,230 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oscal-compass/oscal-sdk-go/settings"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin/plugintest"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestOscal2Policy(t *testing.T) {
+	mappings, err := LoadRuleMappings(pkg.PathFromPkgDirectory("./testdata/sarif/rule-mapping.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		scanner  string
+		fileName string
+		want     map[string]interface{}
+	}{
+		{
+			scanner:  "checkov",
+			fileName: ".checkov.yaml",
+			want: map[string]interface{}{
+				"check": []interface{}{"CKV_AWS_19", "CKV_K8S_16"},
+			},
+		},
+		{
+			scanner:  "kube-linter",
+			fileName: ".kube-linter.yaml",
+			want: map[string]interface{}{
+				"checks": map[string]interface{}{
+					"doNotAutoAddDefaults": true,
+					"include":              []interface{}{"CKV_K8S_16", "privileged-container"},
+				},
+			},
+		},
+	}
+	for _, c := range tests {
+		t.Run(c.scanner, func(t *testing.T) {
+			tempDir := t.TempDir()
+			require.NoError(t, NewOscal2Policy(tempDir, c.scanner, mappings).Generate(createPolicy(t)))
+			var got map[string]interface{}
+			require.NoError(t, pkg.LoadYamlFileToObject(filepath.Join(tempDir, c.fileName), &got))
+			require.Equal(t, c.want, got)
+		})
+	}
+
+	tempDir := t.TempDir()
+	require.NoError(t, NewOscal2Policy(tempDir, "", mappings).Generate(createPolicy(t)))
+	entries, err := os.ReadDir(tempDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestRuleMappings(t *testing.T) {
+	mappings, err := LoadRuleMappings(pkg.PathFromPkgDirectory("./testdata/sarif/rule-mapping.yaml"))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"s3-bucket-encryption"}, mappings.CheckIDs("checkov", "CKV_AWS_19"))
+	// mappings with a tool only apply to the rules of that tool
+	require.Equal(t, []string{"CKV_AWS_19"}, mappings.CheckIDs("Trivy", "CKV_AWS_19"))
+	require.Equal(t, []string{"no-privileged-containers"}, mappings.CheckIDs("Trivy", "CKV_K8S_16"))
+	require.Equal(t, []string{"CKV_AWS_19"}, mappings.RuleIDs("Checkov", "s3-bucket-encryption"))
+	require.Equal(t, []string{"privileged-container", "CKV_K8S_16"}, mappings.RuleIDs("kube-linter", "no-privileged-containers"))
+	require.Empty(t, mappings.RuleIDs("kube-linter", "s3-bucket-encryption"))
+	require.Equal(t, []string{"unmapped"}, mappings.RuleIDs("Checkov", "unmapped"))
+
+	invalid := filepath.Join(t.TempDir(), "mapping.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("- rule-id: CKV_AWS_19\n"), 0o600))
+	_, err = LoadRuleMappings(invalid)
+	require.ErrorContains(t, err, "rule mapping 0 in")
+}
+
+func TestLoadSARIFLogs(t *testing.T) {
+	logs, err := LoadSARIFLogs(pkg.PathFromPkgDirectory("./testdata/sarif/results"))
+	require.NoError(t, err)
+	var paths []string
+	for _, log := range logs {
+		paths = append(paths, log.Path)
+	}
+	require.Equal(t, []string{"checkov.sarif", "kube-linter.sarif", "semgrep.json", "trivy.sarif"}, paths)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "old.sarif"), []byte(`{"version": "1.0.0", "runs": []}`), 0o600))
+	_, err = LoadSARIFLogs(dir)
+	require.EqualError(t, err, "failed to load old.sarif: unsupported SARIF version \"1.0.0\"")
+}
+
+func TestResult2Oscal(t *testing.T) {
+	mappings, err := LoadRuleMappings(pkg.PathFromPkgDirectory("./testdata/sarif/rule-mapping.yaml"))
+	require.NoError(t, err)
+	logs, err := LoadSARIFLogs(pkg.PathFromPkgDirectory("./testdata/sarif/results"))
+	require.NoError(t, err)
+
+	reporter := NewResultToOscal(createPolicy(t), logs, mappings)
+	results, err := reporter.GenerateResults()
+	require.NoError(t, err)
+
+	type subjectResult struct {
+		title  string
+		result policy.Result
+		reason string
+	}
+	wantResults := map[string][]subjectResult{
+		"s3-bucket-encryption": {
+			{title: "File: terraform/main.tf, Line: 1", result: policy.ResultFail, reason: "Ensure all data stored in the S3 bucket is securely encrypted at rest"},
+			{title: "File: terraform/main.tf, Line: 3", result: policy.ResultWarning, reason: "Bucket does not have encryption enabled"},
+		},
+		"no-privileged-containers": {
+			{title: "Tool: Checkov, Rule: CKV_K8S_16", result: policy.ResultPass, reason: "no results reported for rule CKV_K8S_16"},
+			{title: "File: k8s/deployment.yaml", result: policy.ResultFail, reason: "container \"api\" is privileged"},
+		},
+		"no-hardcoded-secrets": {
+			{title: "File: config/settings.py, Line: 12", result: policy.ResultFail, reason: "AWS Access Key ID detected"},
+			{title: "File: scripts/deploy.sh, Line: 4", result: policy.ResultFail, reason: "AWS Access Key ID detected"},
+		},
+	}
+	require.Len(t, results.ObservationsByCheck, 3)
+	observations := map[string]policy.ObservationByCheck{}
+	for _, observation := range results.ObservationsByCheck {
+		observations[observation.CheckID] = observation
+		t.Run(observation.CheckID, func(t *testing.T) {
+			var got []subjectResult
+			for _, subject := range observation.Subjects {
+				got = append(got, subjectResult{title: subject.Title, result: subject.Result, reason: subject.Reason})
+			}
+			require.Equal(t, wantResults[observation.CheckID], got)
+		})
+	}
+
+	observation := observations["s3-bucket-encryption"]
+	require.Equal(t, []policy.Link{
+		{Description: "SARIF log of Checkov", Href: "checkov.sarif", Props: []policy.Property{{Name: "tool-version", Value: "3.2.0"}}},
+		{Description: "SARIF log of Trivy", Href: "trivy.sarif", Props: []policy.Property{{Name: "tool-version", Value: "0.50.0"}}},
+	}, observation.RelevantEvidences)
+	subject := observation.Subjects[0]
+	require.Equal(t, "terraform/main.tf:1", subject.ResourceID)
+	require.Equal(t, []policy.Property{
+		{Name: "tool", Value: "Checkov"},
+		{Name: "rule-id", Value: "CKV_AWS_19"},
+		{Name: "kind", Value: "fail"},
+		{Name: "level", Value: "error"},
+		{Name: "file", Value: "terraform/main.tf"},
+		{Name: "line", Value: "1"},
+		{Name: "end-line", Value: "8"},
+	}, subject.Props)
+
+	// the rule of the kube-linter result is referenced by index and its level is the rule default
+	subject = observations["no-privileged-containers"].Subjects[1]
+	require.Equal(t, "k8s/deployment.yaml", subject.ResourceID)
+	require.Equal(t, []policy.Property{
+		{Name: "tool", Value: "kube-linter"},
+		{Name: "rule-id", Value: "privileged-container"},
+		{Name: "kind", Value: "fail"},
+		{Name: "level", Value: "error"},
+		{Name: "file", Value: "k8s/deployment.yaml"},
+		{Name: "logical-location", Value: "payments/api"},
+	}, subject.Props)
+}
+
+func TestMapResult(t *testing.T) {
+	tests := []struct {
+		kind  string
+		level string
+		want  policy.Result
+	}{
+		{kind: "pass", want: policy.ResultPass},
+		{kind: "informational", want: policy.ResultPass},
+		{kind: "notApplicable", want: policy.ResultSkipped},
+		{kind: "review", want: policy.ResultWarning},
+		{kind: "open", want: policy.ResultWarning},
+		{kind: "fail", level: "error", want: policy.ResultFail},
+		{kind: "fail", level: "warning", want: policy.ResultWarning},
+		{kind: "fail", level: "note", want: policy.ResultWarning},
+		{kind: "fail", level: "none", want: policy.ResultInvalid},
+		{kind: "unknown", want: policy.ResultInvalid},
+	}
+	for _, c := range tests {
+		t.Run(c.kind+"/"+c.level, func(t *testing.T) {
+			require.Equal(t, c.want, mapResult(c.kind, c.level))
+		})
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	plugin := NewPlugin()
+	err := plugin.Configure(map[string]string{})
+	require.EqualError(t, err, "policy results directory must be set")
+
+	configuration := map[string]string{
+		"policy-results-dir": "not-exist",
+	}
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "path \"not-exist\": stat not-exist: no such file or directory")
+
+	configuration["policy-results-dir"] = pkg.PathFromPkgDirectory("./testdata/sarif/results")
+	configuration["rule-mapping"] = pkg.PathFromPkgDirectory("./testdata/sarif/rule-mapping.yaml")
+	err = plugin.Configure(configuration)
+	require.NoError(t, err)
+
+	configuration["scanner"] = "semgrep"
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "invalid scanner \"semgrep\": must be one of checkov, kube-linter")
+	require.Empty(t, plugin.config.Scanner)
+
+	// options of an earlier configuration are not kept
+	delete(configuration, "scanner")
+	delete(configuration, "rule-mapping")
+	require.NoError(t, plugin.Configure(configuration))
+	require.Empty(t, plugin.config.RuleMapping)
+}
+
+func createPolicy(t *testing.T) policy.Policy {
+	// apply the parameter values as the framework does before calling the plugin
+	testSettings := settings.NewSettings(
+		map[string]struct{}{"s3-bucket-encryption": {}, "no-privileged-containers": {}, "no-hardcoded-secrets": {}},
+		map[string]string{},
+	)
+	return plugintest.LoadPolicy(t, pkg.PathFromPkgDirectory("./testdata/sarif/component-definition.json"), "SARIF", testSettings)
+}
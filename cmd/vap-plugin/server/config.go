@@ -0,0 +1,95 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+)
+
+const defaultParamsNamespace = "c2p"
+
+var validationActions = []string{
+	string(admissionregistrationv1.Deny),
+	string(admissionregistrationv1.Warn),
+	string(admissionregistrationv1.Audit),
+}
+
+type Config struct {
+	PoliciesDir string `mapstructure:"policy-dir"`
+	// ManifestsDir is a directory of Kubernetes manifests the policies are evaluated against.
+	ManifestsDir string `mapstructure:"manifests-dir"`
+	OutputDir    string `mapstructure:"output-dir"`
+	// ParamsNamespace is the namespace of the ConfigMaps holding the policy parameters.
+	ParamsNamespace string `mapstructure:"params-namespace"`
+	// ValidationActions is a comma-separated list of the validation actions of the generated bindings.
+	ValidationActions string `mapstructure:"validation-actions"`
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if err := pluginutil.CheckRequired("policy directory", c.PoliciesDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckRequired("output directory", c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.PoliciesDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.ManifestsDir); err != nil {
+		errs = append(errs, err)
+	}
+	if err := pluginutil.CheckPath(c.OutputDir); err != nil {
+		errs = append(errs, err)
+	}
+	actions := c.Actions()
+	for _, action := range actions {
+		if err := pluginutil.CheckOneOf("validation action", string(action), validationActions); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if hasAction(actions, admissionregistrationv1.Deny) && hasAction(actions, admissionregistrationv1.Warn) {
+		errs = append(errs, errors.New("validation actions Deny and Warn cannot be used together"))
+	}
+	return errors.Join(errs...)
+}
+
+// Namespace returns the namespace of the params ConfigMaps.
+func (c Config) Namespace() string {
+	if c.ParamsNamespace == "" {
+		return defaultParamsNamespace
+	}
+	return c.ParamsNamespace
+}
+
+// Actions returns the validation actions of the generated bindings. It defaults to Deny.
+func (c Config) Actions() []admissionregistrationv1.ValidationAction {
+	var actions []admissionregistrationv1.ValidationAction
+	for _, action := range strings.Split(c.ValidationActions, ",") {
+		action = strings.TrimSpace(action)
+		if action != "" {
+			actions = append(actions, admissionregistrationv1.ValidationAction(action))
+		}
+	}
+	if len(actions) == 0 {
+		return []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}
+	}
+	return actions
+}
+
+func hasAction(actions []admissionregistrationv1.ValidationAction, action admissionregistrationv1.ValidationAction) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
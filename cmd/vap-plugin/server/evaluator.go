@@ -0,0 +1,338 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/cel/environment"
+)
+
+// Variables available to the expressions of a ValidatingAdmissionPolicy.
+// The authorizer variables are not available offline.
+const (
+	objectVarName          = "object"
+	oldObjectVarName       = "oldObject"
+	paramsVarName          = "params"
+	requestVarName         = "request"
+	namespaceObjectVarName = "namespaceObject"
+	variablesVarName       = "variables"
+)
+
+// Evaluator compiles the CEL expressions of ValidatingAdmissionPolicies with the Kubernetes CEL libraries
+// so that they can be evaluated against manifests without a cluster.
+type Evaluator struct {
+	env *cel.Env
+}
+
+// NewEvaluator returns an Evaluator with the Kubernetes CEL environment used for stored expressions.
+func NewEvaluator() (*Evaluator, error) {
+	envSet, err := environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion(), true).Extend(
+		environment.VersionedOptions{
+			IntroducedVersion: environment.DefaultCompatibilityVersion(),
+			EnvOptions: []cel.EnvOption{
+				cel.Variable(objectVarName, cel.DynType),
+				cel.Variable(oldObjectVarName, cel.DynType),
+				cel.Variable(paramsVarName, cel.DynType),
+				cel.Variable(requestVarName, cel.DynType),
+				cel.Variable(namespaceObjectVarName, cel.DynType),
+				cel.Variable(variablesVarName, cel.MapType(cel.StringType, cel.DynType)),
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	env, err := envSet.Env(environment.StoredExpressions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	return &Evaluator{env: env}, nil
+}
+
+type namedProgram struct {
+	name    string
+	program cel.Program
+}
+
+type compiledValidation struct {
+	validation        admissionregistrationv1.Validation
+	program           cel.Program
+	messageExpression cel.Program
+}
+
+// CompiledPolicy is a ValidatingAdmissionPolicy with compiled expressions.
+type CompiledPolicy struct {
+	policy          *admissionregistrationv1.ValidatingAdmissionPolicy
+	matchConditions []namedProgram
+	variables       []namedProgram
+	validations     []compiledValidation
+}
+
+// Decision is the outcome of the validations of a policy for an object.
+type Decision struct {
+	// Violations are the messages of the failed validations.
+	Violations []string
+	// Err is set when a validation could not be evaluated and the failure policy is Fail.
+	Err error
+}
+
+// Compile compiles the match conditions, variables and validations of the policy.
+func (e *Evaluator) Compile(vap *admissionregistrationv1.ValidatingAdmissionPolicy) (*CompiledPolicy, error) {
+	compiled := &CompiledPolicy{policy: vap}
+	var errs []error
+	for _, condition := range vap.Spec.MatchConditions {
+		program, err := e.compile(condition.Expression)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("match condition %s: %w", condition.Name, err))
+			continue
+		}
+		compiled.matchConditions = append(compiled.matchConditions, namedProgram{name: condition.Name, program: program})
+	}
+	for _, variable := range vap.Spec.Variables {
+		program, err := e.compile(variable.Expression)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("variable %s: %w", variable.Name, err))
+			continue
+		}
+		compiled.variables = append(compiled.variables, namedProgram{name: variable.Name, program: program})
+	}
+	for idx, validation := range vap.Spec.Validations {
+		program, err := e.compile(validation.Expression)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("validation %d: %w", idx, err))
+			continue
+		}
+		cv := compiledValidation{validation: validation, program: program}
+		if validation.MessageExpression != "" {
+			cv.messageExpression, err = e.compile(validation.MessageExpression)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("message expression of validation %d: %w", idx, err))
+				continue
+			}
+		}
+		compiled.validations = append(compiled.validations, cv)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return compiled, nil
+}
+
+func (e *Evaluator) compile(expression string) (cel.Program, error) {
+	ast, issues := e.env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	return e.env.Program(ast)
+}
+
+// Matches reports whether the object is selected by the match constraints and match conditions of the policy,
+// as if it was created. namespace is the Namespace of a namespaced object and may be nil.
+func (c *CompiledPolicy) Matches(obj, namespace, params *unstructured.Unstructured) (bool, error) {
+	constraints := c.policy.Spec.MatchConstraints
+	if constraints != nil {
+		if !matchesAnyRule(obj, constraints.ResourceRules) || matchesAnyRule(obj, constraints.ExcludeResourceRules) {
+			return false, nil
+		}
+		matched, err := matchesSelector(constraints.ObjectSelector, obj.GetLabels())
+		if err != nil || !matched {
+			return false, err
+		}
+		// the namespace selector applies to namespaced objects and to Namespaces themselves
+		isNamespace := obj.GetKind() == "Namespace" && obj.GroupVersionKind().Group == ""
+		if obj.GetNamespace() != "" || isNamespace {
+			var namespaceLabels map[string]string
+			if isNamespace {
+				namespaceLabels = withNameLabel(obj.GetLabels(), obj.GetName())
+			} else if namespace != nil {
+				namespaceLabels = withNameLabel(namespace.GetLabels(), namespace.GetName())
+			} else {
+				namespaceLabels = withNameLabel(nil, obj.GetNamespace())
+			}
+			matched, err = matchesSelector(constraints.NamespaceSelector, namespaceLabels)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+	}
+
+	activation := newActivation(obj, namespace, params)
+	for _, condition := range c.matchConditions {
+		val, _, err := condition.program.Eval(activation)
+		if err != nil {
+			return false, fmt.Errorf("match condition %s: %w", condition.name, err)
+		}
+		if val != types.True {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Validate evaluates the validations of the policy for the object.
+func (c *CompiledPolicy) Validate(obj, namespace, params *unstructured.Unstructured) Decision {
+	activation := newActivation(obj, namespace, params)
+	variables := activation[variablesVarName].(map[string]interface{})
+	decision := Decision{}
+	for _, variable := range c.variables {
+		val, _, err := variable.program.Eval(activation)
+		if err != nil {
+			return c.failure(fmt.Errorf("variable %s: %w", variable.name, err))
+		}
+		variables[variable.name] = val
+	}
+	for _, validation := range c.validations {
+		val, _, err := validation.program.Eval(activation)
+		if err != nil {
+			if failure := c.failure(fmt.Errorf("expression %q: %w", validation.validation.Expression, err)); failure.Err != nil {
+				return failure
+			}
+			continue
+		}
+		if val == types.True {
+			continue
+		}
+		if val.Type() != types.BoolType {
+			if failure := c.failure(fmt.Errorf("expression %q must return bool, got %s", validation.validation.Expression, val.Type().TypeName())); failure.Err != nil {
+				return failure
+			}
+			continue
+		}
+		decision.Violations = append(decision.Violations, validation.message(activation))
+	}
+	return decision
+}
+
+// failure returns the decision for an evaluation error according to the failure policy, which defaults to Fail.
+func (c *CompiledPolicy) failure(err error) Decision {
+	failurePolicy := c.policy.Spec.FailurePolicy
+	if failurePolicy != nil && *failurePolicy == admissionregistrationv1.Ignore {
+		logger.Debug(fmt.Sprintf("ignoring evaluation error of policy %s: %v", c.policy.Name, err))
+		return Decision{}
+	}
+	return Decision{Err: err}
+}
+
+func (v compiledValidation) message(activation map[string]interface{}) string {
+	if v.messageExpression != nil {
+		val, _, err := v.messageExpression.Eval(activation)
+		if err == nil {
+			if message, ok := val.Value().(string); ok && strings.TrimSpace(message) != "" {
+				return message
+			}
+		}
+	}
+	if v.validation.Message != "" {
+		return v.validation.Message
+	}
+	return fmt.Sprintf("failed expression: %s", v.validation.Expression)
+}
+
+func newActivation(obj, namespace, params *unstructured.Unstructured) map[string]interface{} {
+	activation := map[string]interface{}{
+		objectVarName:          obj.Object,
+		oldObjectVarName:       nil,
+		paramsVarName:          nil,
+		namespaceObjectVarName: nil,
+		variablesVarName:       map[string]interface{}{},
+		requestVarName: map[string]interface{}{
+			"operation": "CREATE",
+			"kind": map[string]interface{}{
+				"group":   obj.GroupVersionKind().Group,
+				"version": obj.GroupVersionKind().Version,
+				"kind":    obj.GetKind(),
+			},
+			"name":      obj.GetName(),
+			"namespace": obj.GetNamespace(),
+		},
+	}
+	if params != nil {
+		activation[paramsVarName] = params.Object
+	}
+	if namespace != nil {
+		activation[namespaceObjectVarName] = namespace.Object
+	}
+	return activation
+}
+
+// matchesAnyRule reports whether the object matches one of the rules for the CREATE operation.
+func matchesAnyRule(obj *unstructured.Unstructured, rules []admissionregistrationv1.NamedRuleWithOperations) bool {
+	gvk := obj.GroupVersionKind()
+	resource, _ := meta.UnsafeGuessKindToResource(gvk)
+	for _, rule := range rules {
+		if !contains(rule.Operations, admissionregistrationv1.Create, admissionregistrationv1.OperationAll) {
+			continue
+		}
+		if !contains(rule.APIGroups, gvk.Group, "*") || !contains(rule.APIVersions, gvk.Version, "*") {
+			continue
+		}
+		if !contains(rule.Resources, resource.Resource, "*") {
+			continue
+		}
+		if rule.Scope != nil && !matchesScope(*rule.Scope, obj.GetNamespace()) {
+			continue
+		}
+		if len(rule.ResourceNames) > 0 && !contains(rule.ResourceNames, obj.GetName()) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func matchesScope(scope admissionregistrationv1.ScopeType, namespace string) bool {
+	switch scope {
+	case admissionregistrationv1.NamespacedScope:
+		return namespace != ""
+	case admissionregistrationv1.ClusterScope:
+		return namespace == ""
+	default:
+		return true
+	}
+}
+
+func matchesSelector(selector *metav1.LabelSelector, objLabels map[string]string) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(labels.Set(objLabels)), nil
+}
+
+// withNameLabel adds the name label the API server sets on every Namespace, so that namespace selectors
+// on it work for Namespaces that are not part of the manifests.
+func withNameLabel(namespaceLabels map[string]string, name string) map[string]string {
+	result := map[string]string{corev1.LabelMetadataName: name}
+	for k, v := range namespaceLabels {
+		result[k] = v
+	}
+	return result
+}
+
+func contains[T ~string](values []T, want ...T) bool {
+	for _, v := range values {
+		for _, w := range want {
+			if v == w {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	// AnnotationCheckID is set on generated resources to map them back to the OSCAL check.
+	AnnotationCheckID = "compliance-to-policy.check-id"
+
+	policyFileName  = "validating-admission-policy.yaml"
+	bindingFileName = "validating-admission-policy-binding.yaml"
+	paramsFileName  = "params.yaml"
+)
+
+// Oscal2Policy generates a ValidatingAdmissionPolicy and a ValidatingAdmissionPolicyBinding for each OSCAL check.
+// The policy of a check is read from the directory named after the check ID in the policies directory and
+// the rule parameter is bound through a params ConfigMap, so that expressions can refer to it as params.data.<parameter-id>.
+type Oscal2Policy struct {
+	policiesDir       string
+	outputDir         string
+	paramsNamespace   string
+	validationActions []admissionregistrationv1.ValidationAction
+	logger            hclog.Logger
+}
+
+func NewOscal2Policy(policiesDir, outputDir, paramsNamespace string, validationActions []admissionregistrationv1.ValidationAction) *Oscal2Policy {
+	return &Oscal2Policy{
+		policiesDir:       policiesDir,
+		outputDir:         outputDir,
+		paramsNamespace:   paramsNamespace,
+		validationActions: validationActions,
+		logger:            logger.Named("composer"),
+	}
+}
+
+func (c *Oscal2Policy) Generate(pl policy.Policy) error {
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			c.logger.Debug(fmt.Sprintf("processing check %s for rule %s", check.ID, ruleSet.Rule.ID))
+			if err := pluginutil.CheckFileName(check.ID); err != nil {
+				return err
+			}
+			vap, err := loadPolicy(filepath.Join(c.policiesDir, check.ID))
+			if err != nil {
+				return fmt.Errorf("check %s: %w", check.ID, err)
+			}
+			vap.Name = pluginutil.ToDNSCompliant(check.ID)
+			vap.Annotations = withCheckID(vap.Annotations, check.ID)
+
+			binding := admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+					Kind:       "ValidatingAdmissionPolicyBinding",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        vap.Name,
+					Annotations: withCheckID(nil, check.ID),
+				},
+				Spec: admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{
+					PolicyName:        vap.Name,
+					ValidationActions: c.validationActions,
+				},
+			}
+
+			params := paramsConfigMap(check.ID, c.paramsNamespace, ruleSet.Rule.Parameter)
+			if params != nil {
+				vap.Spec.ParamKind = &admissionregistrationv1.ParamKind{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+				}
+				denyAction := admissionregistrationv1.DenyAction
+				binding.Spec.ParamRef = &admissionregistrationv1.ParamRef{
+					Name:                    params.Name,
+					Namespace:               params.Namespace,
+					ParameterNotFoundAction: &denyAction,
+				}
+			}
+
+			destDir := filepath.Join(c.outputDir, check.ID)
+			if err := os.MkdirAll(destDir, 0o755); err != nil {
+				return err
+			}
+			if err := pkg.WriteObjToYamlFile(filepath.Join(destDir, policyFileName), vap); err != nil {
+				return err
+			}
+			if err := pkg.WriteObjToYamlFile(filepath.Join(destDir, bindingFileName), binding); err != nil {
+				return err
+			}
+			if params != nil {
+				if err := pkg.WriteObjToYamlFile(filepath.Join(destDir, paramsFileName), params); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// paramsConfigMap returns the ConfigMap holding the parameter value of a check, or nil if the rule has no parameter.
+func paramsConfigMap(checkID, namespace string, parameter *extensions.Parameter) *corev1.ConfigMap {
+	if parameter == nil || parameter.Value == "" {
+		return nil
+	}
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pluginutil.ToDNSCompliant(checkID) + "-params",
+			Namespace:   namespace,
+			Annotations: withCheckID(nil, checkID),
+		},
+		Data: map[string]string{
+			parameter.ID: parameter.Value,
+		},
+	}
+}
+
+// loadPolicy returns the first ValidatingAdmissionPolicy found in the YAML files of dir.
+func loadPolicy(dir string) (*admissionregistrationv1.ValidatingAdmissionPolicy, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isYaml(entry.Name()) {
+			continue
+		}
+		objs, err := pkg.LoadYaml(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			logger.Warn(fmt.Sprintf("%s is not k8s object: %v", entry.Name(), err))
+			continue
+		}
+		for _, obj := range objs {
+			if obj.GetKind() != "ValidatingAdmissionPolicy" || obj.GroupVersionKind().Group != admissionregistrationv1.GroupName {
+				continue
+			}
+			vap := admissionregistrationv1.ValidatingAdmissionPolicy{}
+			if err := pkg.ToK8sTypedObject(obj, &vap); err != nil {
+				return nil, fmt.Errorf("failed to convert %s: %w", entry.Name(), err)
+			}
+			return &vap, nil
+		}
+	}
+	return nil, fmt.Errorf("no ValidatingAdmissionPolicy found in %s", dir)
+}
+
+func withCheckID(annotations map[string]string, checkID string) map[string]string {
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationCheckID] = checkID
+	return annotations
+}
+
+func isYaml(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
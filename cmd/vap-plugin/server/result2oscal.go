@@ -0,0 +1,212 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg/pluginutil"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// manifest is an object loaded from the manifests directory.
+type manifest struct {
+	obj  *unstructured.Unstructured
+	path string
+}
+
+// ResultToOscal evaluates the ValidatingAdmissionPolicy of each check offline against a directory of manifests.
+type ResultToOscal struct {
+	policy            policy.Policy
+	policiesDir       string
+	manifestsDir      string
+	paramsNamespace   string
+	validationActions []admissionregistrationv1.ValidationAction
+}
+
+func NewResultToOscal(pl policy.Policy, policiesDir, manifestsDir, paramsNamespace string, validationActions []admissionregistrationv1.ValidationAction) *ResultToOscal {
+	return &ResultToOscal{
+		policy:            pl,
+		policiesDir:       policiesDir,
+		manifestsDir:      manifestsDir,
+		paramsNamespace:   paramsNamespace,
+		validationActions: validationActions,
+	}
+}
+
+func (r *ResultToOscal) GenerateResults() (policy.PVPResult, error) {
+	manifests, err := loadManifests(r.manifestsDir)
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	namespaces := map[string]*unstructured.Unstructured{}
+	for _, m := range manifests {
+		if m.obj.GetKind() == "Namespace" && m.obj.GroupVersionKind().Group == "" {
+			namespaces[m.obj.GetName()] = m.obj
+		}
+	}
+
+	evaluator, err := NewEvaluator()
+	if err != nil {
+		return policy.PVPResult{}, err
+	}
+	evaluatedOn := time.Now()
+
+	var observations []policy.ObservationByCheck
+	for _, rule := range r.policy {
+		for _, check := range rule.Checks {
+			if err := pluginutil.CheckFileName(check.ID); err != nil {
+				return policy.PVPResult{}, err
+			}
+			vap, err := loadPolicy(filepath.Join(r.policiesDir, check.ID))
+			if err != nil {
+				return policy.PVPResult{}, fmt.Errorf("check %s: %w", check.ID, err)
+			}
+			compiled, err := evaluator.Compile(vap)
+			if err != nil {
+				return policy.PVPResult{}, fmt.Errorf("check %s: %w", check.ID, err)
+			}
+			params, err := r.params(check.ID, rule.Rule.Parameter)
+			if err != nil {
+				return policy.PVPResult{}, fmt.Errorf("check %s: %w", check.ID, err)
+			}
+
+			observation := policy.ObservationByCheck{
+				Title:       rule.Rule.ID,
+				CheckID:     check.ID,
+				Description: fmt.Sprintf("Observation of check %s", check.ID),
+				Methods:     []string{"TEST-AUTOMATED"},
+				Props: []policy.Property{
+					pluginutil.MakeProp("assessment-rule-id", rule.Rule.ID),
+					pluginutil.MakeProp("evaluation-mode", "offline"),
+				},
+				Collected: evaluatedOn,
+				Subjects:  []policy.Subject{},
+			}
+			for _, m := range manifests {
+				namespace := namespaces[m.obj.GetNamespace()]
+				matched, err := compiled.Matches(m.obj, namespace, params)
+				if err != nil {
+					observation.Subjects = append(observation.Subjects, r.subject(m, evaluatedOn, Decision{Err: err}))
+					continue
+				}
+				if !matched {
+					continue
+				}
+				decision := compiled.Validate(m.obj, namespace, params)
+				observation.Subjects = append(observation.Subjects, r.subject(m, evaluatedOn, decision))
+			}
+			observations = append(observations, observation)
+		}
+	}
+	return policy.PVPResult{
+		ObservationsByCheck: observations,
+	}, nil
+}
+
+// params returns the params ConfigMap of the check as it is generated by Oscal2Policy.
+func (r *ResultToOscal) params(checkID string, parameter *extensions.Parameter) (*unstructured.Unstructured, error) {
+	configMap := paramsConfigMap(checkID, r.paramsNamespace, parameter)
+	if configMap == nil {
+		return nil, nil
+	}
+	obj, err := pkg.ToK8sUnstructedObject(configMap)
+	if err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+func (r *ResultToOscal) subject(m manifest, evaluatedOn time.Time, decision Decision) policy.Subject {
+	obj := m.obj
+	gvknsn := fmt.Sprintf("ApiVersion: %s, Kind: %s, Namespace: %s, Name: %s", obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	subject := policy.Subject{
+		Title:       gvknsn,
+		ResourceID:  resourceID(obj),
+		Type:        "resource",
+		EvaluatedOn: evaluatedOn,
+		Props: []policy.Property{
+			pluginutil.MakeProp("manifest", m.path),
+		},
+	}
+	switch {
+	case decision.Err != nil:
+		subject.Result = policy.ResultError
+		subject.Reason = decision.Err.Error()
+	case len(decision.Violations) > 0:
+		subject.Result = r.violationResult()
+		subject.Reason = strings.Join(decision.Violations, "; ")
+	default:
+		subject.Result = policy.ResultPass
+		subject.Reason = "all validations passed"
+	}
+	return subject
+}
+
+// violationResult maps the validation actions of the bindings to the result of a failed validation.
+// Violations of bindings that only warn do not block admission and are reported as warnings.
+func (r *ResultToOscal) violationResult() policy.Result {
+	if !hasAction(r.validationActions, admissionregistrationv1.Deny) && hasAction(r.validationActions, admissionregistrationv1.Warn) {
+		return policy.ResultWarning
+	}
+	return policy.ResultFail
+}
+
+func resourceID(obj *unstructured.Unstructured) string {
+	tokens := []string{obj.GetAPIVersion(), obj.GetKind()}
+	if obj.GetNamespace() != "" {
+		tokens = append(tokens, obj.GetNamespace())
+	}
+	tokens = append(tokens, obj.GetName())
+	return strings.Join(tokens, "/")
+}
+
+// loadManifests loads the Kubernetes objects from the YAML and JSON files in dir and its subdirectories.
+// Lists are expanded into their items.
+func loadManifests(dir string) ([]manifest, error) {
+	var manifests []manifest
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !(isYaml(d.Name()) || strings.HasSuffix(d.Name(), ".json")) {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		objs, err := pkg.LoadYaml(path)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("skipping %s: %v", relPath, err))
+			return nil
+		}
+		for _, obj := range objs {
+			if !obj.IsList() {
+				manifests = append(manifests, manifest{obj: obj, path: relPath})
+				continue
+			}
+			if err := obj.EachListItem(func(item runtime.Object) error {
+				manifests = append(manifests, manifest{obj: item.(*unstructured.Unstructured), path: relPath})
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return manifests, err
+}
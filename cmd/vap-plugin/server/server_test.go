@@ -0,0 +1,249 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/oscal-compass/oscal-sdk-go/settings"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin/plugintest"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestOscal2Policy(t *testing.T) {
+	policyDir := pkg.PathFromPkgDirectory("./testdata/vap/policy-resources")
+	tempDir := t.TempDir()
+
+	policyExample := createPolicy(t)
+	o2p := NewOscal2Policy(policyDir, tempDir, "c2p", []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny})
+	require.NoError(t, o2p.Generate(policyExample))
+
+	vap := admissionregistrationv1.ValidatingAdmissionPolicy{}
+	require.NoError(t, pkg.LoadYamlFileToK8sTypedObject(filepath.Join(tempDir, "replica-limits", policyFileName), &vap))
+	require.Equal(t, "replica-limits", vap.Name)
+	require.Equal(t, "replica-limits", vap.Annotations[AnnotationCheckID])
+	require.Equal(t, &admissionregistrationv1.ParamKind{APIVersion: "v1", Kind: "ConfigMap"}, vap.Spec.ParamKind)
+
+	binding := admissionregistrationv1.ValidatingAdmissionPolicyBinding{}
+	require.NoError(t, pkg.LoadYamlFileToK8sTypedObject(filepath.Join(tempDir, "replica-limits", bindingFileName), &binding))
+	require.Equal(t, "replica-limits", binding.Spec.PolicyName)
+	require.Equal(t, []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}, binding.Spec.ValidationActions)
+	require.NotNil(t, binding.Spec.ParamRef)
+	require.Equal(t, "replica-limits-params", binding.Spec.ParamRef.Name)
+	require.Equal(t, "c2p", binding.Spec.ParamRef.Namespace)
+
+	params := corev1.ConfigMap{}
+	require.NoError(t, pkg.LoadYamlFileToK8sTypedObject(filepath.Join(tempDir, "replica-limits", paramsFileName), &params))
+	require.Equal(t, map[string]string{"max_replicas": "5"}, params.Data)
+
+	// a check without parameter is not bound to a params ConfigMap
+	noParamsVap := admissionregistrationv1.ValidatingAdmissionPolicy{}
+	require.NoError(t, pkg.LoadYamlFileToK8sTypedObject(filepath.Join(tempDir, "disallow-latest-tag", policyFileName), &noParamsVap))
+	require.Nil(t, noParamsVap.Spec.ParamKind)
+	require.NoFileExists(t, filepath.Join(tempDir, "disallow-latest-tag", paramsFileName))
+
+	traversal := policy.Policy{
+		{
+			RuleSet: extensions.RuleSet{
+				Rule:   extensions.Rule{ID: "traversal"},
+				Checks: []extensions.Check{{ID: "../replica-limits"}},
+			},
+		},
+	}
+	require.EqualError(t, o2p.Generate(traversal), "invalid check id \"../replica-limits\": must be a file name")
+}
+
+func TestResult2Oscal(t *testing.T) {
+	policyDir := pkg.PathFromPkgDirectory("./testdata/vap/policy-resources")
+	manifestsDir := pkg.PathFromPkgDirectory("./testdata/vap/manifests")
+
+	testPolicy := createPolicy(t)
+	reporter := NewResultToOscal(testPolicy, policyDir, manifestsDir, "c2p", []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny})
+	results, err := reporter.GenerateResults()
+	require.NoError(t, err)
+	require.Len(t, results.ObservationsByCheck, 3)
+
+	type subjectResult struct {
+		resourceID string
+		result     policy.Result
+		reason     string
+	}
+	wantResults := map[string][]subjectResult{
+		"required-labels": {
+			{resourceID: "v1/Namespace/default", result: policy.ResultFail, reason: "missing required labels: owner, team"},
+			{resourceID: "v1/Namespace/payments", result: policy.ResultPass, reason: "all validations passed"},
+		},
+		"replica-limits": {
+			{resourceID: "apps/v1/Deployment/payments/api", result: policy.ResultPass, reason: "all validations passed"},
+			{resourceID: "apps/v1/Deployment/default/web", result: policy.ResultFail, reason: "the number of replicas exceeds the allowed maximum"},
+			{resourceID: "apps/v1/Deployment/kube-system/coredns", result: policy.ResultPass, reason: "all validations passed"},
+		},
+		"disallow-latest-tag": {
+			{resourceID: "apps/v1/Deployment/payments/api", result: policy.ResultPass, reason: "all validations passed"},
+			{
+				resourceID: "apps/v1/Deployment/default/web",
+				result:     policy.ResultFail,
+				reason:     "failed expression: object.spec.template.spec.containers.all(c, !c.image.endsWith(':latest'))",
+			},
+		},
+	}
+	for _, observation := range results.ObservationsByCheck {
+		t.Run(observation.CheckID, func(t *testing.T) {
+			want, ok := wantResults[observation.CheckID]
+			require.True(t, ok)
+			var got []subjectResult
+			for _, subject := range observation.Subjects {
+				got = append(got, subjectResult{resourceID: subject.ResourceID, result: subject.Result, reason: subject.Reason})
+			}
+			require.Equal(t, want, got)
+		})
+	}
+
+	subject := results.ObservationsByCheck[0].Subjects[0]
+	require.Equal(t, "ApiVersion: v1, Kind: Namespace, Namespace: , Name: default", subject.Title)
+	require.Equal(t, []policy.Property{{Name: "manifest", Value: "namespaces.yaml"}}, subject.Props)
+}
+
+func TestResult2OscalWarn(t *testing.T) {
+	reporter := NewResultToOscal(nil, "", "", "c2p", []admissionregistrationv1.ValidationAction{admissionregistrationv1.Warn, admissionregistrationv1.Audit})
+	require.Equal(t, policy.ResultWarning, reporter.violationResult())
+	reporter = NewResultToOscal(nil, "", "", "c2p", []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny, admissionregistrationv1.Audit})
+	require.Equal(t, policy.ResultFail, reporter.violationResult())
+}
+
+func TestEvaluator(t *testing.T) {
+	evaluator, err := NewEvaluator()
+	require.NoError(t, err)
+
+	ignore := admissionregistrationv1.Ignore
+	tests := []struct {
+		name           string
+		spec           admissionregistrationv1.ValidatingAdmissionPolicySpec
+		wantViolations []string
+		wantErr        bool
+	}{
+		{
+			name: "Pass",
+			spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				Validations: []admissionregistrationv1.Validation{{Expression: "object.metadata.name == 'test'"}},
+			},
+		},
+		{
+			name: "MessageExpression",
+			spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				Validations: []admissionregistrationv1.Validation{
+					{Expression: "object.metadata.name == 'other'", MessageExpression: "'unexpected name ' + object.metadata.name"},
+				},
+			},
+			wantViolations: []string{"unexpected name test"},
+		},
+		{
+			name: "KubernetesLibrary",
+			spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				Validations: []admissionregistrationv1.Validation{
+					{Expression: "quantity(object.spec.memory).isLessThan(quantity('1Gi'))", Message: "too much memory"},
+				},
+			},
+			wantViolations: []string{"too much memory"},
+		},
+		{
+			name: "EvaluationError",
+			spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				Validations: []admissionregistrationv1.Validation{{Expression: "object.spec.missing == 'x'"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "EvaluationErrorIgnored",
+			spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+				FailurePolicy: &ignore,
+				Validations:   []admissionregistrationv1.Validation{{Expression: "object.spec.missing == 'x'"}},
+			},
+		},
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "default"},
+		"spec":       map[string]interface{}{"memory": "2Gi"},
+	}}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			compiled, err := evaluator.Compile(&admissionregistrationv1.ValidatingAdmissionPolicy{Spec: c.spec})
+			require.NoError(t, err)
+			decision := compiled.Validate(obj, nil, nil)
+			if c.wantErr {
+				require.Error(t, decision.Err)
+				return
+			}
+			require.NoError(t, decision.Err)
+			require.Equal(t, c.wantViolations, decision.Violations)
+		})
+	}
+
+	_, err = evaluator.Compile(&admissionregistrationv1.ValidatingAdmissionPolicy{
+		Spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+			Validations: []admissionregistrationv1.Validation{{Expression: "object.metadata.name =="}},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestConfigure(t *testing.T) {
+	plugin := NewPlugin()
+	err := plugin.Configure(map[string]string{})
+	require.EqualError(t, err, "policy directory must be set\noutput directory must be set")
+
+	configuration := map[string]string{
+		"policy-dir": "not-exist",
+		"output-dir": t.TempDir(),
+	}
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "path \"not-exist\": stat not-exist: no such file or directory")
+
+	policyDir := pkg.PathFromPkgDirectory("./testdata/vap/policy-resources")
+	configuration["policy-dir"] = policyDir
+	err = plugin.Configure(configuration)
+	require.NoError(t, err)
+	require.Equal(t, []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}, plugin.config.Actions())
+	require.Equal(t, "c2p", plugin.config.Namespace())
+
+	configuration["validation-actions"] = "Deny,Warn"
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "validation actions Deny and Warn cannot be used together")
+	require.Empty(t, plugin.config.ValidationActions)
+
+	configuration["validation-actions"] = "Block"
+	err = plugin.Configure(configuration)
+	require.EqualError(t, err, "invalid validation action \"Block\": must be one of Deny, Warn, Audit")
+
+	// options of an earlier configuration are not kept
+	configuration["validation-actions"] = "Audit"
+	configuration["params-namespace"] = "params"
+	require.NoError(t, plugin.Configure(configuration))
+	delete(configuration, "validation-actions")
+	delete(configuration, "params-namespace")
+	require.NoError(t, plugin.Configure(configuration))
+	require.Equal(t, []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}, plugin.config.Actions())
+	require.Equal(t, "c2p", plugin.config.Namespace())
+}
+
+func createPolicy(t *testing.T) policy.Policy {
+	// apply the parameter values as the framework does before calling the plugin
+	testSettings := settings.NewSettings(
+		map[string]struct{}{"required-labels": {}, "replica-limits": {}, "disallow-latest-tag": {}},
+		map[string]string{"labels": "owner,team", "max_replicas": "5"},
+	)
+	return plugintest.LoadPolicy(t, pkg.PathFromPkgDirectory("./testdata/vap/component-definition.json"), "VAP", testSettings)
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
@@ -30,48 +31,77 @@ import (
 
 var logger logr.Logger = ctrl.Log.WithName("ocmk8sclients")
 
+// OcmK8ResourceInterfaceSetType holds the dynamic clients for the OCM resources. PolicySet and
+// PlacementDecision are nil when the cluster does not serve them.
 type OcmK8ResourceInterfaceSetType struct {
-	Policy           dynamic.NamespaceableResourceInterface
-	PlacementRule    dynamic.NamespaceableResourceInterface
-	PlacementBinding dynamic.NamespaceableResourceInterface
+	Policy            dynamic.NamespaceableResourceInterface
+	PolicySet         dynamic.NamespaceableResourceInterface
+	PlacementRule     dynamic.NamespaceableResourceInterface
+	PlacementBinding  dynamic.NamespaceableResourceInterface
+	PlacementDecision dynamic.NamespaceableResourceInterface
 }
 
-func NewOcmK8sClientSet(discoveryClient *discovery.DiscoveryClient, dyClient dynamic.Interface) (OcmK8ResourceInterfaceSetType, error) {
+// optionalKinds are the kinds whose client is not set when the cluster does not serve them.
+var optionalKinds = map[string]bool{
+	"PolicySet":         true,
+	"PlacementDecision": true,
+}
+
+func NewOcmK8sClientSet(discoveryClient discovery.DiscoveryInterface, dyClient dynamic.Interface) (OcmK8ResourceInterfaceSetType, error) {
 	var ocmK8sClientSet OcmK8ResourceInterfaceSetType
 	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
 	if err != nil {
 		logger.Error(err, "failed to get APIGroupResource")
 		return ocmK8sClientSet, err
 	}
-	ocmResourceGKs := []schema.GroupKind{{
-		Group: "policy.open-cluster-management.io",
-		Kind:  "Policy",
+	ocmResourceGVKs := []schema.GroupVersionKind{{
+		Group:   "policy.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "Policy",
+	}, {
+		Group:   "policy.open-cluster-management.io",
+		Version: "v1beta1",
+		Kind:    "PolicySet",
+	}, {
+		Group:   "policy.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "PlacementBinding",
 	}, {
-		Group: "policy.open-cluster-management.io",
-		Kind:  "PlacementBinding",
+		Group:   "apps.open-cluster-management.io",
+		Version: "v1",
+		Kind:    "PlacementRule",
 	}, {
-		Group: "apps.open-cluster-management.io",
-		Kind:  "PlacementRule",
+		Group:   "cluster.open-cluster-management.io",
+		Version: "v1beta1",
+		Kind:    "PlacementDecision",
 	}}
 	restMapper := restmapper.NewDiscoveryRESTMapper(groupResources)
-	for _, gk := range ocmResourceGKs {
-		mapping, err := restMapper.RESTMapping(gk, "v1")
+	for _, gvk := range ocmResourceGVKs {
+		mapping, err := restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 		if err != nil {
-			logger.Error(err, fmt.Sprintf("failed to get restMapping %s", gk.String()))
+			if optionalKinds[gvk.Kind] && meta.IsNoMatchError(err) {
+				logger.Info(fmt.Sprintf("%s is not served, skipping its client", gvk.GroupKind().String()))
+				continue
+			}
+			logger.Error(err, fmt.Sprintf("failed to get restMapping %s", gvk.GroupKind().String()))
 			return ocmK8sClientSet, err
 		}
 		if mapping == nil {
-			logger.Error(err, fmt.Sprintf("restMapping is null %s", gk.String()))
+			logger.Error(err, fmt.Sprintf("restMapping is null %s", gvk.GroupKind().String()))
 			return ocmK8sClientSet, err
 		}
 		k8sNamespacedClient := dyClient.Resource(mapping.Resource)
-		switch gk.Kind {
+		switch gvk.Kind {
 		case "Policy":
 			ocmK8sClientSet.Policy = k8sNamespacedClient
+		case "PolicySet":
+			ocmK8sClientSet.PolicySet = k8sNamespacedClient
 		case "PlacementRule":
 			ocmK8sClientSet.PlacementRule = k8sNamespacedClient
 		case "PlacementBinding":
 			ocmK8sClientSet.PlacementBinding = k8sNamespacedClient
+		case "PlacementDecision":
+			ocmK8sClientSet.PlacementDecision = k8sNamespacedClient
 		}
 	}
 	if ocmK8sClientSet.Policy == nil {
@@ -0,0 +1,54 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package ocmk8sclients
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	typesplacementdecision "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/placementdecision"
+)
+
+type placementDecisionClient struct {
+	client dynamic.NamespaceableResourceInterface
+}
+
+func NewPlacementDecisionClient(client dynamic.NamespaceableResourceInterface) placementDecisionClient {
+	return placementDecisionClient{
+		client: client,
+	}
+}
+
+func (c *placementDecisionClient) List(namespace string) ([]*typesplacementdecision.PlacementDecision, error) {
+	unstList, err := c.client.Namespace(namespace).List(context.TODO(), v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	typedList := []*typesplacementdecision.PlacementDecision{}
+	for _, unstPlacementDecision := range unstList.Items {
+		typedObj := typesplacementdecision.PlacementDecision{}
+		if err := pkg.ToK8sTypedObject(&unstPlacementDecision, &typedObj); err != nil {
+			return nil, err
+		}
+		typedList = append(typedList, &typedObj)
+	}
+	return typedList, nil
+}
+
+func (c *placementDecisionClient) Get(namespace string, name string) (*typesplacementdecision.PlacementDecision, error) {
+	unstObj, err := c.client.Namespace(namespace).Get(context.TODO(), name, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	typedObj := typesplacementdecision.PlacementDecision{}
+	if err := pkg.ToK8sTypedObject(unstObj, &typedObj); err != nil {
+		return nil, err
+	}
+	return &typedObj, nil
+}
@@ -0,0 +1,54 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package ocmk8sclients
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	typespolicy "github.com/oscal-compass/compliance-to-policy-go/v2/pkg/types/policy"
+)
+
+type policySetClient struct {
+	client dynamic.NamespaceableResourceInterface
+}
+
+func NewPolicySetClient(client dynamic.NamespaceableResourceInterface) policySetClient {
+	return policySetClient{
+		client: client,
+	}
+}
+
+func (c *policySetClient) List(namespace string) ([]*typespolicy.PolicySet, error) {
+	unstList, err := c.client.Namespace(namespace).List(context.TODO(), v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	typedList := []*typespolicy.PolicySet{}
+	for _, unstPolicySet := range unstList.Items {
+		typedObj := typespolicy.PolicySet{}
+		if err := pkg.ToK8sTypedObject(&unstPolicySet, &typedObj); err != nil {
+			return nil, err
+		}
+		typedList = append(typedList, &typedObj)
+	}
+	return typedList, nil
+}
+
+func (c *policySetClient) Get(namespace string, name string) (*typespolicy.PolicySet, error) {
+	unstObj, err := c.client.Namespace(namespace).Get(context.TODO(), name, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	typedObj := typespolicy.PolicySet{}
+	if err := pkg.ToK8sTypedObject(unstObj, &typedObj); err != nil {
+		return nil, err
+	}
+	return &typedObj, nil
+}
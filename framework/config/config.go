@@ -14,6 +14,8 @@ import (
 
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-2"
 	"github.com/hashicorp/go-hclog"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 )
 
 const (
@@ -21,6 +23,11 @@ const (
 	pluginComponentType = "validation"
 	// DefaultPluginPath default location c2p will look for plugins
 	DefaultPluginPath = "c2p-plugins"
+	// PluginPathEnv is the environment variable with a list of plugin
+	// directories that take precedence in the default search path.
+	PluginPathEnv = "C2P_PLUGIN_PATH"
+	// SystemPluginPath is the system-wide plugin directory.
+	SystemPluginPath = "/usr/local/share/c2p/plugins"
 )
 
 // C2PConfig represents configuration options for the C2P framework.PluginManager.
@@ -28,10 +35,61 @@ type C2PConfig struct {
 	// PluginDir is the directory where the PluginManager searches
 	// for installed plugins.
 	PluginDir string
+	// PluginSearchPath is an ordered list of directories where the PluginManager
+	// searches for installed plugins. When set, it is used instead of PluginDir.
+	// See DefaultPluginSearchPath for the precedence rules.
+	PluginSearchPath []string
 	// Logger is the logging implementation used in the PluginManager and
 	// plugin clients.
 	Logger               hclog.Logger
 	ComponentDefinitions []oscalTypes.ComponentDefinition
+	// SignaturePolicy defines how the manifest signatures of
+	// plugins are enforced. It defaults to plugin.SignaturePolicyOff.
+	SignaturePolicy plugin.SignaturePolicy
+	// TrustedKeys are the public keys plugin manifest
+	// signatures are verified against.
+	TrustedKeys []plugin.TrustedKey
+	// Sandbox restricts the environment, working directory and resources
+	// of the launched plugins. Plugins are not sandboxed when it is nil.
+	Sandbox *plugin.Sandbox
+	// RemotePlugins are the endpoints of plugins that are not launched by the host, by plugin ID.
+	// They take precedence over the installed plugins with the same ID and are not subject to
+	// the signature policy.
+	RemotePlugins map[string]plugin.Remote
+	// PluginLogs captures the log stream of each launched plugin in memory and, optionally,
+	// in rotating files. The plugin logs are still sent to the Logger.
+	PluginLogs *plugin.LogCapture
+	// EvidenceDir is the directory where the evidence returned by collector
+	// plugins is stored before it is passed to the policy plugins for evaluation.
+	EvidenceDir string
+	// ResultProcessors is the pipeline of processors that transform the results of the
+	// PVPs before they are reported, in order.
+	ResultProcessors []ResultProcessor
+}
+
+// ResultProcessor is a step of the result processor pipeline. It is either a built-in
+// processor or a processor plugin.
+type ResultProcessor struct {
+	// Name is the name of a built-in processor.
+	Name string
+	// Plugin is the ID of a processor plugin.
+	Plugin string
+	// Options are the configuration options of the processor.
+	Options map[string]string
+}
+
+// Validate returns an error if the ResultProcessor does not set
+// exactly one of Name and Plugin.
+func (r ResultProcessor) Validate() error {
+	switch {
+	case r.Name == "" && r.Plugin == "":
+		return errors.New("result processor must set a name or a plugin")
+	case r.Name != "" && r.Plugin != "":
+		return fmt.Errorf("result processor %s cannot set both a name and the plugin %s", r.Name, r.Plugin)
+	case r.Plugin != "" && !(plugin.Metadata{ID: r.Plugin}).ValidateID():
+		return fmt.Errorf("invalid result processor plugin id %q", r.Plugin)
+	}
+	return nil
 }
 
 var defaultLogger = hclog.New(&hclog.LoggerOptions{
@@ -49,29 +107,127 @@ func DefaultConfig() *C2PConfig {
 	}
 }
 
+// DefaultPluginSearchPath returns the plugin directories in order of precedence:
+//
+//  1. The directories listed in the C2P_PLUGIN_PATH environment variable.
+//  2. The project-local DefaultPluginPath.
+//  3. The user plugin directory, c2p/plugins in the XDG data directory ($XDG_DATA_HOME, or ~/.local/share).
+//  4. The SystemPluginPath.
+//
+// The project-local directory comes before the user and system directories, so a project can pin
+// the plugin versions it is tested with.
+func DefaultPluginSearchPath() []string {
+	var searchPath []string
+	for _, dir := range filepath.SplitList(os.Getenv(PluginPathEnv)) {
+		if dir != "" {
+			searchPath = append(searchPath, dir)
+		}
+	}
+	searchPath = append(searchPath, DefaultPluginPath)
+
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+	}
+	if dataHome != "" {
+		searchPath = append(searchPath, filepath.Join(dataHome, "c2p", "plugins"))
+	}
+	return append(searchPath, SystemPluginPath)
+}
+
+// SearchPath returns the directories the PluginManager searches for plugins.
+func (c *C2PConfig) SearchPath() []string {
+	if len(c.PluginSearchPath) > 0 {
+		return c.PluginSearchPath
+	}
+	return []string{c.PluginDir}
+}
+
 // Validate returns an error if C2PConfig has invalid fields.
 func (c *C2PConfig) Validate() error {
-	// Sanitize the plugin directory input
-	c.PluginDir = strings.TrimSpace(c.PluginDir)
-	c.PluginDir = filepath.Clean(c.PluginDir)
-	if strings.TrimSpace(c.PluginDir) == "" {
-		return fmt.Errorf("plugin directory cannot be empty")
-	}
-	if _, err := os.Stat(c.PluginDir); err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return fmt.Errorf("plugin directory %s does not exist: %w", c.PluginDir, err)
+	if len(c.PluginSearchPath) > 0 {
+		if err := c.validateSearchPath(); err != nil {
+			return err
+		}
+	} else {
+		// Sanitize the plugin directory input
+		c.PluginDir = strings.TrimSpace(c.PluginDir)
+		c.PluginDir = filepath.Clean(c.PluginDir)
+		if strings.TrimSpace(c.PluginDir) == "" {
+			return fmt.Errorf("plugin directory cannot be empty")
+		}
+		if _, err := os.Stat(c.PluginDir); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("plugin directory %s does not exist: %w", c.PluginDir, err)
+			}
+			return err
 		}
-		return err
 	}
 	if len(c.ComponentDefinitions) == 0 {
 		return fmt.Errorf("component definitions not set")
 	}
+	if err := c.SignaturePolicy.Validate(); err != nil {
+		return err
+	}
+	if c.SignaturePolicy == plugin.SignaturePolicyRequire && len(c.TrustedKeys) == 0 {
+		return fmt.Errorf("signature policy %s requires at least one trusted key", c.SignaturePolicy)
+	}
+	if c.Sandbox != nil {
+		if err := c.Sandbox.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.PluginLogs != nil {
+		if err := c.PluginLogs.Validate(); err != nil {
+			return err
+		}
+	}
+	for id, remote := range c.RemotePlugins {
+		if !(plugin.Metadata{ID: id}).ValidateID() {
+			return fmt.Errorf("invalid remote plugin id %q", id)
+		}
+		if err := remote.Validate(); err != nil {
+			return fmt.Errorf("invalid remote plugin %s: %w", id, err)
+		}
+	}
+	for i, processor := range c.ResultProcessors {
+		if err := processor.Validate(); err != nil {
+			return fmt.Errorf("invalid result processor %d: %w", i, err)
+		}
+	}
 	if c.Logger == nil {
 		c.Logger = defaultLogger
 	}
 	return nil
 }
 
+// validateSearchPath sanitizes the plugin search path. Directories that do not exist
+// are allowed, as long as one of them exists.
+func (c *C2PConfig) validateSearchPath() error {
+	searchPath := make([]string, 0, len(c.PluginSearchPath))
+	found := false
+	for _, dir := range c.PluginSearchPath {
+		dir = strings.TrimSpace(dir)
+		if dir == "" {
+			return fmt.Errorf("plugin search path cannot contain empty directories")
+		}
+		dir = filepath.Clean(dir)
+		if _, err := os.Stat(dir); err == nil {
+			found = true
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		searchPath = append(searchPath, dir)
+	}
+	if !found {
+		return fmt.Errorf("none of the plugin directories in the search path %s exist", strings.Join(searchPath, string(os.PathListSeparator)))
+	}
+	c.PluginSearchPath = searchPath
+	return nil
+}
+
 // PluginConfig is a function signature that returns configuration
 // option key, value pairs for a given plugin id.
 type PluginConfig func(string) map[string]string
@@ -6,10 +6,14 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-2"
 	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 )
 
 func TestC2PConfig_Validate(t *testing.T) {
@@ -23,6 +27,39 @@ func TestC2PConfig_Validate(t *testing.T) {
 	}
 	require.NoError(t, config.Validate())
 	require.NotNil(t, config.Logger)
+
+	config.SignaturePolicy = "strict"
+	require.EqualError(t, config.Validate(), "invalid signature policy \"strict\": must be one of off, warn, require-signed")
+	config.SignaturePolicy = plugin.SignaturePolicyRequire
+	require.EqualError(t, config.Validate(), "signature policy require-signed requires at least one trusted key")
+	config.TrustedKeys = []plugin.TrustedKey{{Name: "test"}}
+	require.NoError(t, config.Validate())
+
+	config.Sandbox = &plugin.Sandbox{Environment: []string{"HOME="}}
+	require.EqualError(t, config.Validate(), "invalid sandbox environment variable name \"HOME=\"")
+	config.Sandbox.Environment = []string{"HOME"}
+	require.NoError(t, config.Validate())
+
+	config.RemotePlugins = map[string]plugin.Remote{"kyverno": {Address: "localhost:9443"}}
+	require.EqualError(t, config.Validate(), "invalid remote plugin kyverno: remote plugin localhost:9443 requires a CA file, a client certificate and a client key")
+	config.RemotePlugins = map[string]plugin.Remote{"Kyverno": {}}
+	require.EqualError(t, config.Validate(), "invalid remote plugin id \"Kyverno\"")
+	config.RemotePlugins = map[string]plugin.Remote{"kyverno": {Address: "localhost:9443", CAFile: "ca.crt", CertFile: "client.crt", KeyFile: "client.key"}}
+	require.NoError(t, config.Validate())
+
+	config.PluginLogs = &plugin.LogCapture{TailLines: -1}
+	require.EqualError(t, config.Validate(), "plugin log capture sizes cannot be negative")
+	config.PluginLogs.TailLines = 10
+	require.NoError(t, config.Validate())
+
+	config.ResultProcessors = []ResultProcessor{{Name: "merge-observations"}, {}}
+	require.EqualError(t, config.Validate(), "invalid result processor 1: result processor must set a name or a plugin")
+	config.ResultProcessors[1] = ResultProcessor{Name: "labels", Plugin: "labels"}
+	require.EqualError(t, config.Validate(), "invalid result processor 1: result processor labels cannot set both a name and the plugin labels")
+	config.ResultProcessors[1] = ResultProcessor{Plugin: "My-Processor"}
+	require.EqualError(t, config.Validate(), "invalid result processor 1: invalid result processor plugin id \"My-Processor\"")
+	config.ResultProcessors[1] = ResultProcessor{Plugin: "my-processor"}
+	require.NoError(t, config.Validate())
 }
 
 func TestDefaultConfig(t *testing.T) {
@@ -30,3 +67,46 @@ func TestDefaultConfig(t *testing.T) {
 	require.Equal(t, defaultConfig.PluginDir, DefaultPluginPath)
 	require.NotNil(t, defaultConfig.Logger)
 }
+
+func TestDefaultPluginSearchPath(t *testing.T) {
+	t.Setenv(PluginPathEnv, "/opt/c2p/plugins"+string(os.PathListSeparator)+"/tmp/plugins")
+	t.Setenv("XDG_DATA_HOME", "/home/user/.data")
+	require.Equal(t, []string{
+		"/opt/c2p/plugins",
+		"/tmp/plugins",
+		DefaultPluginPath,
+		"/home/user/.data/c2p/plugins",
+		SystemPluginPath,
+	}, DefaultPluginSearchPath())
+
+	t.Setenv(PluginPathEnv, "")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("HOME", "/home/user")
+	require.Equal(t, []string{
+		DefaultPluginPath,
+		"/home/user/.local/share/c2p/plugins",
+		SystemPluginPath,
+	}, DefaultPluginSearchPath())
+}
+
+func TestC2PConfig_ValidateSearchPath(t *testing.T) {
+	pluginDir := t.TempDir()
+	missingDir := filepath.Join(pluginDir, "missing")
+	config := DefaultConfig()
+	config.ComponentDefinitions = []oscalTypes.ComponentDefinition{{}}
+
+	config.PluginSearchPath = []string{missingDir}
+	require.EqualError(t, config.Validate(), "none of the plugin directories in the search path "+missingDir+" exist")
+
+	config.PluginSearchPath = []string{" " + missingDir + "/ ", ""}
+	require.EqualError(t, config.Validate(), "plugin search path cannot contain empty directories")
+
+	// the plugin directory is ignored with a search path
+	config.PluginSearchPath = []string{missingDir + "/", pluginDir}
+	require.NoError(t, config.Validate())
+	require.Equal(t, []string{missingDir, pluginDir}, config.SearchPath())
+
+	config.PluginSearchPath = nil
+	config.PluginDir = pluginDir
+	require.Equal(t, []string{pluginDir}, config.SearchPath())
+}
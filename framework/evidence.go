@@ -0,0 +1,133 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// EvidenceIndex is the file in the evidence directory that lists the
+// stored evidence.
+const EvidenceIndex = "evidence.json"
+
+// storedEvidence is an entry of the evidence index.
+type storedEvidence struct {
+	Collector string            `json:"collector"`
+	Name      string            `json:"name"`
+	MediaType string            `json:"mediaType,omitempty"`
+	Digest    string            `json:"digest"`
+	Href      string            `json:"href"`
+	Props     []policy.Property `json:"props,omitempty"`
+}
+
+// evidenceHref returns the reference to the evidence stored
+// by the collector in the evidence directory.
+func evidenceHref(dir, collectorID, name string) string {
+	return filepath.ToSlash(filepath.Join(dir, collectorID, name))
+}
+
+// storeEvidence writes the evidence of each collector to a subdirectory of dir named
+// after the collector ID and the evidence index. The digest of the evidence is verified,
+// or set when the collector did not set it, the Href is set to the stored file and the
+// Collector to the collector ID.
+func storeEvidence(dir string, collected map[string][]policy.Evidence, collectorIDs []string) ([]policy.Evidence, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	var stored []policy.Evidence
+	index := []storedEvidence{}
+	for _, collectorID := range collectorIDs {
+		collectorDir := filepath.Join(dir, collectorID)
+		if err := os.MkdirAll(collectorDir, 0750); err != nil {
+			return nil, err
+		}
+		written := make(map[string]bool)
+		for _, evidence := range collected[collectorID] {
+			name := evidence.Name
+			if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+				return nil, fmt.Errorf("collector plugin %s returned evidence with invalid name %q", collectorID, name)
+			}
+			if written[name] {
+				return nil, fmt.Errorf("collector plugin %s returned the evidence %s more than once", collectorID, name)
+			}
+			written[name] = true
+			if evidence.Digest == "" {
+				evidence.Digest = policy.Digest(evidence.Content)
+			} else if err := evidence.Verify(); err != nil {
+				return nil, fmt.Errorf("collector plugin %s: %w", collectorID, err)
+			}
+			if err := os.WriteFile(filepath.Join(collectorDir, name), evidence.Content, 0600); err != nil {
+				return nil, err
+			}
+			evidence.Href = evidenceHref(dir, collectorID, name)
+			evidence.Collector = collectorID
+			stored = append(stored, evidence)
+			index = append(index, storedEvidence{
+				Collector: collectorID,
+				Name:      name,
+				MediaType: evidence.MediaType,
+				Digest:    evidence.Digest,
+				Href:      evidence.Href,
+				Props:     evidence.Props,
+			})
+		}
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, EvidenceIndex), data, 0600); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// LoadEvidence loads the evidence stored in the evidence directory by CollectEvidence so
+// it can be evaluated again. The digest of each evidence is verified against its content.
+func LoadEvidence(dir string) ([]policy.Evidence, error) {
+	data, err := os.ReadFile(filepath.Join(dir, EvidenceIndex))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no evidence index in %s: %w", dir, err)
+		}
+		return nil, err
+	}
+	var index []storedEvidence
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("invalid evidence index in %s: %w", dir, err)
+	}
+
+	var loaded []policy.Evidence
+	for _, entry := range index {
+		if filepath.Base(entry.Collector) != entry.Collector || filepath.Base(entry.Name) != entry.Name {
+			return nil, fmt.Errorf("invalid evidence %s/%s in the evidence index", entry.Collector, entry.Name)
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Collector, entry.Name))
+		if err != nil {
+			return nil, err
+		}
+		evidence := policy.Evidence{
+			Name:      entry.Name,
+			MediaType: entry.MediaType,
+			Content:   content,
+			Digest:    entry.Digest,
+			Href:      evidenceHref(dir, entry.Collector, entry.Name),
+			Props:     entry.Props,
+			Collector: entry.Collector,
+		}
+		if err := evidence.Verify(); err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, evidence)
+	}
+	return loaded, nil
+}
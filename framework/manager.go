@@ -7,7 +7,13 @@ package framework
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/oscal-compass/oscal-sdk-go/rules"
@@ -21,8 +27,8 @@ import (
 // PluginManager manages the plugin lifecycle and compliance-to-policy
 // workflows.
 type PluginManager struct {
-	// pluginDir is the location to search for plugins.
-	pluginDir string
+	// searchPath is the ordered list of directories to search for plugins.
+	searchPath []string
 	// rulesStore contains indexed information about available RuleSets
 	// which can be searched for the component title.
 	rulesStore rules.Store
@@ -33,9 +39,39 @@ type PluginManager struct {
 	// component in the rules.Store (which provides input for the corresponding policy.Provider
 	// plugin).
 	pluginIdMap map[string]string
+	// ruleIndex contains the compliance context of the rules
+	// that is added to the policy passed to the plugins.
+	ruleIndex *ruleIndex
 	// clientFactory is the function used to
 	// create new plugin clients.
 	clientFactory plugin.ClientFactoryFunc
+	// remoteFactory is the function used to connect
+	// to remote plugins.
+	remoteFactory plugin.RemoteFactoryFunc
+	// remotes stores the remote plugin endpoints
+	// from the C2PConfig by plugin ID.
+	remotes map[string]plugin.Remote
+	// providers stores the in-process policy.Provider implementations
+	// registered with RegisterProvider by plugin ID.
+	providers map[string]registeredProvider
+	// verifier enforces the signature policy on
+	// plugin manifests.
+	verifier *plugin.Verifier
+	// pluginLogs holds the captured log streams of
+	// the launched plugins.
+	pluginLogs *plugin.PluginLogs
+	// sandboxed is whether the launched plugins run
+	// in a plugin.Sandbox.
+	sandboxed bool
+	// evidenceDir is the directory where the evidence
+	// returned by collector plugins is stored.
+	evidenceDir string
+	// evidenceCollectors stores the collectors whose evidence each launched
+	// policy plugin evaluates, from the plugin manifests, by plugin ID.
+	evidenceCollectors map[string][]string
+	// resultProcessors are the steps of the result
+	// processor pipeline.
+	resultProcessors []config.ResultProcessor
 	// logger for the PluginManager
 	log hclog.Logger
 }
@@ -44,8 +80,12 @@ type PluginManager struct {
 // interact with supported plugins.
 //
 // It supports the plugin lifecycle with the following methods:
+//   - Registering in-process providers: RegisterProvider()
 //   - Finding and initializing plugins: FindRequestedPlugins() and LaunchPolicyPlugins()
 //   - Execution - GeneratePolicy() and AggregateResults()
+//   - Remediation - FindRemediationPlugins(), LaunchRemediationPlugins() and ProposeRemediations()
+//   - Evidence collection - FindCollectorPlugins(), LaunchCollectorPlugins(), CollectEvidence() and EvaluateEvidence()
+//   - Result processing - LaunchResultProcessors()
 //   - Clean/Stop - Clean()
 func NewPluginManager(cfg *config.C2PConfig) (*PluginManager, error) {
 	if err := cfg.Validate(); err != nil {
@@ -59,34 +99,180 @@ func NewPluginManager(cfg *config.C2PConfig) (*PluginManager, error) {
 		return nil, err
 	}
 
+	verifier, err := plugin.NewVerifier(cfg.SignaturePolicy, cfg.TrustedKeys, cfg.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOptions := []plugin.ClientOption{plugin.WithClientVerifier(verifier)}
+	if cfg.Sandbox != nil {
+		clientOptions = append(clientOptions, plugin.WithSandbox(cfg.Sandbox))
+	}
+	var pluginLogs *plugin.PluginLogs
+	if cfg.PluginLogs != nil {
+		pluginLogs, err = plugin.NewPluginLogs(*cfg.PluginLogs)
+		if err != nil {
+			return nil, err
+		}
+		clientOptions = append(clientOptions, plugin.WithPluginLogs(pluginLogs))
+		if cfg.PluginLogs.Dir != "" {
+			cfg.Logger.Info(fmt.Sprintf("Capturing plugin logs in %s for run %s", cfg.PluginLogs.Dir, pluginLogs.RunID()))
+		}
+	}
+
 	return &PluginManager{
-		pluginDir:     cfg.PluginDir,
-		rulesStore:    rulesStore,
-		clientFactory: plugin.ClientFactory(cfg.Logger),
-		pluginIdMap:   pluginIDMap,
-		log:           cfg.Logger,
+		searchPath:         cfg.SearchPath(),
+		rulesStore:         rulesStore,
+		clientFactory:      plugin.ClientFactory(cfg.Logger, clientOptions...),
+		remoteFactory:      plugin.RemoteFactory(cfg.Logger, clientOptions...),
+		remotes:            cfg.RemotePlugins,
+		pluginIdMap:        pluginIDMap,
+		ruleIndex:          newRuleIndex(cfg.ComponentDefinitions),
+		providers:          make(map[string]registeredProvider),
+		verifier:           verifier,
+		pluginLogs:         pluginLogs,
+		sandboxed:          cfg.Sandbox != nil,
+		evidenceDir:        cfg.EvidenceDir,
+		evidenceCollectors: make(map[string][]string),
+		resultProcessors:   cfg.ResultProcessors,
+		log:                cfg.Logger,
 	}, nil
 }
 
+// registeredProvider is an in-process policy.Provider and the manifest
+// used to resolve its configuration options.
+type registeredProvider struct {
+	manifest plugin.Manifest
+	provider policy.Provider
+}
+
+// PluginLogs returns the captured log lines of the plugin kept in memory, oldest first.
+// It returns nil when plugin logs are not captured.
+func (m *PluginManager) PluginLogs(pluginID string) []string {
+	return m.pluginLogs.Lines(pluginID)
+}
+
+// RunID returns the ID the captured plugin logs are tagged with.
+// It is empty when plugin logs are not captured.
+func (m *PluginManager) RunID() string {
+	return m.pluginLogs.RunID()
+}
+
+// RegisterProvider registers an in-process policy.Provider implementation under the given
+// plugin ID. The provider is returned by FindRequestedPlugins and LaunchPolicyPlugins in place of a
+// plugin binary with the same ID, so it goes through the same configuration and execution flow
+// without being built and installed in the plugin directory.
+//
+// The given configuration options are resolved against the plugin configuration the same way
+// as the options declared in a plugin manifest. Registered providers are linked into the caller
+// and are not subject to the signature policy of the C2PConfig.
+func (m *PluginManager) RegisterProvider(id string, provider policy.Provider, options ...plugin.ConfigurationOption) error {
+	metadata := plugin.Metadata{
+		ID:          id,
+		Description: fmt.Sprintf("In-process provider %s", id),
+		Types:       []string{plugin.PVPPluginName},
+	}
+	if !metadata.ValidateID() {
+		return fmt.Errorf("invalid plugin id %q", id)
+	}
+	if provider == nil {
+		return fmt.Errorf("provider for plugin %s cannot be nil", id)
+	}
+	if _, ok := m.providers[id]; ok {
+		return fmt.Errorf("provider for plugin %s is already registered", id)
+	}
+	m.providers[id] = registeredProvider{
+		manifest: plugin.Manifest{
+			Metadata:      metadata,
+			Configuration: options,
+		},
+		provider: provider,
+	}
+	return nil
+}
+
+// remoteManifest returns the manifest for a remote plugin from the C2PConfig. The configuration
+// options are declared by the manifest installed with the same ID in the search path, if any.
+func (m *PluginManager) remoteManifest(id string, remote plugin.Remote) (plugin.Manifest, error) {
+	manifest := plugin.Manifest{
+		Metadata: plugin.Metadata{
+			ID:          id,
+			Description: fmt.Sprintf("Remote plugin %s", id),
+			Types:       []string{plugin.PVPPluginName},
+		},
+		Remote: &remote,
+	}
+	installed, err := plugin.FindPluginsInPath(
+		m.searchPath,
+		plugin.WithProviderIds([]string{id}),
+		plugin.WithPluginType(plugin.PVPPluginName),
+		plugin.WithVerifier(m.verifier),
+	)
+	var notFound *plugin.NotFoundError
+	switch {
+	case errors.Is(err, plugin.ErrPluginsNotFound) || errors.As(err, &notFound):
+		return manifest, nil
+	case err != nil:
+		return manifest, err
+	}
+	manifest.Configuration = installed[id].Configuration
+	return manifest, nil
+}
+
+// isConfiguredRemote returns true when the manifest is for
+// a remote plugin from the C2PConfig.
+func (m *PluginManager) isConfiguredRemote(manifest plugin.Manifest) bool {
+	_, ok := m.remotes[manifest.ID]
+	return ok && manifest.ManifestPath() == ""
+}
+
 // FindRequestedPlugins retrieves information for the plugins that have been requested
 // in the C2PConfig and returns the plugin manifests for use with LaunchPolicyPlugins().
 func (m *PluginManager) FindRequestedPlugins() (plugin.Manifests, error) {
+	registeredManifests := make(plugin.Manifests)
 	providerIds := make([]string, 0, len(m.pluginIdMap))
 	for id := range m.pluginIdMap {
+		if registered, ok := m.providers[id]; ok {
+			m.log.Debug(fmt.Sprintf("Using in-process provider for plugin %s", id))
+			registeredManifests[id] = registered.manifest
+			continue
+		}
+		if remote, ok := m.remotes[id]; ok {
+			m.log.Debug(fmt.Sprintf("Using remote plugin %s", id))
+			manifest, err := m.remoteManifest(id, remote)
+			if err != nil {
+				return nil, err
+			}
+			registeredManifests[id] = manifest
+			continue
+		}
 		providerIds = append(providerIds, id)
 	}
+	if len(providerIds) == 0 {
+		return registeredManifests, nil
+	}
 
-	m.log.Info(fmt.Sprintf("Searching for plugins in %s", m.pluginDir))
+	m.log.Info(fmt.Sprintf("Searching for plugins in %s", strings.Join(m.searchPath, string(os.PathListSeparator))))
 
-	pluginManifests, err := plugin.FindPlugins(
-		m.pluginDir,
+	pluginManifests, err := plugin.FindPluginsInPath(
+		m.searchPath,
 		plugin.WithProviderIds(providerIds),
 		plugin.WithPluginType(plugin.PVPPluginName),
+		plugin.WithVerifier(m.verifier),
 	)
 	if err != nil {
 		return pluginManifests, err
 	}
 	m.log.Debug(fmt.Sprintf("Found %d matching plugins", len(pluginManifests)))
+	for id, manifest := range pluginManifests {
+		m.log.Debug(fmt.Sprintf("Found plugin %s at %s", id, manifest.ManifestPath()))
+		for _, shadowed := range manifest.Shadowed {
+			m.log.Warn(fmt.Sprintf("Ignoring plugin %s at %s, shadowed by %s", id, shadowed, manifest.ManifestPath()))
+		}
+	}
+	for id, manifest := range registeredManifests {
+		pluginManifests[id] = manifest
+	}
 	return pluginManifests, nil
 }
 
@@ -96,25 +282,62 @@ func (m *PluginManager) FindRequestedPlugins() (plugin.Manifests, error) {
 func (m *PluginManager) LaunchPolicyPlugins(manifests plugin.Manifests, pluginConfig config.PluginConfig) (map[string]policy.Provider, error) {
 	pluginsByIds := make(map[string]policy.Provider)
 	for _, manifest := range manifests {
-		policyPlugin, err := plugin.NewPolicyPlugin(manifest, m.clientFactory)
-		if err != nil {
-			return pluginsByIds, err
+		var policyPlugin policy.Provider
+		if registered, ok := m.providers[manifest.ID]; ok {
+			policyPlugin = registered.provider
+			m.log.Debug(fmt.Sprintf("Using in-process provider for plugin %s", manifest.ID))
+		} else if m.isConfiguredRemote(manifest) {
+			// Remote plugins from the C2PConfig have no manifest to verify
+			connected, err := plugin.NewRemotePolicyPlugin(manifest, m.log)
+			if err != nil {
+				return pluginsByIds, err
+			}
+			policyPlugin = connected
+			m.log.Debug(fmt.Sprintf("Connected to remote plugin %s", manifest.ID))
+		} else if manifest.IsRemote() {
+			connected, err := m.remoteFactory(manifest)
+			if err != nil {
+				return pluginsByIds, err
+			}
+			policyPlugin = connected
+			m.log.Debug(fmt.Sprintf("Connected to remote plugin %s", manifest.ID))
+		} else {
+			launched, err := plugin.NewPolicyPlugin(manifest, m.clientFactory)
+			if err != nil {
+				return pluginsByIds, m.pluginLogs.WrapError(manifest.ID, err)
+			}
+			policyPlugin = launched
+			m.log.Debug(fmt.Sprintf("Launched plugin %s", manifest.ID))
 		}
 		pluginsByIds[manifest.ID] = policyPlugin
-		m.log.Debug(fmt.Sprintf("Launched plugin %s", manifest.ID))
+		if _, inProcess := m.providers[manifest.ID]; !inProcess {
+			m.evidenceCollectors[manifest.ID] = manifest.Collectors
+		}
 		m.log.Debug(fmt.Sprintf("Gathering configuration options for %s", manifest.ID))
 
-		// Get all the base configuration
+		// Get all the base configuration. Remote plugins from the C2PConfig that do not
+		// declare options get the configuration selections as is.
 		if len(manifest.Configuration) > 0 {
 			if err := m.configurePlugin(policyPlugin, manifest, pluginConfig); err != nil {
-				return pluginsByIds, fmt.Errorf("failed to configure plugin %s: %w", manifest.ID, err)
+				return pluginsByIds, m.pluginLogs.WrapError(manifest.ID, fmt.Errorf("failed to configure plugin %s: %w", manifest.ID, err))
+			}
+		} else if m.isConfiguredRemote(manifest) {
+			if selections := pluginConfig(manifest.ID); len(selections) > 0 {
+				if err := policyPlugin.Configure(selections); err != nil {
+					return pluginsByIds, fmt.Errorf("failed to configure plugin %s: %w", manifest.ID, err)
+				}
 			}
 		}
 	}
 	return pluginsByIds, nil
 }
 
-func (m *PluginManager) configurePlugin(policyPlugin policy.Provider, manifest plugin.Manifest, pluginConfig config.PluginConfig) error {
+// configurable is a plugin that accepts configuration options.
+type configurable interface {
+	Configure(map[string]string) error
+}
+
+func (m *PluginManager) configurePlugin(policyPlugin configurable, manifest plugin.Manifest, pluginConfig config.PluginConfig) error {
 	selections := pluginConfig(manifest.ID)
 	if selections == nil {
 		selections = make(map[string]string)
@@ -124,12 +347,35 @@ func (m *PluginManager) configurePlugin(policyPlugin policy.Provider, manifest p
 	if err != nil {
 		return err
 	}
+	if _, inProcess := m.providers[manifest.ID]; m.sandboxed && !inProcess && !manifest.IsRemote() {
+		// Sandboxed plugins run in their own working directory
+		if err := resolveHostPaths(manifest.Configuration, configMap); err != nil {
+			return err
+		}
+	}
 	if err := policyPlugin.Configure(configMap); err != nil {
 		return err
 	}
 	return nil
 }
 
+// resolveHostPaths replaces the relative values of the path options of the
+// configuration with absolute paths in the host working directory.
+func resolveHostPaths(options []plugin.ConfigurationOption, configMap map[string]string) error {
+	for _, option := range options {
+		value := configMap[option.Name]
+		if !option.Path || value == "" || filepath.IsAbs(value) {
+			continue
+		}
+		absPath, err := filepath.Abs(value)
+		if err != nil {
+			return fmt.Errorf("failed to resolve option %s: %w", option.Name, err)
+		}
+		configMap[option.Name] = absPath
+	}
+	return nil
+}
+
 // GeneratePolicy identifies policy configuration for each provider in the given pluginSet to execute the Generate() method
 // each policy.Provider. The rule set passed to each plugin can be configured with compliance specific settings with the
 // complianceSettings input.
@@ -146,8 +392,8 @@ func (m *PluginManager) GeneratePolicy(ctx context.Context, pluginSet map[string
 		if err != nil {
 			return fmt.Errorf("failed to get rule sets for component %s: %w", componentTitle, err)
 		}
-		if err := policyPlugin.Generate(appliedRuleSet); err != nil {
-			return fmt.Errorf("plugin %s: %w", providerId, err)
+		if err := policyPlugin.Generate(m.ruleIndex.Policy(componentTitle, appliedRuleSet)); err != nil {
+			return m.pluginLogs.WrapError(providerId, fmt.Errorf("plugin %s: %w", providerId, err))
 		}
 	}
 	return nil
@@ -157,6 +403,17 @@ func (m *PluginManager) GeneratePolicy(ctx context.Context, pluginSet map[string
 // each policy.Provider. The rule set passed to each plugin can be configured with compliance specific settings with the
 // complianceSettings input.
 func (m *PluginManager) AggregateResults(ctx context.Context, pluginSet map[string]policy.Provider, complianceSettings settings.Settings) ([]policy.PVPResult, error) {
+	return m.aggregateResults(ctx, pluginSet, nil, complianceSettings)
+}
+
+// EvaluateEvidence passes the evidence from CollectEvidence() or LoadEvidence() to each provider in the given pluginSet
+// that implements policy.Evaluator. Providers that do not evaluate collected evidence get the results
+// with GetResults() as in AggregateResults().
+func (m *PluginManager) EvaluateEvidence(ctx context.Context, pluginSet map[string]policy.Provider, evidence []policy.Evidence, complianceSettings settings.Settings) ([]policy.PVPResult, error) {
+	return m.aggregateResults(ctx, pluginSet, evidence, complianceSettings)
+}
+
+func (m *PluginManager) aggregateResults(ctx context.Context, pluginSet map[string]policy.Provider, evidence []policy.Evidence, complianceSettings settings.Settings) ([]policy.PVPResult, error) {
 	var allResults []policy.PVPResult
 	for providerId, policyPlugin := range pluginSet {
 		// get the provider ids here to grab the policy
@@ -170,18 +427,280 @@ func (m *PluginManager) AggregateResults(ctx context.Context, pluginSet map[stri
 			return allResults, fmt.Errorf("failed to get rule sets for component %s: %w", componentTitle, err)
 		}
 
-		pluginResults, err := policyPlugin.GetResults(appliedRuleSet)
+		pluginResults, err := m.results(providerId, policyPlugin, m.ruleIndex.Policy(componentTitle, appliedRuleSet), m.providerEvidence(providerId, evidence))
 		if err != nil {
-			return allResults, fmt.Errorf("plugin %s: %w", providerId, err)
+			return allResults, m.pluginLogs.WrapError(providerId, fmt.Errorf("plugin %s: %w", providerId, err))
 		}
 		allResults = append(allResults, pluginResults)
 	}
 	return allResults, nil
 }
 
-// Clean deletes managed instances of plugin clients that have been created using LaunchPolicyPlugins.
-// This will remove all clients launched with the plugin.ClientFactoryFunc.
+// providerEvidence returns the evidence of the collectors declared in the manifest
+// of the launched policy plugin, so plugins only get the evidence they evaluate.
+// In-process providers get all the evidence.
+func (m *PluginManager) providerEvidence(providerId string, evidence []policy.Evidence) []policy.Evidence {
+	collectorIDs, ok := m.evidenceCollectors[providerId]
+	if !ok {
+		return evidence
+	}
+	var selected []policy.Evidence
+	for _, e := range evidence {
+		if slices.Contains(collectorIDs, e.Collector) {
+			selected = append(selected, e)
+		}
+	}
+	return selected
+}
+
+// results evaluates the evidence with the provider when there is evidence and the
+// provider supports it, and gets the results from the provider otherwise.
+func (m *PluginManager) results(providerId string, policyPlugin policy.Provider, providerPolicy policy.Policy, evidence []policy.Evidence) (policy.PVPResult, error) {
+	if evaluator, ok := policyPlugin.(policy.Evaluator); ok && len(evidence) > 0 {
+		pluginResults, err := evaluator.Evaluate(providerPolicy, evidence)
+		if !errors.Is(err, plugin.ErrEvaluationNotSupported) {
+			return pluginResults, err
+		}
+		m.log.Debug(fmt.Sprintf("Provider %s does not evaluate collected evidence, getting results", providerId))
+	}
+	return policyPlugin.GetResults(providerPolicy)
+}
+
+// FindRemediationPlugins retrieves information for the given remediation plugins and returns the
+// plugin manifests for use with LaunchRemediationPlugins().
+func (m *PluginManager) FindRemediationPlugins(pluginIds []string) (plugin.Manifests, error) {
+	if len(pluginIds) == 0 {
+		return plugin.Manifests{}, nil
+	}
+	m.log.Info(fmt.Sprintf("Searching for remediation plugins in %s", strings.Join(m.searchPath, string(os.PathListSeparator))))
+	return plugin.FindPluginsInPath(
+		m.searchPath,
+		plugin.WithProviderIds(pluginIds),
+		plugin.WithPluginType(plugin.RemediationPluginName),
+		plugin.WithVerifier(m.verifier),
+	)
+}
+
+// LaunchRemediationPlugins launches the remediation plugins and configures each plugin to make it ready for use
+// with ProposeRemediations(). The plugin is configured based on default options and given options the same way as
+// with LaunchPolicyPlugins().
+func (m *PluginManager) LaunchRemediationPlugins(manifests plugin.Manifests, pluginConfig config.PluginConfig) (map[string]policy.Remediator, error) {
+	remediatorsByIds := make(map[string]policy.Remediator)
+	for _, manifest := range manifests {
+		if manifest.IsRemote() {
+			return remediatorsByIds, fmt.Errorf("remediation plugin %s: remote remediation plugins are not supported", manifest.ID)
+		}
+		remediator, err := plugin.NewRemediationPlugin(manifest, m.clientFactory)
+		if err != nil {
+			return remediatorsByIds, m.pluginLogs.WrapError(manifest.ID, err)
+		}
+		remediatorsByIds[manifest.ID] = remediator
+		m.log.Debug(fmt.Sprintf("Launched remediation plugin %s", manifest.ID))
+
+		if len(manifest.Configuration) > 0 {
+			if err := m.configurePlugin(remediator, manifest, pluginConfig); err != nil {
+				return remediatorsByIds, m.pluginLogs.WrapError(manifest.ID, fmt.Errorf("failed to configure plugin %s: %w", manifest.ID, err))
+			}
+		}
+	}
+	return remediatorsByIds, nil
+}
+
+// ProposeRemediations gets the results of each provider in the given pluginSet and passes the failed subjects,
+// with the policy of the provider, to each of the remediators. It returns the proposed remediations by remediation
+// plugin ID. The remediations are only proposed and applying them is left to the caller.
+func (m *PluginManager) ProposeRemediations(ctx context.Context, pluginSet map[string]policy.Provider, remediators map[string]policy.Remediator, complianceSettings settings.Settings) (map[string][]policy.Remediation, error) {
+	proposed := make(map[string][]policy.Remediation)
+	for providerId, policyPlugin := range pluginSet {
+		componentTitle, ok := m.pluginIdMap[providerId]
+		if !ok {
+			return proposed, fmt.Errorf("missing title for provider %s", providerId)
+		}
+		appliedRuleSet, err := settings.ApplyToComponent(ctx, componentTitle, m.rulesStore, complianceSettings)
+		if err != nil {
+			return proposed, fmt.Errorf("failed to get rule sets for component %s: %w", componentTitle, err)
+		}
+		providerPolicy := m.ruleIndex.Policy(componentTitle, appliedRuleSet)
+
+		pluginResults, err := policyPlugin.GetResults(providerPolicy)
+		if err != nil {
+			return proposed, m.pluginLogs.WrapError(providerId, fmt.Errorf("plugin %s: %w", providerId, err))
+		}
+		failed := pluginResults.Failed()
+		if len(failed.ObservationsByCheck) == 0 {
+			m.log.Debug(fmt.Sprintf("No failed subjects to remediate for provider %s", providerId))
+			continue
+		}
+
+		for remediatorId, remediator := range remediators {
+			m.log.Debug(fmt.Sprintf("Proposing remediations for provider %s with %s", providerId, remediatorId))
+			remediations, err := remediator.Remediate(providerPolicy, failed)
+			if err != nil {
+				return proposed, m.pluginLogs.WrapError(remediatorId, fmt.Errorf("plugin %s: %w", remediatorId, err))
+			}
+			proposed[remediatorId] = append(proposed[remediatorId], remediations...)
+		}
+	}
+	return proposed, nil
+}
+
+// FindCollectorPlugins retrieves information for the given collector plugins and returns the
+// plugin manifests for use with LaunchCollectorPlugins().
+func (m *PluginManager) FindCollectorPlugins(pluginIds []string) (plugin.Manifests, error) {
+	if len(pluginIds) == 0 {
+		return plugin.Manifests{}, nil
+	}
+	m.log.Info(fmt.Sprintf("Searching for collector plugins in %s", strings.Join(m.searchPath, string(os.PathListSeparator))))
+	return plugin.FindPluginsInPath(
+		m.searchPath,
+		plugin.WithProviderIds(pluginIds),
+		plugin.WithPluginType(plugin.CollectorPluginName),
+		plugin.WithVerifier(m.verifier),
+	)
+}
+
+// LaunchCollectorPlugins launches the collector plugins and configures each plugin to make it ready for use
+// with CollectEvidence(). The plugin is configured based on default options and given options the same way as
+// with LaunchPolicyPlugins().
+func (m *PluginManager) LaunchCollectorPlugins(manifests plugin.Manifests, pluginConfig config.PluginConfig) (map[string]policy.Collector, error) {
+	collectorsByIds := make(map[string]policy.Collector)
+	for _, manifest := range manifests {
+		if manifest.IsRemote() {
+			return collectorsByIds, fmt.Errorf("collector plugin %s: remote collector plugins are not supported", manifest.ID)
+		}
+		collector, err := plugin.NewCollectorPlugin(manifest, m.clientFactory)
+		if err != nil {
+			return collectorsByIds, m.pluginLogs.WrapError(manifest.ID, err)
+		}
+		collectorsByIds[manifest.ID] = collector
+		m.log.Debug(fmt.Sprintf("Launched collector plugin %s", manifest.ID))
+
+		if len(manifest.Configuration) > 0 {
+			if err := m.configurePlugin(collector, manifest, pluginConfig); err != nil {
+				return collectorsByIds, m.pluginLogs.WrapError(manifest.ID, fmt.Errorf("failed to configure plugin %s: %w", manifest.ID, err))
+			}
+		}
+	}
+	return collectorsByIds, nil
+}
+
+// CollectEvidence passes the policy of all the providers in the given pluginSet to each of the collectors and
+// stores the returned evidence in the evidence directory of the C2PConfig. The stored evidence is returned for
+// use with EvaluateEvidence(), so one collection can be evaluated by several providers.
+func (m *PluginManager) CollectEvidence(ctx context.Context, pluginSet map[string]policy.Provider, collectors map[string]policy.Collector, complianceSettings settings.Settings) ([]policy.Evidence, error) {
+	if m.evidenceDir == "" {
+		return nil, errors.New("evidence directory is not set")
+	}
+
+	providerIds := make([]string, 0, len(pluginSet))
+	for providerId := range pluginSet {
+		providerIds = append(providerIds, providerId)
+	}
+	sort.Strings(providerIds)
+	var collectionPolicy policy.Policy
+	seen := make(map[string]bool)
+	for _, providerId := range providerIds {
+		componentTitle, ok := m.pluginIdMap[providerId]
+		if !ok {
+			return nil, fmt.Errorf("missing title for provider %s", providerId)
+		}
+		appliedRuleSet, err := settings.ApplyToComponent(ctx, componentTitle, m.rulesStore, complianceSettings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rule sets for component %s: %w", componentTitle, err)
+		}
+		for _, ruleSet := range m.ruleIndex.Policy(componentTitle, appliedRuleSet) {
+			if !seen[ruleSet.Rule.ID] {
+				seen[ruleSet.Rule.ID] = true
+				collectionPolicy = append(collectionPolicy, ruleSet)
+			}
+		}
+	}
+
+	collectorIds := make([]string, 0, len(collectors))
+	for collectorId := range collectors {
+		collectorIds = append(collectorIds, collectorId)
+	}
+	sort.Strings(collectorIds)
+	collected := make(map[string][]policy.Evidence)
+	for _, collectorId := range collectorIds {
+		m.log.Debug(fmt.Sprintf("Collecting evidence with %s", collectorId))
+		evidence, err := collectors[collectorId].Collect(collectionPolicy)
+		if err != nil {
+			return nil, m.pluginLogs.WrapError(collectorId, fmt.Errorf("plugin %s: %w", collectorId, err))
+		}
+		collected[collectorId] = evidence
+	}
+
+	m.log.Info(fmt.Sprintf("Storing collected evidence in %s", m.evidenceDir))
+	return storeEvidence(m.evidenceDir, collected, collectorIds)
+}
+
+// LaunchResultProcessors builds the result processor pipeline from the C2PConfig. The built-in processors are
+// configured with the options of their step. The processor plugins are found, launched and configured with the
+// options of their step the same way as with LaunchPolicyPlugins().
+func (m *PluginManager) LaunchResultProcessors() (*ResultPipeline, error) {
+	var pluginIds []string
+	for _, step := range m.resultProcessors {
+		if step.Plugin != "" {
+			pluginIds = append(pluginIds, step.Plugin)
+		}
+	}
+	manifests := plugin.Manifests{}
+	if len(pluginIds) > 0 {
+		m.log.Info(fmt.Sprintf("Searching for processor plugins in %s", strings.Join(m.searchPath, string(os.PathListSeparator))))
+		found, err := plugin.FindPluginsInPath(
+			m.searchPath,
+			plugin.WithProviderIds(pluginIds),
+			plugin.WithPluginType(plugin.ProcessorPluginName),
+			plugin.WithVerifier(m.verifier),
+		)
+		if err != nil {
+			return nil, err
+		}
+		manifests = found
+	}
+
+	pipeline := &ResultPipeline{}
+	for _, step := range m.resultProcessors {
+		if step.Name != "" {
+			processor, err := newBuiltinProcessor(step.Name, step.Options)
+			if err != nil {
+				return nil, err
+			}
+			pipeline.steps = append(pipeline.steps, resultStep{name: step.Name, processor: processor})
+			continue
+		}
+
+		manifest := manifests[step.Plugin]
+		if manifest.IsRemote() {
+			return nil, fmt.Errorf("processor plugin %s: remote processor plugins are not supported", manifest.ID)
+		}
+		if len(manifest.Configuration) == 0 && len(step.Options) > 0 {
+			return nil, fmt.Errorf("processor plugin %s does not declare any configuration options", manifest.ID)
+		}
+		processor, err := plugin.NewProcessorPlugin(manifest, m.clientFactory)
+		if err != nil {
+			return nil, m.pluginLogs.WrapError(manifest.ID, err)
+		}
+		m.log.Debug(fmt.Sprintf("Launched processor plugin %s", manifest.ID))
+		if len(manifest.Configuration) > 0 {
+			options := step.Options
+			if err := m.configurePlugin(processor, manifest, func(string) map[string]string { return options }); err != nil {
+				return nil, m.pluginLogs.WrapError(manifest.ID, fmt.Errorf("failed to configure plugin %s: %w", manifest.ID, err))
+			}
+		}
+		pipeline.steps = append(pipeline.steps, resultStep{name: step.Plugin, processor: processor})
+	}
+	return pipeline, nil
+}
+
+// Clean deletes managed instances of plugin clients that have been created using LaunchPolicyPlugins,
+// LaunchRemediationPlugins, LaunchCollectorPlugins and LaunchResultProcessors.
+// This will remove all clients launched with the plugin.ClientFactoryFunc and close the plugin log files.
 func (m *PluginManager) Clean() {
 	m.log.Debug("Cleaning launched plugins")
 	plugin.Cleanup()
+	if err := m.pluginLogs.Close(); err != nil {
+		m.log.Warn(fmt.Sprintf("failed to close plugin log files: %v", err))
+	}
 }
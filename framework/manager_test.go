@@ -7,11 +7,14 @@ package framework
 
 import (
 	"context"
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 
 	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-2"
+	hplugin "github.com/hashicorp/go-plugin"
 	"github.com/oscal-compass/oscal-sdk-go/extensions"
 	"github.com/oscal-compass/oscal-sdk-go/models"
 	"github.com/oscal-compass/oscal-sdk-go/settings"
@@ -25,33 +28,47 @@ import (
 )
 
 var (
-	expectedCertFileRule = extensions.RuleSet{
-		Rule: extensions.Rule{
-			ID:          "etcd_cert_file",
-			Description: "Ensure that the --cert-file argument is set as appropriate",
-		},
-		Checks: []extensions.Check{
-			{
+	testComponent = &policy.Component{
+		UUID:        "c8106bc8-5174-4e86-91a4-52f2fe0ed027",
+		Title:       "TestKubernetes",
+		Type:        "service",
+		Description: "TestKubernetes",
+	}
+	expectedCertFileRule = policy.RuleSet{
+		RuleSet: extensions.RuleSet{
+			Rule: extensions.Rule{
 				ID:          "etcd_cert_file",
-				Description: "Check that the --cert-file argument is set as appropriate",
+				Description: "Ensure that the --cert-file argument is set as appropriate",
 			},
-		},
-	}
-	expectedKeyFileRule = extensions.RuleSet{
-		Rule: extensions.Rule{
-			ID:          "etcd_key_file",
-			Description: "Ensure that the --key-file argument is set as appropriate",
-			Parameter: &extensions.Parameter{
-				ID:          "file_name",
-				Description: "A parameter for a file name",
+			Checks: []extensions.Check{
+				{
+					ID:          "etcd_cert_file",
+					Description: "Check that the --cert-file argument is set as appropriate",
+				},
 			},
 		},
-		Checks: []extensions.Check{
-			{
+		ControlIDs: []string{"CIS-2.1"},
+		Component:  testComponent,
+	}
+	expectedKeyFileRule = policy.RuleSet{
+		RuleSet: extensions.RuleSet{
+			Rule: extensions.Rule{
 				ID:          "etcd_key_file",
-				Description: "Check that the --key-file argument is set as appropriate",
+				Description: "Ensure that the --key-file argument is set as appropriate",
+				Parameter: &extensions.Parameter{
+					ID:          "file_name",
+					Description: "A parameter for a file name",
+				},
+			},
+			Checks: []extensions.Check{
+				{
+					ID:          "etcd_key_file",
+					Description: "Check that the --key-file argument is set as appropriate",
+				},
 			},
 		},
+		ControlIDs: []string{"CIS-2.1"},
+		Component:  testComponent,
 	}
 )
 
@@ -69,6 +86,15 @@ func TestNewPluginManager(t *testing.T) {
 	manager, err := NewPluginManager(cfg)
 	require.NoError(t, err)
 	require.NotNil(t, manager)
+	require.Empty(t, manager.RunID())
+
+	logDir := filepath.Join(t.TempDir(), "logs")
+	cfg.PluginLogs = &plugin.LogCapture{Dir: logDir, RunID: "run-1"}
+	manager, err = NewPluginManager(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "run-1", manager.RunID())
+	require.DirExists(t, logDir)
+	require.Nil(t, manager.PluginLogs("mypvpvalidator"))
 }
 
 func TestPluginManager_GeneratePolicy(t *testing.T) {
@@ -168,6 +194,424 @@ func TestPluginManager_Configure(t *testing.T) {
 	providerTestObj.AssertExpectations(t)
 }
 
+func TestPluginManager_ConfigureSandboxed(t *testing.T) {
+	cfg := prepConfig(t)
+	cfg.Sandbox = &plugin.Sandbox{}
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	manifest := plugin.Manifest{
+		Metadata: plugin.Metadata{
+			ID: "myplugin",
+		},
+		Configuration: []plugin.ConfigurationOption{
+			{Name: "policy-dir", Required: true, Path: true},
+			{Name: "output-dir", Required: true, Path: true},
+			{Name: "name", Required: true},
+		},
+	}
+	pluginMap := func(string) map[string]string {
+		return map[string]string{"policy-dir": "testdata", "output-dir": "not-exist", "name": "testdata"}
+	}
+
+	// relative paths of the path options are resolved against the host working directory
+	policyDir, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+	outputDir, err := filepath.Abs("not-exist")
+	require.NoError(t, err)
+	providerTestObj := new(policyProvider)
+	providerTestObj.
+		On("Configure", map[string]string{"policy-dir": policyDir, "output-dir": outputDir, "name": "testdata"}).
+		Return(nil)
+	err = pluginManager.configurePlugin(providerTestObj, manifest, pluginMap)
+	require.NoError(t, err)
+	providerTestObj.AssertExpectations(t)
+}
+
+func TestPluginManager_RegisterProvider(t *testing.T) {
+	cfg := prepConfig(t)
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	defaultValue := "value"
+	options := []plugin.ConfigurationOption{
+		{
+			Name:     "option1",
+			Required: true,
+		},
+		{
+			Name:    "option2",
+			Default: &defaultValue,
+		},
+	}
+	providerTestObj := new(policyProvider)
+	require.NoError(t, pluginManager.RegisterProvider("mypvpvalidator", providerTestObj, options...))
+	require.EqualError(t, pluginManager.RegisterProvider("mypvpvalidator", providerTestObj), "provider for plugin mypvpvalidator is already registered")
+	require.EqualError(t, pluginManager.RegisterProvider("MyPlugin", providerTestObj), "invalid plugin id \"MyPlugin\"")
+
+	// The plugin directory has no plugins, the registered provider is found without searching it.
+	manifests, err := pluginManager.FindRequestedPlugins()
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	require.Equal(t, options, manifests["mypvpvalidator"].Configuration)
+
+	// Options are resolved against the registered configuration options.
+	_, err = pluginManager.LaunchPolicyPlugins(manifests, func(string) map[string]string { return nil })
+	require.EqualError(t, err, "failed to configure plugin mypvpvalidator: required value not supplied for option \"option1\"")
+
+	providerTestObj.
+		On("Configure", map[string]string{"option1": "override", "option2": "value"}).
+		Return(nil)
+	pluginSet, err := pluginManager.LaunchPolicyPlugins(manifests, func(string) map[string]string {
+		return map[string]string{"option1": "override"}
+	})
+	require.NoError(t, err)
+	require.Same(t, providerTestObj, pluginSet["mypvpvalidator"])
+	providerTestObj.AssertExpectations(t)
+}
+
+func TestPluginManager_RegisterProviderGRPC(t *testing.T) {
+	cfg := prepConfig(t)
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	// Serve the provider over an in-process gRPC connection to exercise
+	// the plugin protocol without a plugin binary.
+	providerTestObj := new(policyProvider)
+	client, _ := hplugin.TestPluginGRPCConn(t, false, map[string]hplugin.Plugin{
+		plugin.PVPPluginName: &plugin.PVPPlugin{Impl: providerTestObj},
+	})
+	t.Cleanup(func() { _ = client.Close() })
+	raw, err := client.Dispense(plugin.PVPPluginName)
+	require.NoError(t, err)
+	require.NoError(t, pluginManager.RegisterProvider("mypvpvalidator", raw.(policy.Provider)))
+
+	manifests, err := pluginManager.FindRequestedPlugins()
+	require.NoError(t, err)
+	pluginSet, err := pluginManager.LaunchPolicyPlugins(manifests, func(string) map[string]string { return nil })
+	require.NoError(t, err)
+
+	providerTestObj.On("Generate", policy.Policy{expectedCertFileRule}).Return(nil)
+	testSettings := settings.NewSettings(map[string]struct{}{"etcd_cert_file": {}}, map[string]string{})
+	require.NoError(t, pluginManager.GeneratePolicy(context.TODO(), pluginSet, testSettings))
+	providerTestObj.AssertExpectations(t)
+}
+
+func TestPluginManager_RemotePlugins(t *testing.T) {
+	// Serve the provider in reattach mode to exercise a remote
+	// plugin from the C2PConfig without a network listener.
+	providerTestObj := new(policyProvider)
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	done := make(chan error, 1)
+	go func() {
+		serveConfig := plugin.ServeConfig{
+			PluginSet: map[string]hplugin.Plugin{plugin.PVPPluginName: &plugin.PVPPlugin{Impl: providerTestObj}},
+		}
+		done <- plugin.Serve(ctx, serveConfig, []string{"--reattach"}, writer)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+	var reattach plugin.Reattach
+	require.NoError(t, json.NewDecoder(reader).Decode(&reattach))
+
+	cfg := prepConfig(t)
+	cfg.RemotePlugins = map[string]plugin.Remote{
+		"mypvpvalidator": {Reattach: &reattach},
+	}
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+	t.Cleanup(pluginManager.Clean)
+
+	// The plugin directory has no plugins, the remote plugin is found without searching it.
+	manifests, err := pluginManager.FindRequestedPlugins()
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	require.True(t, manifests["mypvpvalidator"].IsRemote())
+
+	// The configuration selections are passed as is
+	providerTestObj.On("Configure", map[string]string{"option1": "override"}).Return(nil)
+	pluginSet, err := pluginManager.LaunchPolicyPlugins(manifests, func(string) map[string]string {
+		return map[string]string{"option1": "override"}
+	})
+	require.NoError(t, err)
+
+	providerTestObj.On("Generate", policy.Policy{expectedCertFileRule}).Return(nil)
+	testSettings := settings.NewSettings(map[string]struct{}{"etcd_cert_file": {}}, map[string]string{})
+	require.NoError(t, pluginManager.GeneratePolicy(context.TODO(), pluginSet, testSettings))
+	providerTestObj.AssertExpectations(t)
+
+	// The configuration options of the installed manifest with the same ID are resolved
+	cfg.PluginDir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.PluginDir, "mypvpvalidator"), nil, 0700))
+	installed := `{
+  "metadata": {"id": "mypvpvalidator", "description": "Installed plugin", "version": "0.0.1", "types": ["pvp"]},
+  "executablePath": "mypvpvalidator",
+  "sha256": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+  "configuration": [
+    {"name": "option1", "description": "Option 1", "required": true},
+    {"name": "option2", "description": "Option 2", "default": "value"}
+  ]
+}`
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.PluginDir, "c2p-mypvpvalidator-manifest.json"), []byte(installed), 0600))
+	pluginManager, err = NewPluginManager(cfg)
+	require.NoError(t, err)
+	t.Cleanup(pluginManager.Clean)
+	manifests, err = pluginManager.FindRequestedPlugins()
+	require.NoError(t, err)
+	require.True(t, manifests["mypvpvalidator"].IsRemote())
+	require.Len(t, manifests["mypvpvalidator"].Configuration, 2)
+
+	_, err = pluginManager.LaunchPolicyPlugins(manifests, func(string) map[string]string { return nil })
+	require.ErrorContains(t, err, "required value not supplied for option \"option1\"")
+	providerTestObj.On("Configure", map[string]string{"option1": "override", "option2": "value"}).Return(nil)
+	_, err = pluginManager.LaunchPolicyPlugins(manifests, func(string) map[string]string {
+		return map[string]string{"option1": "override"}
+	})
+	require.NoError(t, err)
+	providerTestObj.AssertExpectations(t)
+}
+
+func TestPluginManager_ProposeRemediations(t *testing.T) {
+	cfg := prepConfig(t)
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	passed := policy.Subject{Title: "passed", ResourceID: "passed", Result: policy.ResultPass}
+	failed := policy.Subject{Title: "failed", ResourceID: "failed", Result: policy.ResultFail}
+	results := policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{
+			{CheckID: "etcd_cert_file", Subjects: []policy.Subject{passed, failed}},
+			{CheckID: "etcd_key_file", Subjects: []policy.Subject{passed}},
+		},
+	}
+	wantFailed := policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{
+			{CheckID: "etcd_cert_file", Subjects: []policy.Subject{failed}},
+		},
+	}
+	remediations := []policy.Remediation{
+		{
+			Title:      "Set the cert file",
+			CheckID:    "etcd_cert_file",
+			ResourceID: "failed",
+			Artifacts:  []policy.Artifact{{Name: "patch.yaml", Content: []byte("spec: {}")}},
+		},
+	}
+
+	providerTestObj := new(policyProvider)
+	providerTestObj.On("GetResults", policy.Policy{expectedCertFileRule}).Return(results, nil)
+	remediatorTestObj := new(remediator)
+	remediatorTestObj.On("Remediate", policy.Policy{expectedCertFileRule}, wantFailed).Return(remediations, nil)
+
+	testSettings := settings.NewSettings(map[string]struct{}{"etcd_cert_file": {}}, map[string]string{})
+	proposed, err := pluginManager.ProposeRemediations(
+		context.TODO(),
+		map[string]policy.Provider{"mypvpvalidator": providerTestObj},
+		map[string]policy.Remediator{"myremediator": remediatorTestObj},
+		testSettings,
+	)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]policy.Remediation{"myremediator": remediations}, proposed)
+	providerTestObj.AssertExpectations(t)
+	remediatorTestObj.AssertExpectations(t)
+
+	// The remediators are not called without failed subjects
+	passingProvider := new(policyProvider)
+	passingProvider.On("GetResults", policy.Policy{expectedCertFileRule}).Return(policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "etcd_cert_file", Subjects: []policy.Subject{passed}}},
+	}, nil)
+	unusedRemediator := new(remediator)
+	proposed, err = pluginManager.ProposeRemediations(
+		context.TODO(),
+		map[string]policy.Provider{"mypvpvalidator": passingProvider},
+		map[string]policy.Remediator{"myremediator": unusedRemediator},
+		testSettings,
+	)
+	require.NoError(t, err)
+	require.Empty(t, proposed)
+	unusedRemediator.AssertNotCalled(t, "Remediate", mock.Anything, mock.Anything)
+}
+
+func TestPluginManager_LaunchRemediationPlugins(t *testing.T) {
+	cfg := prepConfig(t)
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	manifests, err := pluginManager.FindRemediationPlugins(nil)
+	require.NoError(t, err)
+	require.Empty(t, manifests)
+
+	remote := plugin.Manifests{
+		"myremediator": plugin.Manifest{
+			Metadata: plugin.Metadata{ID: "myremediator", Types: []string{plugin.RemediationPluginName}},
+			Remote:   &plugin.Remote{Address: "localhost:9443"},
+		},
+	}
+	_, err = pluginManager.LaunchRemediationPlugins(remote, func(string) map[string]string { return nil })
+	require.EqualError(t, err, "remediation plugin myremediator: remote remediation plugins are not supported")
+}
+
+func TestPluginManager_CollectEvidence(t *testing.T) {
+	cfg := prepConfig(t)
+	cfg.EvidenceDir = filepath.Join(t.TempDir(), "evidence")
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	reports := policy.NewEvidence("policyreports.yaml", "application/yaml", []byte("items: []\n"))
+	dump := policy.Evidence{Name: "pods.json", MediaType: "application/json", Content: []byte("{}")}
+	collectorTestObj := new(collector)
+	collectorTestObj.On("Collect", policy.Policy{expectedCertFileRule}).Return([]policy.Evidence{reports, dump}, nil)
+
+	testSettings := settings.NewSettings(map[string]struct{}{"etcd_cert_file": {}}, map[string]string{})
+	pluginSet := map[string]policy.Provider{"mypvpvalidator": new(policyProvider)}
+	evidence, err := pluginManager.CollectEvidence(
+		context.TODO(),
+		pluginSet,
+		map[string]policy.Collector{"mycollector": collectorTestObj},
+		testSettings,
+	)
+	require.NoError(t, err)
+	collectorTestObj.AssertExpectations(t)
+	require.Len(t, evidence, 2)
+	require.Equal(t, filepath.ToSlash(filepath.Join(cfg.EvidenceDir, "mycollector", "policyreports.yaml")), evidence[0].Href)
+	require.Equal(t, "mycollector", evidence[0].Collector)
+	require.Equal(t, policy.Digest([]byte("{}")), evidence[1].Digest)
+	require.FileExists(t, filepath.Join(cfg.EvidenceDir, "mycollector", "pods.json"))
+
+	loaded, err := LoadEvidence(cfg.EvidenceDir)
+	require.NoError(t, err)
+	require.Equal(t, evidence, loaded)
+
+	// Evidence that does not match its digest is not stored
+	tampered := reports
+	tampered.Content = []byte("items: [tampered]\n")
+	tamperingCollector := new(collector)
+	tamperingCollector.On("Collect", policy.Policy{expectedCertFileRule}).Return([]policy.Evidence{tampered}, nil)
+	_, err = pluginManager.CollectEvidence(
+		context.TODO(),
+		pluginSet,
+		map[string]policy.Collector{"mycollector": tamperingCollector},
+		testSettings,
+	)
+	require.ErrorContains(t, err, "collector plugin mycollector: evidence policyreports.yaml: digest")
+
+	cfg.EvidenceDir = ""
+	pluginManager, err = NewPluginManager(cfg)
+	require.NoError(t, err)
+	_, err = pluginManager.CollectEvidence(context.TODO(), pluginSet, nil, testSettings)
+	require.EqualError(t, err, "evidence directory is not set")
+}
+
+func TestPluginManager_EvaluateEvidence(t *testing.T) {
+	cfg := prepConfig(t)
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	evidence := []policy.Evidence{policy.NewEvidence("policyreports.yaml", "application/yaml", []byte("items: []\n"))}
+	evaluated := policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "etcd_cert_file", Title: "evaluated"}},
+	}
+	collected := policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "etcd_cert_file", Title: "collected"}},
+	}
+	testSettings := settings.NewSettings(map[string]struct{}{"etcd_cert_file": {}}, map[string]string{})
+
+	evaluatorTestObj := &evaluator{policyProvider: new(policyProvider)}
+	evaluatorTestObj.On("Evaluate", policy.Policy{expectedCertFileRule}, evidence).Return(evaluated, nil)
+	results, err := pluginManager.EvaluateEvidence(context.TODO(), map[string]policy.Provider{"mypvpvalidator": evaluatorTestObj}, evidence, testSettings)
+	require.NoError(t, err)
+	require.Equal(t, []policy.PVPResult{evaluated}, results)
+	evaluatorTestObj.AssertNotCalled(t, "GetResults", mock.Anything)
+
+	// Providers that do not evaluate evidence get the results themselves
+	unsupported := &evaluator{policyProvider: new(policyProvider)}
+	unsupported.On("Evaluate", policy.Policy{expectedCertFileRule}, evidence).Return(policy.PVPResult{}, plugin.ErrEvaluationNotSupported)
+	unsupported.On("GetResults", policy.Policy{expectedCertFileRule}).Return(collected, nil)
+	results, err = pluginManager.EvaluateEvidence(context.TODO(), map[string]policy.Provider{"mypvpvalidator": unsupported}, evidence, testSettings)
+	require.NoError(t, err)
+	require.Equal(t, []policy.PVPResult{collected}, results)
+
+	providerTestObj := new(policyProvider)
+	providerTestObj.On("GetResults", policy.Policy{expectedCertFileRule}).Return(collected, nil)
+	results, err = pluginManager.EvaluateEvidence(context.TODO(), map[string]policy.Provider{"mypvpvalidator": providerTestObj}, evidence, testSettings)
+	require.NoError(t, err)
+	require.Equal(t, []policy.PVPResult{collected}, results)
+
+	// Launched plugins only get the evidence of the collectors in their manifest
+	reports := evidence[0]
+	reports.Collector = "mycollector"
+	dump := policy.NewEvidence("pods.json", "application/json", []byte("{}"))
+	dump.Collector = "othercollector"
+	pluginManager.evidenceCollectors["mypvpvalidator"] = []string{"mycollector"}
+	selecting := &evaluator{policyProvider: new(policyProvider)}
+	selecting.On("Evaluate", policy.Policy{expectedCertFileRule}, []policy.Evidence{reports}).Return(evaluated, nil)
+	results, err = pluginManager.EvaluateEvidence(context.TODO(), map[string]policy.Provider{"mypvpvalidator": selecting}, []policy.Evidence{reports, dump}, testSettings)
+	require.NoError(t, err)
+	require.Equal(t, []policy.PVPResult{evaluated}, results)
+
+	// Launched plugins that do not declare collectors get the results themselves
+	pluginManager.evidenceCollectors["mypvpvalidator"] = nil
+	undeclared := &evaluator{policyProvider: new(policyProvider)}
+	undeclared.On("GetResults", policy.Policy{expectedCertFileRule}).Return(collected, nil)
+	results, err = pluginManager.EvaluateEvidence(context.TODO(), map[string]policy.Provider{"mypvpvalidator": undeclared}, []policy.Evidence{reports, dump}, testSettings)
+	require.NoError(t, err)
+	require.Equal(t, []policy.PVPResult{collected}, results)
+	undeclared.AssertNotCalled(t, "Evaluate", mock.Anything, mock.Anything)
+}
+
+func TestPluginManager_LaunchResultProcessors(t *testing.T) {
+	cfg := prepConfig(t)
+	cfg.ResultProcessors = []config.ResultProcessor{
+		{Name: ExcludeNamespacesProcessor, Options: map[string]string{"namespaces": "kube-system"}},
+		{Name: MergeObservationsProcessor},
+	}
+	pluginManager, err := NewPluginManager(cfg)
+	require.NoError(t, err)
+
+	pipeline, err := pluginManager.LaunchResultProcessors()
+	require.NoError(t, err)
+	results := []policy.PVPResult{
+		{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "etcd_cert_file", Subjects: []policy.Subject{{Title: "Namespace: kube-system, Name: etcd"}}}}},
+		{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "etcd_cert_file", Subjects: []policy.Subject{{Title: "node", ResourceID: "node"}}}}},
+	}
+	processed, err := pipeline.Process(results)
+	require.NoError(t, err)
+	require.Equal(t, []policy.PVPResult{
+		{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "etcd_cert_file", Subjects: []policy.Subject{{Title: "node", ResourceID: "node"}}}}},
+	}, processed)
+
+	cfg.ResultProcessors = []config.ResultProcessor{{Name: "dedupe"}}
+	pluginManager, err = NewPluginManager(cfg)
+	require.NoError(t, err)
+	_, err = pluginManager.LaunchResultProcessors()
+	require.EqualError(t, err, "unknown result processor \"dedupe\"")
+
+	cfg.ResultProcessors = []config.ResultProcessor{{Plugin: "myprocessor"}}
+	pluginManager, err = NewPluginManager(cfg)
+	require.NoError(t, err)
+	_, err = pluginManager.LaunchResultProcessors()
+	require.ErrorIs(t, err, plugin.ErrPluginsNotFound)
+
+	// Options for a processor plugin without configuration options are rejected
+	cfg.PluginDir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.PluginDir, "myprocessor"), nil, 0700))
+	installed := `{
+  "metadata": {"id": "myprocessor", "description": "Processor plugin", "version": "0.0.1", "types": ["processor"]},
+  "executablePath": "myprocessor",
+  "sha256": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+}`
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.PluginDir, "c2p-myprocessor-manifest.json"), []byte(installed), 0600))
+	cfg.ResultProcessors = []config.ResultProcessor{{Plugin: "myprocessor", Options: map[string]string{"namespaces": "kube-system"}}}
+	pluginManager, err = NewPluginManager(cfg)
+	require.NoError(t, err)
+	_, err = pluginManager.LaunchResultProcessors()
+	require.EqualError(t, err, "processor plugin myprocessor does not declare any configuration options")
+}
+
 // prepConfig returns an initialized C2PConfig to support the
 // unit tests.
 func prepConfig(t *testing.T) *config.C2PConfig {
@@ -206,3 +650,44 @@ func (p *policyProvider) GetResults(policyRules policy.Policy) (policy.PVPResult
 	args := p.Called(policyRules)
 	return args.Get(0).(policy.PVPResult), args.Error(1)
 }
+
+// remediator is a mocked implementation of policy.Remediator.
+type remediator struct {
+	mock.Mock
+}
+
+func (r *remediator) Configure(option map[string]string) error {
+	args := r.Called(option)
+	return args.Error(0)
+}
+
+func (r *remediator) Remediate(policyRules policy.Policy, result policy.PVPResult) ([]policy.Remediation, error) {
+	args := r.Called(policyRules, result)
+	return args.Get(0).([]policy.Remediation), args.Error(1)
+}
+
+// collector is a mocked implementation of policy.Collector.
+type collector struct {
+	mock.Mock
+}
+
+func (c *collector) Configure(option map[string]string) error {
+	args := c.Called(option)
+	return args.Error(0)
+}
+
+func (c *collector) Collect(policyRules policy.Policy) ([]policy.Evidence, error) {
+	args := c.Called(policyRules)
+	return args.Get(0).([]policy.Evidence), args.Error(1)
+}
+
+// evaluator is a mocked implementation of a policy.Provider
+// that implements policy.Evaluator.
+type evaluator struct {
+	*policyProvider
+}
+
+func (e *evaluator) Evaluate(policyRules policy.Policy, evidence []policy.Evidence) (policy.PVPResult, error) {
+	args := e.Called(policyRules, evidence)
+	return args.Get(0).(policy.PVPResult), args.Error(1)
+}
@@ -0,0 +1,276 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package framework
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-2"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// parameterAlternativesProp is the trestle property listing the comma-separated
+// values a rule parameter can be set to.
+const parameterAlternativesProp = "Parameter_Value_Alternatives"
+
+// ruleSetProps are the properties that define the rule sets
+// and are not passed as additional properties.
+var ruleSetProps = map[string]bool{
+	extensions.RuleIdProp:               true,
+	extensions.RuleDescriptionProp:      true,
+	extensions.CheckIdProp:              true,
+	extensions.CheckDescriptionProp:     true,
+	extensions.ParameterIdProp:          true,
+	extensions.ParameterDescriptionProp: true,
+	extensions.ParameterDefaultProp:     true,
+	parameterAlternativesProp:           true,
+}
+
+// ruleContext is the compliance context of a rule gathered
+// from a component.
+type ruleContext struct {
+	props        []policy.Property
+	checkProps   map[string][]policy.Property
+	alternatives []string
+	defaultValue string
+	controlIDs   map[string]struct{}
+}
+
+// componentContext is the context of the rules of a component and the values
+// of its multi-valued parameters by parameter ID.
+type componentContext struct {
+	component   *policy.Component
+	rules       map[string]*ruleContext
+	paramValues map[string][]string
+}
+
+// ruleIndex stores the context of the rules by component title. The checks of a rule
+// are declared by a validation component and the rule by the components it applies to.
+type ruleIndex struct {
+	components map[string]*componentContext
+	// targets are the titles of the components other than validation
+	// components that declare or implement a rule, by rule ID.
+	targets map[string][]string
+}
+
+// newRuleIndex indexes the rule context in the OSCAL Component Definitions.
+func newRuleIndex(compDefs []oscalTypes.ComponentDefinition) *ruleIndex {
+	index := &ruleIndex{
+		components: make(map[string]*componentContext),
+		targets:    make(map[string][]string),
+	}
+	for _, compDef := range compDefs {
+		if compDef.Components == nil {
+			continue
+		}
+		for _, component := range *compDef.Components {
+			index.indexComponent(component)
+		}
+	}
+	return index
+}
+
+func (r *ruleIndex) indexComponent(component oscalTypes.DefinedComponent) {
+	componentCtx, ok := r.components[component.Title]
+	if !ok {
+		componentCtx = &componentContext{
+			rules:       make(map[string]*ruleContext),
+			paramValues: make(map[string][]string),
+		}
+		r.components[component.Title] = componentCtx
+	}
+	if component.Type != "validation" && componentCtx.component == nil {
+		componentCtx.component = &policy.Component{
+			UUID:        component.UUID,
+			Title:       component.Title,
+			Type:        component.Type,
+			Description: component.Description,
+		}
+	}
+
+	if component.Props != nil {
+		// Each rule set is linked by a group id in the property remarks
+		byRemarks := make(map[string][]oscalTypes.Property)
+		var remarks []string
+		for _, prop := range *component.Props {
+			if prop.Remarks == "" {
+				if componentCtx.component != nil {
+					componentCtx.component.Props = append(componentCtx.component.Props, policy.Property{Name: prop.Name, Value: prop.Value})
+				}
+				continue
+			}
+			if _, ok := byRemarks[prop.Remarks]; !ok {
+				remarks = append(remarks, prop.Remarks)
+			}
+			byRemarks[prop.Remarks] = append(byRemarks[prop.Remarks], prop)
+		}
+
+		for _, remark := range remarks {
+			group := byRemarks[remark]
+			ruleIDProp, ok := extensions.GetTrestleProp(extensions.RuleIdProp, group)
+			if !ok {
+				continue
+			}
+			rule := r.rule(component, ruleIDProp.Value)
+			checkIDProp, isCheck := extensions.GetTrestleProp(extensions.CheckIdProp, group)
+			var extraProps []policy.Property
+			for _, prop := range group {
+				switch {
+				case prop.Name == extensions.ParameterDefaultProp:
+					rule.defaultValue = prop.Value
+				case prop.Name == parameterAlternativesProp:
+					rule.alternatives = splitValues(prop.Value)
+				case !ruleSetProps[prop.Name]:
+					extraProps = append(extraProps, policy.Property{Name: prop.Name, Value: prop.Value})
+				}
+			}
+
+			// The checks are declared by the validation component and the rules
+			// by the components they apply to.
+			if isCheck {
+				if len(extraProps) > 0 {
+					rule.checkProps[checkIDProp.Value] = append(rule.checkProps[checkIDProp.Value], extraProps...)
+				}
+				continue
+			}
+			rule.props = append(rule.props, extraProps...)
+		}
+	}
+
+	if component.ControlImplementations == nil {
+		return
+	}
+	for _, implementation := range *component.ControlImplementations {
+		componentCtx.indexParameters(implementation.SetParameters)
+		for _, requirement := range implementation.ImplementedRequirements {
+			componentCtx.indexParameters(requirement.SetParameters)
+			var ruleProps []oscalTypes.Property
+			if requirement.Props != nil {
+				ruleProps = append(ruleProps, *requirement.Props...)
+			}
+			if requirement.Statements != nil {
+				for _, statement := range *requirement.Statements {
+					if statement.Props != nil {
+						ruleProps = append(ruleProps, *statement.Props...)
+					}
+				}
+			}
+			for _, prop := range extensions.FindAllProps(ruleProps, extensions.WithName(extensions.RuleIdProp)) {
+				r.rule(component, prop.Value).controlIDs[requirement.ControlId] = struct{}{}
+			}
+		}
+	}
+}
+
+// indexParameters stores the values of the multi-valued parameters. Parameters
+// set to a single value are applied to the rules with the compliance settings.
+func (c *componentContext) indexParameters(setParameters *[]oscalTypes.SetParameter) {
+	if setParameters == nil {
+		return
+	}
+	for _, setParameter := range *setParameters {
+		if len(setParameter.Values) < 2 {
+			continue
+		}
+		if _, ok := c.paramValues[setParameter.ParamId]; !ok {
+			c.paramValues[setParameter.ParamId] = setParameter.Values
+		}
+	}
+}
+
+// rule returns the context of the rule in the component, which must be indexed.
+func (r *ruleIndex) rule(component oscalTypes.DefinedComponent, ruleID string) *ruleContext {
+	componentCtx := r.components[component.Title]
+	rule, ok := componentCtx.rules[ruleID]
+	if !ok {
+		rule = &ruleContext{
+			checkProps: make(map[string][]policy.Property),
+			controlIDs: make(map[string]struct{}),
+		}
+		componentCtx.rules[ruleID] = rule
+		if component.Type != "validation" && !slices.Contains(r.targets[ruleID], component.Title) {
+			r.targets[ruleID] = append(r.targets[ruleID], component.Title)
+		}
+	}
+	return rule
+}
+
+// Policy returns the Policy for the rule sets of the validation component with the
+// given title with the indexed compliance context.
+//
+// The check properties are those of the validation component. The rest of the context is
+// taken from the component the rule applies to. When several components declare or implement
+// the rule, the context is ambiguous and only the controls of all the components are set.
+func (r *ruleIndex) Policy(componentTitle string, ruleSets []extensions.RuleSet) policy.Policy {
+	var p policy.Policy
+	for _, ruleSet := range ruleSets {
+		p = append(p, r.ruleSet(componentTitle, ruleSet))
+	}
+	return p
+}
+
+func (r *ruleIndex) ruleSet(componentTitle string, ruleSet extensions.RuleSet) policy.RuleSet {
+	policyRuleSet := policy.RuleSet{RuleSet: ruleSet}
+	ruleID := ruleSet.Rule.ID
+
+	if validation, ok := r.components[componentTitle]; ok {
+		if rule, ok := validation.rules[ruleID]; ok {
+			for _, check := range ruleSet.Checks {
+				if props, ok := rule.checkProps[check.ID]; ok {
+					if policyRuleSet.CheckProps == nil {
+						policyRuleSet.CheckProps = make(map[string][]policy.Property)
+					}
+					policyRuleSet.CheckProps[check.ID] = props
+				}
+			}
+		}
+	}
+
+	targets := r.targets[ruleID]
+	for _, title := range targets {
+		for controlID := range r.components[title].rules[ruleID].controlIDs {
+			if !slices.Contains(policyRuleSet.ControlIDs, controlID) {
+				policyRuleSet.ControlIDs = append(policyRuleSet.ControlIDs, controlID)
+			}
+		}
+	}
+	sort.Strings(policyRuleSet.ControlIDs)
+	if len(targets) != 1 {
+		return policyRuleSet
+	}
+
+	target := r.components[targets[0]]
+	rule := target.rules[ruleID]
+	policyRuleSet.Props = rule.props
+	policyRuleSet.Component = target.component
+	if parameter := ruleSet.Rule.Parameter; parameter != nil {
+		policyRuleSet.ParameterDetails.Alternatives = rule.alternatives
+		// A multi-valued parameter is used unless the compliance
+		// settings selected a single value for it.
+		values, ok := target.paramValues[parameter.ID]
+		if ok && (parameter.Value == rule.defaultValue || parameter.Value == values[0]) {
+			parameterCopy := *parameter
+			parameterCopy.Value = values[0]
+			policyRuleSet.Rule.Parameter = &parameterCopy
+			policyRuleSet.ParameterDetails.Values = values
+		}
+	}
+	return policyRuleSet
+}
+
+func splitValues(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
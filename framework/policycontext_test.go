@@ -0,0 +1,161 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package framework
+
+import (
+	"testing"
+
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-2"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func trestleProp(name, value, remarks string) oscalTypes.Property {
+	return oscalTypes.Property{Name: name, Value: value, Remarks: remarks, Ns: extensions.TrestleNameSpace}
+}
+
+func TestRuleIndex_Policy(t *testing.T) {
+	compDef := oscalTypes.ComponentDefinition{
+		Components: &[]oscalTypes.DefinedComponent{
+			{
+				UUID:        "c8106bc8-5174-4e86-91a4-52f2fe0ed027",
+				Title:       "TestKubernetes",
+				Type:        "service",
+				Description: "Kubernetes cluster",
+				Props: &[]oscalTypes.Property{
+					{Name: "cluster-type", Value: "openshift"},
+					trestleProp(extensions.RuleIdProp, "allowed_registries", "rule_set_00"),
+					trestleProp(extensions.RuleDescriptionProp, "Only allowed registries are used", "rule_set_00"),
+					trestleProp(extensions.ParameterIdProp, "registries", "rule_set_00"),
+					trestleProp(extensions.ParameterDescriptionProp, "Allowed registries", "rule_set_00"),
+					trestleProp(extensions.ParameterDefaultProp, "quay.io", "rule_set_00"),
+					trestleProp(parameterAlternativesProp, "quay.io, docker.io, ghcr.io", "rule_set_00"),
+					trestleProp("Severity", "high", "rule_set_00"),
+					trestleProp(extensions.RuleIdProp, "etcd_cert_file", "rule_set_01"),
+				},
+				ControlImplementations: &[]oscalTypes.ControlImplementationSet{
+					{
+						ImplementedRequirements: []oscalTypes.ImplementedRequirementControlImplementation{
+							{
+								ControlId: "cm-7",
+								Props:     &[]oscalTypes.Property{trestleProp(extensions.RuleIdProp, "allowed_registries", "")},
+								SetParameters: &[]oscalTypes.SetParameter{
+									{ParamId: "registries", Values: []string{"quay.io", "ghcr.io"}},
+								},
+							},
+							{
+								ControlId: "cm-2",
+								Statements: &[]oscalTypes.ControlStatementImplementation{
+									{
+										StatementId: "cm-2_smt.a",
+										Props:       &[]oscalTypes.Property{trestleProp(extensions.RuleIdProp, "allowed_registries", "")},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Title: "OtherCluster",
+				Type:  "service",
+				Props: &[]oscalTypes.Property{
+					trestleProp(extensions.RuleIdProp, "etcd_cert_file", "rule_set_00"),
+					trestleProp("Severity", "low", "rule_set_00"),
+				},
+				ControlImplementations: &[]oscalTypes.ControlImplementationSet{
+					{
+						ImplementedRequirements: []oscalTypes.ImplementedRequirementControlImplementation{
+							{
+								ControlId: "ac-1",
+								Props:     &[]oscalTypes.Property{trestleProp(extensions.RuleIdProp, "etcd_cert_file", "")},
+								SetParameters: &[]oscalTypes.SetParameter{
+									{ParamId: "registries", Values: []string{"docker.io", "ghcr.io"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Title: "MyPVPValidator",
+				Type:  "validation",
+				Props: &[]oscalTypes.Property{
+					trestleProp(extensions.RuleIdProp, "allowed_registries", "rule_set_10"),
+					trestleProp(extensions.CheckIdProp, "check_registries", "rule_set_10"),
+					trestleProp("Check_Type", "admission", "rule_set_10"),
+				},
+			},
+			{
+				Title: "OtherPVPValidator",
+				Type:  "validation",
+				Props: &[]oscalTypes.Property{
+					trestleProp(extensions.RuleIdProp, "allowed_registries", "rule_set_10"),
+					trestleProp(extensions.CheckIdProp, "check_registries", "rule_set_10"),
+					trestleProp("Check_Type", "audit", "rule_set_10"),
+				},
+			},
+		},
+	}
+	index := newRuleIndex([]oscalTypes.ComponentDefinition{compDef})
+
+	registriesRule := extensions.RuleSet{
+		Rule: extensions.Rule{
+			ID:          "allowed_registries",
+			Description: "Only allowed registries are used",
+			Parameter: &extensions.Parameter{
+				ID:          "registries",
+				Description: "Allowed registries",
+				Value:       "quay.io",
+			},
+		},
+		Checks: []extensions.Check{{ID: "check_registries"}},
+	}
+	unknownRule := extensions.RuleSet{Rule: extensions.Rule{ID: "unknown"}}
+
+	component := &policy.Component{
+		UUID:        "c8106bc8-5174-4e86-91a4-52f2fe0ed027",
+		Title:       "TestKubernetes",
+		Type:        "service",
+		Description: "Kubernetes cluster",
+		Props:       []policy.Property{{Name: "cluster-type", Value: "openshift"}},
+	}
+	expectedRule := policy.RuleSet{
+		RuleSet:    registriesRule,
+		Props:      []policy.Property{{Name: "Severity", Value: "high"}},
+		CheckProps: map[string][]policy.Property{"check_registries": {{Name: "Check_Type", Value: "admission"}}},
+		ParameterDetails: policy.ParameterDetails{
+			Values:       []string{"quay.io", "ghcr.io"},
+			Alternatives: []string{"quay.io", "docker.io", "ghcr.io"},
+		},
+		ControlIDs: []string{"cm-2", "cm-7"},
+		Component:  component,
+	}
+	require.Equal(t, policy.Policy{expectedRule, {RuleSet: unknownRule}}, index.Policy("MyPVPValidator", []extensions.RuleSet{registriesRule, unknownRule}))
+
+	// The check properties are those of the validation component
+	p := index.Policy("OtherPVPValidator", []extensions.RuleSet{registriesRule})
+	require.Len(t, p, 1)
+	require.Equal(t, map[string][]policy.Property{"check_registries": {{Name: "Check_Type", Value: "audit"}}}, p[0].CheckProps)
+	require.Equal(t, component, p[0].Component)
+
+	// The context of a rule of several components only has their controls
+	certFileRule := extensions.RuleSet{Rule: extensions.Rule{ID: "etcd_cert_file"}}
+	p = index.Policy("MyPVPValidator", []extensions.RuleSet{certFileRule})
+	require.Equal(t, policy.Policy{{RuleSet: certFileRule, ControlIDs: []string{"ac-1"}}}, p)
+
+	// A single value selected by the compliance settings
+	// is used instead of the multi-valued parameter.
+	selectedRule := registriesRule
+	selectedRule.Rule.Parameter = &extensions.Parameter{ID: "registries", Value: "docker.io"}
+	p = index.Policy("MyPVPValidator", []extensions.RuleSet{selectedRule})
+	require.Len(t, p, 1)
+	require.Equal(t, "docker.io", p[0].Rule.Parameter.Value)
+	require.Nil(t, p[0].ParameterDetails.Values)
+	require.Equal(t, []string{"quay.io", "docker.io", "ghcr.io"}, p[0].ParameterDetails.Alternatives)
+}
@@ -0,0 +1,371 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package framework
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	sigyaml "sigs.k8s.io/yaml"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Built-in result processors.
+const (
+	// ExcludeNamespacesProcessor drops the subjects in the namespaces of the
+	// comma-separated `namespaces` option.
+	ExcludeNamespacesProcessor = "exclude-namespaces"
+	// MapChecksProcessor maps the check names of the policy engines to OSCAL check IDs
+	// with the mapping in the YAML `file` option.
+	MapChecksProcessor = "map-checks"
+	// LabelsProcessor adds the properties in the YAML lookup `file` option to the subjects
+	// by namespace, or by resource ID when the `key` option is `resource-id`.
+	LabelsProcessor = "labels"
+	// MergeObservationsProcessor merges the results of all the plugins and the
+	// observations for the same check.
+	MergeObservationsProcessor = "merge-observations"
+)
+
+// ResultPipeline is the ordered list of result processors that transform the
+// results of the PVPs before they are reported.
+type ResultPipeline struct {
+	steps []resultStep
+}
+
+// resultStep is a named processor of the ResultPipeline.
+type resultStep struct {
+	name      string
+	processor policy.Processor
+}
+
+// Process passes the results through each processor of the pipeline in order.
+// A nil pipeline returns the results unchanged.
+func (p *ResultPipeline) Process(results []policy.PVPResult) ([]policy.PVPResult, error) {
+	if p == nil {
+		return results, nil
+	}
+	for _, step := range p.steps {
+		processed, err := step.processor.Process(results)
+		if err != nil {
+			return nil, fmt.Errorf("result processor %s: %w", step.name, err)
+		}
+		results = processed
+	}
+	return results, nil
+}
+
+var builtinProcessors = map[string]func() policy.Processor{
+	ExcludeNamespacesProcessor: func() policy.Processor { return &excludeNamespaces{} },
+	MapChecksProcessor:         func() policy.Processor { return &mapChecks{} },
+	LabelsProcessor:            func() policy.Processor { return &labels{} },
+	MergeObservationsProcessor: func() policy.Processor { return &mergeObservations{} },
+}
+
+// newBuiltinProcessor returns the built-in processor with the given name
+// configured with the options.
+func newBuiltinProcessor(name string, options map[string]string) (policy.Processor, error) {
+	newProcessor, ok := builtinProcessors[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown result processor %q", name)
+	}
+	processor := newProcessor()
+	if err := processor.Configure(options); err != nil {
+		return nil, fmt.Errorf("failed to configure result processor %s: %w", name, err)
+	}
+	return processor, nil
+}
+
+// checkOptions returns an error for the options that are not in allowed.
+func checkOptions(options map[string]string, allowed ...string) error {
+	for option := range options {
+		if !contains(allowed, option) {
+			return fmt.Errorf("unknown option %q", option)
+		}
+	}
+	return nil
+}
+
+// loadLookupFile loads the YAML or JSON lookup file of the `file` option.
+func loadLookupFile(options map[string]string, out interface{}) error {
+	path := options["file"]
+	if path == "" {
+		return fmt.Errorf("option %q is required", "file")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := sigyaml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid lookup file %s: %w", path, err)
+	}
+	return nil
+}
+
+// titleNamespace matches the namespace in the subject titles of the Kubernetes plugins,
+// such as "ApiVersion: v1, Kind: Pod, Namespace: default, Name: nginx".
+var titleNamespace = regexp.MustCompile(`(?:^|, )Namespace: ([^,]*)(?:,|$)`)
+
+// subjectNamespace returns the namespace from the `namespace` property of the subject
+// or from its title.
+func subjectNamespace(subject policy.Subject) string {
+	for _, prop := range subject.Props {
+		if prop.Name == "namespace" {
+			return prop.Value
+		}
+	}
+	if match := titleNamespace.FindStringSubmatch(subject.Title); match != nil {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// excludeNamespaces drops the subjects in the excluded namespaces.
+type excludeNamespaces struct {
+	namespaces map[string]bool
+}
+
+func (e *excludeNamespaces) Configure(options map[string]string) error {
+	if err := checkOptions(options, "namespaces"); err != nil {
+		return err
+	}
+	e.namespaces = make(map[string]bool)
+	for _, namespace := range strings.Split(options["namespaces"], ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			e.namespaces[namespace] = true
+		}
+	}
+	if len(e.namespaces) == 0 {
+		return fmt.Errorf("option %q is required", "namespaces")
+	}
+	return nil
+}
+
+func (e *excludeNamespaces) Process(results []policy.PVPResult) ([]policy.PVPResult, error) {
+	for i := range results {
+		observations := make([]policy.ObservationByCheck, 0, len(results[i].ObservationsByCheck))
+		for _, observation := range results[i].ObservationsByCheck {
+			subjects := make([]policy.Subject, 0, len(observation.Subjects))
+			for _, subject := range observation.Subjects {
+				if !e.namespaces[subjectNamespace(subject)] {
+					subjects = append(subjects, subject)
+				}
+			}
+			observation.Subjects = subjects
+			observations = append(observations, observation)
+		}
+		results[i].ObservationsByCheck = observations
+	}
+	return results, nil
+}
+
+// mapChecks maps the check names of the policy engines to check IDs.
+type mapChecks struct {
+	checkIDs map[string]string
+}
+
+func (m *mapChecks) Configure(options map[string]string) error {
+	if err := checkOptions(options, "file"); err != nil {
+		return err
+	}
+	return loadLookupFile(options, &m.checkIDs)
+}
+
+func (m *mapChecks) Process(results []policy.PVPResult) ([]policy.PVPResult, error) {
+	for i := range results {
+		for j, observation := range results[i].ObservationsByCheck {
+			if checkID, ok := m.checkIDs[observation.CheckID]; ok {
+				results[i].ObservationsByCheck[j].CheckID = checkID
+			}
+		}
+	}
+	return results, nil
+}
+
+const (
+	labelsByNamespace  = "namespace"
+	labelsByResourceID = "resource-id"
+)
+
+// labels adds properties, such as owner or team labels, from
+// a lookup file to the subjects.
+type labels struct {
+	key    string
+	lookup map[string]map[string]string
+}
+
+func (l *labels) Configure(options map[string]string) error {
+	if err := checkOptions(options, "file", "key"); err != nil {
+		return err
+	}
+	l.key = options["key"]
+	switch l.key {
+	case "":
+		l.key = labelsByNamespace
+	case labelsByNamespace, labelsByResourceID:
+	default:
+		return fmt.Errorf("invalid key %q: must be one of %s, %s", l.key, labelsByNamespace, labelsByResourceID)
+	}
+	return loadLookupFile(options, &l.lookup)
+}
+
+func (l *labels) Process(results []policy.PVPResult) ([]policy.PVPResult, error) {
+	for i := range results {
+		for j := range results[i].ObservationsByCheck {
+			subjects := results[i].ObservationsByCheck[j].Subjects
+			for k, subject := range subjects {
+				key := subject.ResourceID
+				if l.key == labelsByNamespace {
+					key = subjectNamespace(subject)
+				}
+				subjects[k].Props = addProps(subject.Props, l.lookup[key])
+			}
+		}
+	}
+	return results, nil
+}
+
+// addProps adds the labels that are not set yet to the properties, sorted by name.
+func addProps(props []policy.Property, labels map[string]string) []policy.Property {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// Subjects can share the properties, so they are not appended in place
+	props = props[:len(props):len(props)]
+	for _, name := range names {
+		if !hasProp(props, name) {
+			props = append(props, policy.Property{Name: name, Value: labels[name]})
+		}
+	}
+	return props
+}
+
+func hasProp(props []policy.Property, name string) bool {
+	for _, prop := range props {
+		if prop.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeObservations merges the results of all the plugins into a single result
+// with one observation by check.
+type mergeObservations struct{}
+
+func (m *mergeObservations) Configure(options map[string]string) error {
+	return checkOptions(options)
+}
+
+func (m *mergeObservations) Process(results []policy.PVPResult) ([]policy.PVPResult, error) {
+	if len(results) == 0 {
+		return results, nil
+	}
+	var merged policy.PVPResult
+	observationIndex := make(map[string]int)
+	inventoryItems := make(map[string]bool)
+	for _, result := range results {
+		for _, observation := range result.ObservationsByCheck {
+			i, ok := observationIndex[observation.CheckID]
+			if !ok {
+				observationIndex[observation.CheckID] = len(merged.ObservationsByCheck)
+				merged.ObservationsByCheck = append(merged.ObservationsByCheck, observation)
+				continue
+			}
+			mergeObservation(&merged.ObservationsByCheck[i], observation)
+		}
+		merged.Links = append(merged.Links, result.Links...)
+		for _, item := range result.InventoryItems {
+			if !inventoryItems[item.ID] {
+				inventoryItems[item.ID] = true
+				merged.InventoryItems = append(merged.InventoryItems, item)
+			}
+		}
+	}
+	return []policy.PVPResult{merged}, nil
+}
+
+// mergeObservation merges the subjects, methods, evidence and properties of the other
+// observation of the same check. For subjects with the same resource ID, the subject with
+// the most severe result is kept. Subjects without a resource ID are all kept.
+func mergeObservation(observation *policy.ObservationByCheck, other policy.ObservationByCheck) {
+	subjectIndex := make(map[string]int)
+	for i, subject := range observation.Subjects {
+		if subject.ResourceID != "" {
+			subjectIndex[subject.ResourceID] = i
+		}
+	}
+	for _, subject := range other.Subjects {
+		i, ok := subjectIndex[subject.ResourceID]
+		if !ok {
+			if subject.ResourceID != "" {
+				subjectIndex[subject.ResourceID] = len(observation.Subjects)
+			}
+			observation.Subjects = append(observation.Subjects, subject)
+		} else if resultSeverity(subject.Result) > resultSeverity(observation.Subjects[i].Result) {
+			observation.Subjects[i] = subject
+		}
+	}
+	for _, method := range other.Methods {
+		if !contains(observation.Methods, method) {
+			observation.Methods = append(observation.Methods, method)
+		}
+	}
+	for _, evidence := range other.RelevantEvidences {
+		if !hasEvidence(observation.RelevantEvidences, evidence.Href) {
+			observation.RelevantEvidences = append(observation.RelevantEvidences, evidence)
+		}
+	}
+	for _, prop := range other.Props {
+		if !hasProp(observation.Props, prop.Name) {
+			observation.Props = append(observation.Props, prop)
+		}
+	}
+	if other.Collected.After(observation.Collected) {
+		observation.Collected = other.Collected
+	}
+}
+
+// resultSeverity orders the results from the least to the most severe.
+func resultSeverity(result policy.Result) int {
+	switch result {
+	case policy.ResultFail:
+		return 5
+	case policy.ResultError:
+		return 4
+	case policy.ResultWarning:
+		return 3
+	case policy.ResultPass:
+		return 2
+	case policy.ResultSkipped:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func hasEvidence(links []policy.Link, href string) bool {
+	for _, link := range links {
+		if link.Href == href {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
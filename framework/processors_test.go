@@ -0,0 +1,202 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package framework
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func podSubject(namespace, name string, result policy.Result) policy.Subject {
+	return policy.Subject{
+		Title:      "ApiVersion: v1, Kind: Pod, Namespace: " + namespace + ", Name: " + name,
+		ResourceID: namespace + "/" + name,
+		Result:     result,
+	}
+}
+
+func writeLookupFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "lookup.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestExcludeNamespaces(t *testing.T) {
+	processor, err := newBuiltinProcessor(ExcludeNamespacesProcessor, map[string]string{"namespaces": "kube-system, openshift-monitoring"})
+	require.NoError(t, err)
+
+	inPropNamespace := podSubject("payments", "api", policy.ResultPass)
+	inPropNamespace.Props = []policy.Property{{Name: "namespace", Value: "kube-system"}}
+	results := []policy.PVPResult{
+		{
+			ObservationsByCheck: []policy.ObservationByCheck{
+				{
+					CheckID: "check",
+					Subjects: []policy.Subject{
+						podSubject("kube-system", "coredns", policy.ResultFail),
+						podSubject("payments", "web", policy.ResultFail),
+						inPropNamespace,
+						{Title: "cluster", ResourceID: "cluster", Result: policy.ResultPass},
+					},
+				},
+			},
+		},
+	}
+	processed, err := processor.Process(results)
+	require.NoError(t, err)
+	require.Equal(t, []policy.Subject{
+		podSubject("payments", "web", policy.ResultFail),
+		{Title: "cluster", ResourceID: "cluster", Result: policy.ResultPass},
+	}, processed[0].ObservationsByCheck[0].Subjects)
+
+	_, err = newBuiltinProcessor(ExcludeNamespacesProcessor, nil)
+	require.EqualError(t, err, "failed to configure result processor exclude-namespaces: option \"namespaces\" is required")
+	_, err = newBuiltinProcessor(ExcludeNamespacesProcessor, map[string]string{"namespace": "kube-system"})
+	require.EqualError(t, err, "failed to configure result processor exclude-namespaces: unknown option \"namespace\"")
+}
+
+func TestMapChecks(t *testing.T) {
+	file := writeLookupFile(t, "disallow-latest-tag: container_image_tag_pinned\n")
+	processor, err := newBuiltinProcessor(MapChecksProcessor, map[string]string{"file": file})
+	require.NoError(t, err)
+
+	results := []policy.PVPResult{
+		{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "disallow-latest-tag"}, {CheckID: "replica-limits"}}},
+	}
+	processed, err := processor.Process(results)
+	require.NoError(t, err)
+	require.Equal(t, "container_image_tag_pinned", processed[0].ObservationsByCheck[0].CheckID)
+	require.Equal(t, "replica-limits", processed[0].ObservationsByCheck[1].CheckID)
+
+	_, err = newBuiltinProcessor(MapChecksProcessor, nil)
+	require.EqualError(t, err, "failed to configure result processor map-checks: option \"file\" is required")
+}
+
+func TestLabels(t *testing.T) {
+	file := writeLookupFile(t, "payments:\n  team: payments\n  owner: alice\n")
+	processor, err := newBuiltinProcessor(LabelsProcessor, map[string]string{"file": file})
+	require.NoError(t, err)
+
+	owned := podSubject("payments", "web", policy.ResultFail)
+	owned.Props = []policy.Property{{Name: "owner", Value: "bob"}}
+	results := []policy.PVPResult{
+		{
+			ObservationsByCheck: []policy.ObservationByCheck{
+				{CheckID: "check", Subjects: []policy.Subject{podSubject("payments", "api", policy.ResultPass), owned, podSubject("default", "web", policy.ResultPass)}},
+			},
+		},
+	}
+	processed, err := processor.Process(results)
+	require.NoError(t, err)
+	subjects := processed[0].ObservationsByCheck[0].Subjects
+	require.Equal(t, []policy.Property{{Name: "owner", Value: "alice"}, {Name: "team", Value: "payments"}}, subjects[0].Props)
+	require.Equal(t, []policy.Property{{Name: "owner", Value: "bob"}, {Name: "team", Value: "payments"}}, subjects[1].Props)
+	require.Empty(t, subjects[2].Props)
+
+	file = writeLookupFile(t, "default/web:\n  team: web\n")
+	processor, err = newBuiltinProcessor(LabelsProcessor, map[string]string{"file": file, "key": "resource-id"})
+	require.NoError(t, err)
+	processed, err = processor.Process(processed)
+	require.NoError(t, err)
+	require.Equal(t, []policy.Property{{Name: "team", Value: "web"}}, processed[0].ObservationsByCheck[0].Subjects[2].Props)
+
+	_, err = newBuiltinProcessor(LabelsProcessor, map[string]string{"file": file, "key": "name"})
+	require.EqualError(t, err, "failed to configure result processor labels: invalid key \"name\": must be one of namespace, resource-id")
+}
+
+func TestMergeObservations(t *testing.T) {
+	processor, err := newBuiltinProcessor(MergeObservationsProcessor, nil)
+	require.NoError(t, err)
+
+	earlier := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	results := []policy.PVPResult{
+		{
+			ObservationsByCheck: []policy.ObservationByCheck{
+				{
+					CheckID:           "check",
+					Methods:           []string{"TEST-AUTOMATED"},
+					Collected:         earlier,
+					Subjects:          []policy.Subject{podSubject("payments", "api", policy.ResultPass), podSubject("payments", "web", policy.ResultPass)},
+					RelevantEvidences: []policy.Link{{Href: "evidence/kyverno/policyreports.yaml"}},
+				},
+			},
+			InventoryItems: []policy.InventoryItem{{ID: "cluster"}},
+		},
+		{
+			ObservationsByCheck: []policy.ObservationByCheck{
+				{
+					CheckID:           "check",
+					Methods:           []string{"TEST-AUTOMATED"},
+					Collected:         later,
+					Subjects:          []policy.Subject{podSubject("payments", "web", policy.ResultFail), podSubject("default", "web", policy.ResultPass)},
+					RelevantEvidences: []policy.Link{{Href: "evidence/kyverno/policyreports.yaml"}, {Href: "evidence/vap/manifests.yaml"}},
+					Props:             []policy.Property{{Name: "evaluation-mode", Value: "offline"}},
+				},
+				{CheckID: "other"},
+			},
+			InventoryItems: []policy.InventoryItem{{ID: "cluster"}, {ID: "node"}},
+		},
+	}
+	processed, err := processor.Process(results)
+	require.NoError(t, err)
+	require.Equal(t, []policy.PVPResult{
+		{
+			ObservationsByCheck: []policy.ObservationByCheck{
+				{
+					CheckID:   "check",
+					Methods:   []string{"TEST-AUTOMATED"},
+					Collected: later,
+					Subjects: []policy.Subject{
+						podSubject("payments", "api", policy.ResultPass),
+						podSubject("payments", "web", policy.ResultFail),
+						podSubject("default", "web", policy.ResultPass),
+					},
+					RelevantEvidences: []policy.Link{{Href: "evidence/kyverno/policyreports.yaml"}, {Href: "evidence/vap/manifests.yaml"}},
+					Props:             []policy.Property{{Name: "evaluation-mode", Value: "offline"}},
+				},
+				{CheckID: "other"},
+			},
+			InventoryItems: []policy.InventoryItem{{ID: "cluster"}, {ID: "node"}},
+		},
+	}, processed)
+
+	// Subjects without a resource ID are not deduplicated
+	processed, err = processor.Process([]policy.PVPResult{
+		{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "check", Subjects: []policy.Subject{{Title: "node-1"}, {Title: "node-2"}}}}},
+		{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "check", Subjects: []policy.Subject{{Title: "node-3"}}}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []policy.Subject{{Title: "node-1"}, {Title: "node-2"}, {Title: "node-3"}}, processed[0].ObservationsByCheck[0].Subjects)
+}
+
+func TestResultPipeline_Process(t *testing.T) {
+	results := []policy.PVPResult{{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "check"}}}}
+
+	var pipeline *ResultPipeline
+	processed, err := pipeline.Process(results)
+	require.NoError(t, err)
+	require.Equal(t, results, processed)
+
+	file := writeLookupFile(t, "check: mapped\n")
+	mapped, err := newBuiltinProcessor(MapChecksProcessor, map[string]string{"file": file})
+	require.NoError(t, err)
+	merged, err := newBuiltinProcessor(MergeObservationsProcessor, nil)
+	require.NoError(t, err)
+	pipeline = &ResultPipeline{steps: []resultStep{{name: MapChecksProcessor, processor: mapped}, {name: MergeObservationsProcessor, processor: merged}}}
+	processed, err = pipeline.Process(append(results, results...))
+	require.NoError(t, err)
+	require.Equal(t, []policy.PVPResult{{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "mapped"}}}}, processed)
+
+	_, err = newBuiltinProcessor("unknown", nil)
+	require.EqualError(t, err, "unknown result processor \"unknown\"")
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/defenseunicorns/go-oscal/src/pkg/uuid"
@@ -21,9 +22,13 @@ import (
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/framework/config"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
+// pluginSignerProp is the metadata property recording the signer of a plugin manifest.
+const pluginSignerProp = "plugin-signer"
+
 type Reporter struct {
 	log        hclog.Logger
 	rulesStore rules.Store
@@ -46,7 +51,9 @@ func NewReporter(cfg *config.C2PConfig) (*Reporter, error) {
 }
 
 type generateOpts struct {
-	title string
+	title     string
+	manifests plugin.Manifests
+	waivers   []Waiver
 }
 
 func (g *generateOpts) defaults() {
@@ -63,6 +70,49 @@ func WithTitle(title string) GenerateOption {
 	}
 }
 
+// WithPluginManifests is a GenerateOption that records the signers of the plugin manifests
+// that produced the results in the AssessmentResults metadata. Plugins without a
+// verified signature are not recorded.
+func WithPluginManifests(manifests plugin.Manifests) GenerateOption {
+	return func(opts *generateOpts) {
+		opts.manifests = manifests
+	}
+}
+
+// WithWaivers is a GenerateOption that applies approved exceptions to the results. The non-passing
+// subjects selected by a waiver are marked as waived, and findings where all the non-passing subjects
+// have an active waiver are satisfied. Subjects of expired waivers are flagged and still not satisfied.
+func WithWaivers(waivers []Waiver) GenerateOption {
+	return func(opts *generateOpts) {
+		opts.waivers = waivers
+	}
+}
+
+// pluginSignerProps returns a metadata property for each plugin manifest with a verified signature.
+// The property class is the plugin ID and the remarks hold the key fingerprint.
+func pluginSignerProps(manifests plugin.Manifests) []oscalTypes.Property {
+	ids := make([]string, 0, len(manifests))
+	for id, manifest := range manifests {
+		if manifest.Signature != nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	props := make([]oscalTypes.Property, 0, len(ids))
+	for _, id := range ids {
+		signature := manifests[id].Signature
+		props = append(props, oscalTypes.Property{
+			Name:    pluginSignerProp,
+			Value:   signature.Signer,
+			Class:   id,
+			Remarks: signature.KeyID,
+			Ns:      extensions.TrestleNameSpace,
+		})
+	}
+	return props
+}
+
 // getFindingForTarget returns an existing finding that matches the targetId if one exists in findings
 func (r *Reporter) getFindingForTarget(findings []oscalTypes.Finding, targetId string) *oscalTypes.Finding {
 
@@ -160,6 +210,13 @@ func (r *Reporter) toOscalObservation(observationByCheck policy.ObservationByChe
 				Ns:    extensions.TrestleNameSpace,
 			},
 		}
+		for _, subjectProp := range subject.Props {
+			props = append(props, oscalTypes.Property{
+				Name:  subjectProp.Name,
+				Value: subjectProp.Value,
+				Ns:    extensions.TrestleNameSpace,
+			})
+		}
 
 		s := oscalTypes.SubjectReference{
 			SubjectUuid: uuid.NewUUID(),
@@ -176,6 +233,7 @@ func (r *Reporter) toOscalObservation(observationByCheck policy.ObservationByChe
 			oscalRelEv := oscalTypes.RelevantEvidence{
 				Href:        relEv.Href,
 				Description: relEv.Description,
+				Props:       toOscalProps(relEv.Props),
 			}
 			relevantEvidences = append(relevantEvidences, oscalRelEv)
 		}
@@ -203,6 +261,101 @@ func (r *Reporter) toOscalObservation(observationByCheck policy.ObservationByChe
 	return oscalObservation
 }
 
+// toOscalInventoryItems converts the PVP InventoryItems of all results to OSCAL InventoryItems
+// and returns the UUID assigned to each InventoryItem ID of each result. Items with the same ID,
+// such as a host reported by several plugins, are merged into one InventoryItem.
+func (r *Reporter) toOscalInventoryItems(results []policy.PVPResult) ([]oscalTypes.InventoryItem, []map[string]string) {
+	inventoryItems := make([]oscalTypes.InventoryItem, 0)
+	indexByID := make(map[string]int)
+	uuidsByResult := make([]map[string]string, len(results))
+	for i, result := range results {
+		uuidsByResult[i] = make(map[string]string)
+		for _, item := range result.InventoryItems {
+			index, exists := indexByID[item.ID]
+			if !exists {
+				index = len(inventoryItems)
+				indexByID[item.ID] = index
+				inventoryItems = append(inventoryItems, oscalTypes.InventoryItem{UUID: uuid.NewUUID()})
+			}
+			r.mergeInventoryItem(&inventoryItems[index], item)
+			uuidsByResult[i][item.ID] = inventoryItems[index].UUID
+		}
+	}
+	return inventoryItems, uuidsByResult
+}
+
+// mergeInventoryItem adds the description, properties and links of the PVP InventoryItem
+// that the OSCAL InventoryItem does not have yet. Conflicting descriptions and property
+// values are reported, and the properties with different values are all kept.
+func (r *Reporter) mergeInventoryItem(oscalItem *oscalTypes.InventoryItem, item policy.InventoryItem) {
+	switch {
+	case oscalItem.Description == "":
+		oscalItem.Description = item.Description
+	case item.Description != "" && item.Description != oscalItem.Description:
+		r.log.Warn(fmt.Sprintf("inventory item %s has different descriptions, keeping %q", item.ID, oscalItem.Description))
+	}
+
+	var props []oscalTypes.Property
+	if oscalItem.Props != nil {
+		props = *oscalItem.Props
+	}
+	for _, prop := range item.Props {
+		found := false
+		for _, existing := range props {
+			if existing.Name != prop.Name {
+				continue
+			}
+			if existing.Value == prop.Value {
+				found = true
+				break
+			}
+			r.log.Warn(fmt.Sprintf("inventory item %s has different values for property %s: %q and %q", item.ID, prop.Name, existing.Value, prop.Value))
+		}
+		if !found {
+			props = append(props, oscalTypes.Property{
+				Name:  prop.Name,
+				Value: prop.Value,
+				Ns:    extensions.TrestleNameSpace,
+			})
+		}
+	}
+	oscalItem.Props = pkg.NilIfEmpty(&props)
+
+	var links []oscalTypes.Link
+	if oscalItem.Links != nil {
+		links = *oscalItem.Links
+	}
+	for _, link := range item.Links {
+		found := false
+		for _, existing := range links {
+			if existing.Href == link.Href {
+				found = true
+				break
+			}
+		}
+		if !found {
+			links = append(links, oscalTypes.Link{
+				Href: link.Href,
+				Text: link.Description,
+			})
+		}
+	}
+	oscalItem.Links = pkg.NilIfEmpty(&links)
+}
+
+// toOscalProps converts PVP Properties to OSCAL Properties in the trestle namespace
+func toOscalProps(props []policy.Property) *[]oscalTypes.Property {
+	oscalProps := make([]oscalTypes.Property, 0)
+	for _, prop := range props {
+		oscalProps = append(oscalProps, oscalTypes.Property{
+			Name:  prop.Name,
+			Value: prop.Value,
+			Ns:    extensions.TrestleNameSpace,
+		})
+	}
+	return pkg.NilIfEmpty(&oscalProps)
+}
+
 // GenerateAssessmentResults converts PVPResults to OSCAL AsessmentResults
 func (r *Reporter) GenerateAssessmentResults(ctx context.Context, planHref string, implementationSettings *settings.ImplementationSettings, results []policy.PVPResult, opts ...GenerateOption) (oscalTypes.AssessmentResults, error) {
 
@@ -214,12 +367,21 @@ func (r *Reporter) GenerateAssessmentResults(ctx context.Context, planHref strin
 
 	r.log.Info(fmt.Sprintf("generating assessments results for plan %s", planHref))
 
+	waivers, err := compileWaivers(options.waivers)
+	if err != nil {
+		return oscalTypes.AssessmentResults{}, fmt.Errorf("invalid waivers: %w", err)
+	}
+	now := time.Now()
+
 	importAp := oscalTypes.ImportAp{
 		Href: planHref,
 	}
 
 	metadata := models.NewSampleMetadata()
 	metadata.Title = options.title
+	if signerProps := pluginSignerProps(options.manifests); len(signerProps) > 0 {
+		metadata.Props = &signerProps
+	}
 
 	assessmentResults := oscalTypes.AssessmentResults{
 		UUID:     uuid.NewUUID(),
@@ -230,8 +392,9 @@ func (r *Reporter) GenerateAssessmentResults(ctx context.Context, planHref strin
 	// for each PVPResult.Observation create an OSCAL Observation
 	oscalObservations := make([]oscalTypes.Observation, 0)
 	oscalFindings := make([]oscalTypes.Finding, 0)
+	inventoryItems, inventoryUUIDs := r.toOscalInventoryItems(results)
 
-	for _, result := range results {
+	for resultIndex, result := range results {
 
 		for _, observationByCheck := range result.ObservationsByCheck {
 			rule, err := r.rulesStore.GetByCheckID(ctx, observationByCheck.CheckID)
@@ -246,12 +409,25 @@ func (r *Reporter) GenerateAssessmentResults(ctx context.Context, planHref strin
 
 			obs := r.toOscalObservation(observationByCheck, rule)
 
-			// if the observation subject result prop is not "pass" then create relevant findings
+			// subjects of an inventory item of the same result reference the inventory item UUID
+			if obs.Subjects != nil {
+				for i, subject := range observationByCheck.Subjects {
+					if itemUUID, ok := inventoryUUIDs[resultIndex][subject.ResourceID]; ok {
+						(*obs.Subjects)[i].SubjectUuid = itemUUID
+					}
+				}
+			}
+
+			if len(waivers) > 0 {
+				r.waiveSubjects(&obs, observationByCheck, rule, waivers, now)
+			}
+
+			// if the observation subject result prop is not "pass" or "skipped" then create relevant findings
 			if obs.Subjects != nil {
 				for _, subject := range *obs.Subjects {
 					for _, prop := range *subject.Props {
 						if prop.Name == "result" {
-							if prop.Value != policy.ResultPass.String() {
+							if prop.Value != policy.ResultPass.String() && prop.Value != policy.ResultSkipped.String() {
 								oscalFindings, err = r.generateFindings(oscalFindings, obs, rule, *implementationSettings)
 								if err != nil {
 									return assessmentResults, fmt.Errorf("failed to create finding for check: %w", err)
@@ -273,15 +449,24 @@ func (r *Reporter) GenerateAssessmentResults(ctx context.Context, planHref strin
 		UUID:             uuid.NewUUID(),
 		Title:            "Automated Assessment Result",
 		Description:      "Assessment Results Automatically Genererated from PVP Results",
-		Start:            time.Now(),
+		Start:            now,
 		ReviewedControls: reviewedControls,
 		Observations:     &oscalObservations,
 	}
 
 	if len(oscalFindings) > 0 {
+		if len(waivers) > 0 {
+			applyWaivers(oscalFindings, oscalObservations)
+		}
 		oscalResult.Findings = &oscalFindings
 	}
 
+	if len(inventoryItems) > 0 {
+		oscalResult.LocalDefinitions = &oscalTypes.LocalDefinitions{
+			InventoryItems: &inventoryItems,
+		}
+	}
+
 	assessmentResults.Results = []oscalTypes.Result{
 		oscalResult,
 	}
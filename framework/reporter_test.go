@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/pkg"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
@@ -84,6 +85,194 @@ func TestReporter_GenereateAssessmentResults(t *testing.T) {
 
 }
 
+func TestReporter_GenerateAssessmentResultsSkipped(t *testing.T) {
+	cfg := prepConfig(t)
+	r, err := NewReporter(cfg)
+	require.NoError(t, err)
+
+	compDef := readCompDef(t)
+	implementationSettings := prepImplementationSettings(t, compDef)
+
+	skippedResults := []policy.PVPResult{
+		{
+			ObservationsByCheck: []policy.ObservationByCheck{
+				{
+					Title:   "etcd_cert_file",
+					CheckID: "etcd_cert_file",
+					Subjects: []policy.Subject{
+						{
+							Title:       "test_subject_1",
+							Result:      policy.ResultSkipped,
+							ResourceID:  "test_resource_1",
+							EvaluatedOn: time.Now(),
+							Props: []policy.Property{
+								{Name: "severity", Value: "high"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ar, err := r.GenerateAssessmentResults(context.TODO(), "https://test-plan-href", &implementationSettings, skippedResults)
+	require.NoError(t, err)
+	require.Len(t, ar.Results, 1)
+	require.Nil(t, ar.Results[0].Findings)
+
+	observations := *ar.Results[0].Observations
+	require.Len(t, observations, 1)
+	subjectProps := *(*observations[0].Subjects)[0].Props
+	require.Len(t, subjectProps, 5)
+	require.Equal(t, "severity", subjectProps[4].Name)
+	require.Equal(t, "high", subjectProps[4].Value)
+}
+
+func TestReporter_GenerateAssessmentResultsInventory(t *testing.T) {
+	cfg := prepConfig(t)
+	r, err := NewReporter(cfg)
+	require.NoError(t, err)
+
+	compDef := readCompDef(t)
+	implementationSettings := prepImplementationSettings(t, compDef)
+
+	inventoryResults := []policy.PVPResult{
+		{
+			ObservationsByCheck: []policy.ObservationByCheck{
+				{
+					Title:   "etcd_cert_file",
+					CheckID: "etcd_cert_file",
+					Subjects: []policy.Subject{
+						{
+							Title:       "cluster1",
+							Type:        "cluster",
+							Result:      policy.ResultPass,
+							ResourceID:  "cluster1",
+							EvaluatedOn: time.Now(),
+						},
+						{
+							Title:       "test_subject_1",
+							Result:      policy.ResultPass,
+							ResourceID:  "test_resource_1",
+							EvaluatedOn: time.Now(),
+						},
+					},
+					RelevantEvidences: []policy.Link{
+						{
+							Description: "compliant",
+							Href:        "https://test-related-evidence-1",
+							Props: []policy.Property{
+								{Name: "cluster-name", Value: "cluster1"},
+							},
+						},
+					},
+				},
+			},
+			InventoryItems: []policy.InventoryItem{
+				{
+					ID:          "cluster1",
+					Description: "Managed cluster cluster1",
+					Props: []policy.Property{
+						{Name: "cluster-name", Value: "cluster1"},
+					},
+				},
+			},
+		},
+	}
+
+	ar, err := r.GenerateAssessmentResults(context.TODO(), "https://test-plan-href", &implementationSettings, inventoryResults)
+	require.NoError(t, err)
+	require.Len(t, ar.Results, 1)
+	require.NotNil(t, ar.Results[0].LocalDefinitions)
+
+	inventoryItems := *ar.Results[0].LocalDefinitions.InventoryItems
+	require.Len(t, inventoryItems, 1)
+	require.Equal(t, "Managed cluster cluster1", inventoryItems[0].Description)
+	require.Equal(t, "cluster-name", (*inventoryItems[0].Props)[0].Name)
+
+	observations := *ar.Results[0].Observations
+	require.Len(t, observations, 1)
+	subjects := *observations[0].Subjects
+	require.Len(t, subjects, 2)
+	require.Equal(t, inventoryItems[0].UUID, subjects[0].SubjectUuid)
+	require.NotEqual(t, inventoryItems[0].UUID, subjects[1].SubjectUuid)
+
+	evidences := *observations[0].RelevantEvidence
+	require.Len(t, evidences, 1)
+	require.Equal(t, "cluster1", (*evidences[0].Props)[0].Value)
+
+	// the items of several results with the same ID are merged and subjects only reference
+	// the items of their own result
+	nodeResult := func(resourceIDs []string, items ...policy.InventoryItem) policy.PVPResult {
+		var subjects []policy.Subject
+		for _, resourceID := range resourceIDs {
+			subjects = append(subjects, policy.Subject{Title: resourceID, Result: policy.ResultPass, ResourceID: resourceID, EvaluatedOn: time.Now()})
+		}
+		return policy.PVPResult{
+			ObservationsByCheck: []policy.ObservationByCheck{{Title: "etcd_cert_file", CheckID: "etcd_cert_file", Subjects: subjects}},
+			InventoryItems:      items,
+		}
+	}
+	ar, err = r.GenerateAssessmentResults(context.TODO(), "https://test-plan-href", &implementationSettings, []policy.PVPResult{
+		nodeResult([]string{"node1", "node2"},
+			policy.InventoryItem{ID: "node1", Description: "Host node1", Props: []policy.Property{{Name: "os", Value: "rhel9"}}},
+			policy.InventoryItem{ID: "node2", Description: "Host node2"},
+		),
+		nodeResult([]string{"node1", "node2"},
+			policy.InventoryItem{ID: "node1", Description: "Node node1", Props: []policy.Property{{Name: "os", Value: "rhel9"}, {Name: "role", Value: "master"}}},
+		),
+	})
+	require.NoError(t, err)
+	inventoryItems = *ar.Results[0].LocalDefinitions.InventoryItems
+	require.Len(t, inventoryItems, 2)
+	require.Equal(t, "Host node1", inventoryItems[0].Description)
+	require.Len(t, *inventoryItems[0].Props, 2)
+	require.Equal(t, "role", (*inventoryItems[0].Props)[1].Name)
+	require.Nil(t, inventoryItems[1].Props)
+
+	observations = *ar.Results[0].Observations
+	require.Len(t, observations, 2)
+	require.Equal(t, inventoryItems[0].UUID, (*observations[0].Subjects)[0].SubjectUuid)
+	require.Equal(t, inventoryItems[1].UUID, (*observations[0].Subjects)[1].SubjectUuid)
+	require.Equal(t, inventoryItems[0].UUID, (*observations[1].Subjects)[0].SubjectUuid)
+	require.NotEqual(t, inventoryItems[1].UUID, (*observations[1].Subjects)[1].SubjectUuid)
+}
+
+func TestReporter_GenerateAssessmentResultsPluginSigners(t *testing.T) {
+	cfg := prepConfig(t)
+	r, err := NewReporter(cfg)
+	require.NoError(t, err)
+
+	compDef := readCompDef(t)
+	implementationSettings := prepImplementationSettings(t, compDef)
+
+	manifests := plugin.Manifests{
+		"unsigned": plugin.Manifest{Metadata: plugin.Metadata{ID: "unsigned"}},
+		"zplugin": plugin.Manifest{
+			Metadata:  plugin.Metadata{ID: "zplugin"},
+			Signature: &plugin.Signature{Signer: "release", KeyID: "sha256:1234"},
+		},
+		"aplugin": plugin.Manifest{
+			Metadata:  plugin.Metadata{ID: "aplugin"},
+			Signature: &plugin.Signature{Signer: "vendor", KeyID: "sha256:5678"},
+		},
+	}
+	ar, err := r.GenerateAssessmentResults(context.TODO(), "https://test-plan-href", &implementationSettings, pvpResults, WithPluginManifests(manifests))
+	require.NoError(t, err)
+	require.NotNil(t, ar.Metadata.Props)
+	props := *ar.Metadata.Props
+	require.Len(t, props, 2)
+	require.Equal(t, "plugin-signer", props[0].Name)
+	require.Equal(t, "vendor", props[0].Value)
+	require.Equal(t, "aplugin", props[0].Class)
+	require.Equal(t, "sha256:5678", props[0].Remarks)
+	require.Equal(t, "release", props[1].Value)
+
+	ar, err = r.GenerateAssessmentResults(context.TODO(), "https://test-plan-href", &implementationSettings, pvpResults)
+	require.NoError(t, err)
+	require.Nil(t, ar.Metadata.Props)
+}
+
 func TestReporter_FindControls(t *testing.T) {
 	cfg := prepConfig(t)
 	r, err := NewReporter(cfg)
@@ -25,6 +25,10 @@ type Subject struct {
 	Result string `json:"result,omitempty" yaml:"result,omitempty"`
 	// Reason
 	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// Waiver ID
+	Waiver string `json:"waiver,omitempty" yaml:"waiver,omitempty"`
+	// Waiver status, active or expired
+	WaiverStatus string `json:"waiverStatus,omitempty" yaml:"waiverStatus,omitempty"`
 }
 
 type RuleResult struct {
@@ -32,6 +36,10 @@ type RuleResult struct {
 	RuleId string `json:"ruleId,omitempty" yaml:"ruleId,omitempty"`
 	// Subjects
 	Subjects []Subject `json:"subjects,omitempty" yaml:"subjects,omitempty"`
+	// Number of subjects with an active waiver
+	Waived int `json:"waived,omitempty" yaml:"waived,omitempty"`
+	// Number of subjects with an expired waiver
+	ExpiredWaivers int `json:"expiredWaivers,omitempty" yaml:"expiredWaivers,omitempty"`
 }
 
 type ControlResult struct {
@@ -163,12 +163,26 @@ func CreateComponentValues(
 								Result: result,
 								Reason: reason,
 							}
+							if waiverProp, waiverFound := extensions.GetTrestleProp(waiverIDProp, *rawSubject.Props); waiverFound {
+								statusProp, _ := extensions.GetTrestleProp(waiverStatusProp, *rawSubject.Props)
+								subject.Waiver = waiverProp.Value
+								subject.WaiverStatus = statusProp.Value
+							}
 							subjects = append(subjects, subject)
 						}
-						controlResult.RuleResults = append(controlResult.RuleResults, tp.RuleResult{
+						ruleResult := tp.RuleResult{
 							RuleId:   ruleId.Value,
 							Subjects: subjects,
-						})
+						}
+						for _, subject := range subjects {
+							switch subject.WaiverStatus {
+							case WaiverStatusActive:
+								ruleResult.Waived++
+							case WaiverStatusExpired:
+								ruleResult.ExpiredWaivers++
+							}
+						}
+						controlResult.RuleResults = append(controlResult.RuleResults, ruleResult)
 					}
 				}
 				component.ControlResults = append(component.ControlResults, controlResult)
@@ -0,0 +1,324 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package framework
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-2"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	sigyaml "sigs.k8s.io/yaml"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Properties recorded on the waived subjects and findings.
+const (
+	waiverIDProp       = "waiver-id"
+	waiverStatusProp   = "waiver-status"
+	waiverApproverProp = "waiver-approver"
+	waiverExpiresProp  = "waiver-expires"
+)
+
+// Waiver statuses recorded on the waived subjects.
+const (
+	// WaiverStatusActive is the status of a waiver that has not expired.
+	WaiverStatusActive = "active"
+	// WaiverStatusExpired is the status of a waiver past its expiry. The subjects
+	// of expired waivers are still reported as not satisfied.
+	WaiverStatusExpired = "expired"
+)
+
+// WaiverFile is the format of a waiver file.
+type WaiverFile struct {
+	Waivers []Waiver `json:"waivers"`
+}
+
+// Waiver is an approved exception for the subjects of a rule or check that do not pass.
+type Waiver struct {
+	// ID identifies the waiver in the assessment results.
+	ID string `json:"id"`
+	// RuleID is the ID of the waived rule.
+	RuleID string `json:"rule-id,omitempty"`
+	// CheckID is the ID of the waived check. When RuleID is also set, both must match.
+	CheckID string `json:"check-id,omitempty"`
+	// Subject selects the waived subjects.
+	Subject SubjectSelector `json:"subject"`
+	// Justification is the reason the risk is accepted.
+	Justification string `json:"justification"`
+	// Approver is who approved the waiver.
+	Approver string `json:"approver"`
+	// Expires is the expiry of the waiver, as a date or an RFC 3339 timestamp.
+	// A date waives the subjects until the end of that day in UTC.
+	Expires string `json:"expires"`
+}
+
+// SubjectSelector selects subjects by resource ID, namespace and title. All the
+// fields that are set must match.
+type SubjectSelector struct {
+	// ResourceID is the resource ID of the subject.
+	ResourceID string `json:"resource-id,omitempty"`
+	// Namespace is the namespace of the subject, from its `namespace` property or its title.
+	Namespace string `json:"namespace,omitempty"`
+	// Title is a regular expression matched against the subject title.
+	Title string `json:"title,omitempty"`
+}
+
+// LoadWaivers loads and validates the waivers of the YAML or JSON waiver file.
+func LoadWaivers(path string) ([]Waiver, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var waiverFile WaiverFile
+	if err := sigyaml.UnmarshalStrict(data, &waiverFile); err != nil {
+		return nil, fmt.Errorf("invalid waiver file %s: %w", path, err)
+	}
+	if _, err := compileWaivers(waiverFile.Waivers); err != nil {
+		return nil, fmt.Errorf("invalid waiver file %s: %w", path, err)
+	}
+	return waiverFile.Waivers, nil
+}
+
+// ExpiresAt returns the time the waiver expires.
+func (w Waiver) ExpiresAt() (time.Time, error) {
+	if expires, err := time.Parse(time.DateOnly, w.Expires); err == nil {
+		return expires.AddDate(0, 0, 1), nil
+	}
+	expires, err := time.Parse(time.RFC3339, w.Expires)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiry %q: must be a date or an RFC 3339 timestamp", w.Expires)
+	}
+	return expires, nil
+}
+
+// Validate returns an error if the waiver is incomplete.
+func (w Waiver) Validate() error {
+	_, err := compileWaiver(w)
+	return err
+}
+
+// waiver is a validated Waiver with its expiry and title expression parsed.
+type waiver struct {
+	Waiver
+	expires time.Time
+	title   *regexp.Regexp
+}
+
+func compileWaivers(waivers []Waiver) ([]waiver, error) {
+	compiled := make([]waiver, 0, len(waivers))
+	ids := make(map[string]bool)
+	for i, w := range waivers {
+		c, err := compileWaiver(w)
+		if err != nil {
+			return nil, fmt.Errorf("waiver %d: %w", i, err)
+		}
+		if ids[w.ID] {
+			return nil, fmt.Errorf("duplicate waiver id %q", w.ID)
+		}
+		ids[w.ID] = true
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+func compileWaiver(w Waiver) (waiver, error) {
+	compiled := waiver{Waiver: w}
+	switch {
+	case w.ID == "":
+		return compiled, fmt.Errorf("id is required")
+	case w.RuleID == "" && w.CheckID == "":
+		return compiled, fmt.Errorf("waiver %s must set a rule id or a check id", w.ID)
+	case w.Subject == SubjectSelector{}:
+		return compiled, fmt.Errorf("waiver %s must select subjects by resource id, namespace or title", w.ID)
+	case w.Justification == "":
+		return compiled, fmt.Errorf("waiver %s must set a justification", w.ID)
+	case w.Approver == "":
+		return compiled, fmt.Errorf("waiver %s must set an approver", w.ID)
+	}
+	expires, err := w.ExpiresAt()
+	if err != nil {
+		return compiled, fmt.Errorf("waiver %s: %w", w.ID, err)
+	}
+	compiled.expires = expires
+	if w.Subject.Title != "" {
+		compiled.title, err = regexp.Compile(w.Subject.Title)
+		if err != nil {
+			return compiled, fmt.Errorf("waiver %s: invalid title expression: %w", w.ID, err)
+		}
+	}
+	return compiled, nil
+}
+
+// matches returns whether the waiver applies to the subject of the rule and check.
+func (w waiver) matches(ruleID, checkID string, subject policy.Subject) bool {
+	if w.RuleID != "" && w.RuleID != ruleID {
+		return false
+	}
+	if w.CheckID != "" && w.CheckID != checkID {
+		return false
+	}
+	if w.Subject.ResourceID != "" && w.Subject.ResourceID != subject.ResourceID {
+		return false
+	}
+	if w.Subject.Namespace != "" && w.Subject.Namespace != subjectNamespace(subject) {
+		return false
+	}
+	return w.title == nil || w.title.MatchString(subject.Title)
+}
+
+// status returns the status of the waiver at the given time.
+func (w waiver) status(now time.Time) string {
+	if now.Before(w.expires) {
+		return WaiverStatusActive
+	}
+	return WaiverStatusExpired
+}
+
+// findWaiver returns the waiver for the subject, preferring active waivers over expired ones.
+func findWaiver(waivers []waiver, ruleID, checkID string, subject policy.Subject, now time.Time) (waiver, bool) {
+	var expired *waiver
+	for i := range waivers {
+		if !waivers[i].matches(ruleID, checkID, subject) {
+			continue
+		}
+		if waivers[i].status(now) == WaiverStatusActive {
+			return waivers[i], true
+		}
+		if expired == nil {
+			expired = &waivers[i]
+		}
+	}
+	if expired != nil {
+		return *expired, true
+	}
+	return waiver{}, false
+}
+
+// waiveSubjects marks the non-passing subjects of the observation selected by a waiver with the
+// waiver properties and remarks.
+func (r *Reporter) waiveSubjects(obs *oscalTypes.Observation, observationByCheck policy.ObservationByCheck, ruleSet extensions.RuleSet, waivers []waiver, now time.Time) {
+	if obs.Subjects == nil {
+		return
+	}
+	for i, subject := range observationByCheck.Subjects {
+		if subject.Result == policy.ResultPass || subject.Result == policy.ResultSkipped {
+			continue
+		}
+		w, found := findWaiver(waivers, ruleSet.Rule.ID, observationByCheck.CheckID, subject, now)
+		if !found {
+			continue
+		}
+		status := w.status(now)
+		oscalSubject := &(*obs.Subjects)[i]
+		*oscalSubject.Props = append(*oscalSubject.Props,
+			oscalTypes.Property{
+				Name:    waiverIDProp,
+				Value:   w.ID,
+				Remarks: w.Justification,
+				Ns:      extensions.TrestleNameSpace,
+			},
+			oscalTypes.Property{
+				Name:  waiverStatusProp,
+				Value: status,
+				Ns:    extensions.TrestleNameSpace,
+			},
+			oscalTypes.Property{
+				Name:  waiverApproverProp,
+				Value: w.Approver,
+				Ns:    extensions.TrestleNameSpace,
+			},
+			oscalTypes.Property{
+				Name:  waiverExpiresProp,
+				Value: w.Expires,
+				Ns:    extensions.TrestleNameSpace,
+			},
+		)
+		if status == WaiverStatusExpired {
+			oscalSubject.Remarks = fmt.Sprintf("Waiver %s approved by %s expired on %s: %s", w.ID, w.Approver, w.Expires, w.Justification)
+			r.log.Warn(fmt.Sprintf("waiver %s for rule %s and subject %s expired on %s", w.ID, ruleSet.Rule.ID, subject.Title, w.Expires))
+		} else {
+			oscalSubject.Remarks = fmt.Sprintf("Waived by %s until %s under waiver %s: %s", w.Approver, w.Expires, w.ID, w.Justification)
+			r.log.Info(fmt.Sprintf("waiver %s applied to rule %s for subject %s", w.ID, ruleSet.Rule.ID, subject.Title))
+		}
+	}
+}
+
+// applyWaivers records the waivers of the non-passing subjects of the related observations on
+// the findings. Findings where all the non-passing subjects have an active waiver are satisfied.
+func applyWaivers(findings []oscalTypes.Finding, observations []oscalTypes.Observation) {
+	observationsByUUID := make(map[string]oscalTypes.Observation, len(observations))
+	for _, obs := range observations {
+		observationsByUUID[obs.UUID] = obs
+	}
+
+	for i := range findings {
+		finding := &findings[i]
+		if finding.RelatedObservations == nil {
+			continue
+		}
+		var props []oscalTypes.Property
+		waiverIDs := make(map[string]bool)
+		relatedUUIDs := make(map[string]bool)
+		var nonPassing, waived, expired int
+		for _, related := range *finding.RelatedObservations {
+			obs, ok := observationsByUUID[related.ObservationUuid]
+			if !ok || obs.Subjects == nil || relatedUUIDs[related.ObservationUuid] {
+				continue
+			}
+			relatedUUIDs[related.ObservationUuid] = true
+			for _, subject := range *obs.Subjects {
+				if subject.Props == nil {
+					continue
+				}
+				result, _ := extensions.GetTrestleProp("result", *subject.Props)
+				if result.Value == policy.ResultPass.String() || result.Value == policy.ResultSkipped.String() {
+					continue
+				}
+				nonPassing++
+				id, found := extensions.GetTrestleProp(waiverIDProp, *subject.Props)
+				if !found {
+					continue
+				}
+				status, _ := extensions.GetTrestleProp(waiverStatusProp, *subject.Props)
+				if status.Value == WaiverStatusActive {
+					waived++
+				} else {
+					expired++
+				}
+				if !waiverIDs[id.Value] {
+					waiverIDs[id.Value] = true
+					props = append(props, oscalTypes.Property{
+						Name:    waiverIDProp,
+						Value:   id.Value,
+						Class:   status.Value,
+						Remarks: id.Remarks,
+						Ns:      extensions.TrestleNameSpace,
+					})
+				}
+			}
+		}
+		if len(props) == 0 {
+			continue
+		}
+
+		if finding.Props != nil {
+			props = append(*finding.Props, props...)
+		}
+		finding.Props = &props
+		finding.Remarks = fmt.Sprintf("Waived subjects: %d of %d non-passing. Subjects with expired waivers: %d.", waived, nonPassing, expired)
+		if waived == nonPassing {
+			finding.Target.Status = oscalTypes.ObjectiveStatus{
+				State:   "satisfied",
+				Reason:  "other",
+				Remarks: "All the non-passing subjects are waived.",
+			}
+		}
+	}
+}
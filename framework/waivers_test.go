@@ -0,0 +1,297 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package framework
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	oscalTypes "github.com/defenseunicorns/go-oscal/src/types/oscal-1-1-2"
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+func TestLoadWaivers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "Valid",
+			content: `waivers:
+- id: w1
+  rule-id: etcd_cert_file
+  subject:
+    namespace: legacy
+  justification: Migration in progress
+  approver: security-team
+  expires: "2030-01-31"
+- id: w2
+  check-id: etcd_cert_file
+  subject:
+    title: "^test_.*"
+  justification: Accepted risk
+  approver: ciso
+  expires: "2030-01-31T12:00:00Z"
+`,
+		},
+		{
+			name: "Failure/MissingApprover",
+			content: `waivers:
+- id: w1
+  rule-id: etcd_cert_file
+  subject:
+    namespace: legacy
+  justification: Migration in progress
+  expires: "2030-01-31"
+`,
+			wantErr: "waiver w1 must set an approver",
+		},
+		{
+			name: "Failure/MissingSubject",
+			content: `waivers:
+- id: w1
+  rule-id: etcd_cert_file
+  justification: Migration in progress
+  approver: security-team
+  expires: "2030-01-31"
+`,
+			wantErr: "waiver w1 must select subjects",
+		},
+		{
+			name: "Failure/InvalidExpiry",
+			content: `waivers:
+- id: w1
+  rule-id: etcd_cert_file
+  subject:
+    namespace: legacy
+  justification: Migration in progress
+  approver: security-team
+  expires: next year
+`,
+			wantErr: `invalid expiry "next year"`,
+		},
+		{
+			name: "Failure/DuplicateID",
+			content: `waivers:
+- id: w1
+  rule-id: etcd_cert_file
+  subject:
+    namespace: legacy
+  justification: Migration in progress
+  approver: security-team
+  expires: "2030-01-31"
+- id: w1
+  rule-id: etcd_cert_file
+  subject:
+    namespace: other
+  justification: Migration in progress
+  approver: security-team
+  expires: "2030-01-31"
+`,
+			wantErr: `duplicate waiver id "w1"`,
+		},
+		{
+			name: "Failure/UnknownField",
+			content: `waivers:
+- id: w1
+  rule: etcd_cert_file
+`,
+			wantErr: "unknown field",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "waivers.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(c.content), 0600))
+			waivers, err := LoadWaivers(path)
+			if c.wantErr != "" {
+				require.ErrorContains(t, err, c.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, waivers, 2)
+			require.Equal(t, "legacy", waivers[0].Subject.Namespace)
+		})
+	}
+}
+
+func TestWaiver_ExpiresAt(t *testing.T) {
+	expires, err := Waiver{Expires: "2030-01-31"}.ExpiresAt()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2030, 2, 1, 0, 0, 0, 0, time.UTC), expires)
+
+	expires, err = Waiver{Expires: "2030-01-31T12:00:00Z"}.ExpiresAt()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2030, 1, 31, 12, 0, 0, 0, time.UTC), expires)
+}
+
+func TestFindWaiver(t *testing.T) {
+	waivers, err := compileWaivers([]Waiver{
+		{
+			ID:            "expired",
+			RuleID:        "etcd_cert_file",
+			Subject:       SubjectSelector{Namespace: "legacy"},
+			Justification: "Old exception",
+			Approver:      "security-team",
+			Expires:       "2020-01-31",
+		},
+		{
+			ID:            "active",
+			CheckID:       "etcd_cert_file",
+			Subject:       SubjectSelector{Namespace: "legacy", Title: "Name: nginx"},
+			Justification: "Migration in progress",
+			Approver:      "security-team",
+			Expires:       "2030-01-31",
+		},
+	})
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	nginx := policy.Subject{Title: "ApiVersion: v1, Kind: Pod, Namespace: legacy, Name: nginx"}
+	w, found := findWaiver(waivers, "etcd_cert_file", "etcd_cert_file", nginx, now)
+	require.True(t, found)
+	require.Equal(t, "active", w.ID)
+	require.Equal(t, WaiverStatusActive, w.status(now))
+
+	redis := policy.Subject{Title: "redis", Props: []policy.Property{{Name: "namespace", Value: "legacy"}}}
+	w, found = findWaiver(waivers, "etcd_cert_file", "etcd_cert_file", redis, now)
+	require.True(t, found)
+	require.Equal(t, "expired", w.ID)
+	require.Equal(t, WaiverStatusExpired, w.status(now))
+
+	_, found = findWaiver(waivers, "other_rule", "other_check", nginx, now)
+	require.False(t, found)
+
+	other := policy.Subject{Title: "ApiVersion: v1, Kind: Pod, Namespace: default, Name: nginx"}
+	_, found = findWaiver(waivers, "etcd_cert_file", "etcd_cert_file", other, now)
+	require.False(t, found)
+}
+
+func TestReporter_GenerateAssessmentResultsWaivers(t *testing.T) {
+	cfg := prepConfig(t)
+	r, err := NewReporter(cfg)
+	require.NoError(t, err)
+
+	compDef := readCompDef(t)
+	implementationSettings := prepImplementationSettings(t, compDef)
+
+	results := func(resourceIDs ...string) []policy.PVPResult {
+		subjects := make([]policy.Subject, 0, len(resourceIDs))
+		for _, resourceID := range resourceIDs {
+			result := policy.ResultFail
+			if resourceID == "passing" {
+				result = policy.ResultPass
+			}
+			subjects = append(subjects, policy.Subject{
+				Title:       resourceID,
+				Result:      result,
+				ResourceID:  resourceID,
+				EvaluatedOn: time.Now(),
+			})
+		}
+		return []policy.PVPResult{
+			{
+				ObservationsByCheck: []policy.ObservationByCheck{
+					{
+						Title:    "etcd_cert_file",
+						CheckID:  "etcd_cert_file",
+						Subjects: subjects,
+					},
+				},
+			},
+		}
+	}
+	waivers := []Waiver{
+		{
+			ID:            "waiver-1",
+			RuleID:        "etcd_cert_file",
+			Subject:       SubjectSelector{ResourceID: "waived"},
+			Justification: "Migration in progress",
+			Approver:      "security-team",
+			Expires:       "2999-12-31",
+		},
+		{
+			ID:            "waiver-2",
+			RuleID:        "etcd_cert_file",
+			Subject:       SubjectSelector{ResourceID: "expired"},
+			Justification: "Old exception",
+			Approver:      "security-team",
+			Expires:       "2020-01-31",
+		},
+	}
+
+	t.Run("Success/AllWaived", func(t *testing.T) {
+		ar, err := r.GenerateAssessmentResults(context.TODO(), "https://test-plan-href", &implementationSettings, results("waived", "passing"), WithWaivers(waivers))
+		require.NoError(t, err)
+
+		subjects := *(*ar.Results[0].Observations)[0].Subjects
+		require.Len(t, subjects, 2)
+		requireWaiverProp(t, subjects[0], waiverIDProp, "waiver-1")
+		requireWaiverProp(t, subjects[0], waiverStatusProp, WaiverStatusActive)
+		requireWaiverProp(t, subjects[0], waiverApproverProp, "security-team")
+		require.Contains(t, subjects[0].Remarks, "Migration in progress")
+		_, found := extensions.GetTrestleProp(waiverIDProp, *subjects[1].Props)
+		require.False(t, found)
+
+		findings := *ar.Results[0].Findings
+		require.Len(t, findings, 1)
+		require.Equal(t, "satisfied", findings[0].Target.Status.State)
+		require.Equal(t, "waiver-1", (*findings[0].Props)[0].Value)
+		require.Equal(t, WaiverStatusActive, (*findings[0].Props)[0].Class)
+	})
+
+	t.Run("Success/ExpiredWaiver", func(t *testing.T) {
+		ar, err := r.GenerateAssessmentResults(context.TODO(), "https://test-plan-href", &implementationSettings, results("waived", "expired"), WithWaivers(waivers))
+		require.NoError(t, err)
+
+		subjects := *(*ar.Results[0].Observations)[0].Subjects
+		requireWaiverProp(t, subjects[1], waiverStatusProp, WaiverStatusExpired)
+		require.Contains(t, subjects[1].Remarks, "expired")
+
+		findings := *ar.Results[0].Findings
+		require.Len(t, findings, 1)
+		require.Equal(t, "not-satisfied", findings[0].Target.Status.State)
+		require.Len(t, *findings[0].Props, 2)
+		require.Equal(t, WaiverStatusExpired, (*findings[0].Props)[1].Class)
+		require.Equal(t, "Waived subjects: 1 of 2 non-passing. Subjects with expired waivers: 1.", findings[0].Remarks)
+
+		values, err := CreateComponentValues(&oscalTypes.Catalog{Metadata: oscalTypes.Metadata{Title: "Catalog"}}, &compDef, &ar, r.log)
+		require.NoError(t, err)
+		var ruleFound bool
+		for _, component := range values.Components {
+			for _, control := range component.ControlResults {
+				for _, rule := range control.RuleResults {
+					if rule.RuleId == "etcd_cert_file" {
+						ruleFound = true
+						require.Equal(t, 1, rule.Waived)
+						require.Equal(t, 1, rule.ExpiredWaivers)
+						require.Equal(t, "waiver-1", rule.Subjects[0].Waiver)
+					}
+				}
+			}
+		}
+		require.True(t, ruleFound)
+	})
+
+	t.Run("Failure/InvalidWaiver", func(t *testing.T) {
+		_, err := r.GenerateAssessmentResults(context.TODO(), "https://test-plan-href", &implementationSettings, results("waived"), WithWaivers([]Waiver{{ID: "waiver-1"}}))
+		require.ErrorContains(t, err, "invalid waivers")
+	})
+}
+
+func requireWaiverProp(t *testing.T, subject oscalTypes.SubjectReference, name, value string) {
+	prop, found := extensions.GetTrestleProp(name, *subject.Props)
+	require.True(t, found)
+	require.Equal(t, value, prop.Value)
+}
@@ -6,7 +6,7 @@
 
 SCRIPT_DIR=$( cd -- "$( dirname -- "${BASH_SOURCE[0]}" )" &> /dev/null && pwd )
 
-cp -f "${SCRIPT_DIR}/../bin/kyverno-plugin" "${SCRIPT_DIR}/../bin/ocm-plugin" "${SCRIPT_DIR}/../c2p-plugins"
+cp -f "${SCRIPT_DIR}/../bin/kyverno-plugin" "${SCRIPT_DIR}/../bin/ocm-plugin" "${SCRIPT_DIR}/../bin/gatekeeper-plugin" "${SCRIPT_DIR}/../bin/vap-plugin" "${SCRIPT_DIR}/../bin/openscap-plugin" "${SCRIPT_DIR}/../bin/compliance-operator-plugin" "${SCRIPT_DIR}/../bin/sarif-plugin" "${SCRIPT_DIR}/../bin/kube-bench-plugin" "${SCRIPT_DIR}/../bin/exec-plugin" "${SCRIPT_DIR}/../c2p-plugins"
 
 checksum=$(sha256sum "${SCRIPT_DIR}/../c2p-plugins/kyverno-plugin" | cut -d ' ' -f 1 )
 cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-kyverno-manifest.json" << EOF
@@ -16,32 +16,40 @@ cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-kyverno-manifest.json" << EOF
     "description": "Kyverno PVP Plugin",
     "version": "0.0.1",
     "types": [
-      "pvp"
+      "pvp",
+      "collector"
     ]
   },
   "executablePath": "kyverno-plugin",
   "sha256": "$checksum",
+  "collectors": [
+    "kyverno"
+  ],
   "configuration": [
     {
       "name": "policy-dir",
       "description": "A directory where kyverno policies are located.",
-      "required": true
+      "required": true,
+      "path": true
     },
     {
       "name": "policy-results-dir",
       "description": "A directory where policy results are located",
-      "required": true
+      "required": true,
+      "path": true
     },
     {
       "name": "temp-dir",
       "description": "A temporary directory for policies",
-      "required": true
+      "required": true,
+      "path": true
     },
     {
       "name": "output-dir",
       "description": "The output directory for policies",
       "required": false,
-      "default": "."
+      "default": ".",
+      "path": true
     }
   ]
 }
@@ -65,23 +73,27 @@ cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-ocm-manifest.json" << EOF
    {
      "name": "policy-dir",
      "description": "A directory where ocm policies are located.",
-     "required": true
+     "required": true,
+     "path": true
    },
    {
      "name": "policy-results-dir",
-     "description": "A directory where policy results are located",
-     "required": true
+     "description": "A directory where policy results are located. Required unless kubeconfig is set",
+     "required": false,
+     "path": true
    },
    {
      "name": "temp-dir",
      "description": "A temporary directory for policies",
-     "required": true
+     "required": true,
+     "path": true
    },
    {
      "name": "output-dir",
      "description": "The output directory for policies",
      "required": false,
-     "default": "."
+     "default": ".",
+     "path": true
    },
    {
       "name": "policy-set-name",
@@ -90,7 +102,409 @@ cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-ocm-manifest.json" << EOF
    {
       "name": "namespace",
       "required": true
+   },
+   {
+     "name": "cluster-selectors",
+     "description": "A Kubernetes label selector for the managed clusters to place policies on (e.g. env=dev,region in (us-east,us-west))",
+     "required": false,
+     "default": "env=dev"
+   },
+   {
+     "name": "placement-kind",
+     "description": "The kind of placement to generate: placement or placement-rule",
+     "required": false,
+     "default": "placement"
+   },
+   {
+     "name": "namespace-include",
+     "description": "A comma-separated list of namespaces the configuration policies apply to",
+     "required": false,
+     "default": "*"
+   },
+   {
+     "name": "namespace-exclude",
+     "description": "A comma-separated list of namespaces the configuration policies ignore",
+     "required": false,
+     "default": "kube-system,open-cluster-management,open-cluster-management-agent,open-cluster-management-agent-addon"
+   },
+   {
+     "name": "remediation-action",
+     "description": "Overrides the remediation action of all policies: inform or enforce",
+     "required": false
+   },
+   {
+     "name": "severity",
+     "description": "Overrides the severity of all policies: low, medium, high or critical",
+     "required": false
+   },
+   {
+     "name": "severity-overrides",
+     "description": "A comma-separated list of check-id=severity pairs overriding the severity of specific policies",
+     "required": false
+   },
+   {
+     "name": "kubeconfig",
+     "description": "A kubeconfig for the hub cluster. When set, Policies, PolicySets and PlacementDecisions are read from the hub instead of policy-results-dir",
+     "required": false,
+     "path": true
+   },
+   {
+     "name": "output-mode",
+     "description": "How generated policies are written: flat (one file per resource) or kustomize (a directory per PolicySet with a kustomization.yaml and a manifest index)",
+     "required": false,
+     "default": "flat"
    }
  ]
 }
-EOF
\ No newline at end of file
+EOF
+
+checksum=$(sha256sum "${SCRIPT_DIR}/../c2p-plugins/gatekeeper-plugin" | cut -d ' ' -f 1 )
+cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-gatekeeper-manifest.json" << EOF
+{
+  "metadata": {
+    "id": "gatekeeper",
+    "description": "Gatekeeper PVP Plugin",
+    "version": "0.0.1",
+    "types": [
+      "pvp"
+    ]
+  },
+  "executablePath": "gatekeeper-plugin",
+  "sha256": "$checksum",
+  "configuration": [
+    {
+      "name": "policy-dir",
+      "description": "A directory with a ConstraintTemplate, and optionally a match.yaml, for each check",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "policy-results-dir",
+      "description": "A directory where Constraints with audit status are located. Not used when kubeconfig is set",
+      "required": false,
+      "path": true
+    },
+    {
+      "name": "output-dir",
+      "description": "The output directory for ConstraintTemplates and Constraints",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "kubeconfig",
+      "description": "A kubeconfig for the cluster running Gatekeeper. When set, Constraints are read from the cluster instead of policy-results-dir",
+      "required": false,
+      "path": true
+    },
+    {
+      "name": "enforcement-action",
+      "description": "The enforcement action of generated Constraints: deny, dryrun or warn",
+      "required": false,
+      "default": "deny"
+    }
+  ]
+}
+EOF
+
+
+checksum=$(sha256sum "${SCRIPT_DIR}/../c2p-plugins/vap-plugin" | cut -d ' ' -f 1 )
+cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-vap-manifest.json" << EOF
+{
+  "metadata": {
+    "id": "vap",
+    "description": "ValidatingAdmissionPolicy PVP Plugin",
+    "version": "0.0.1",
+    "types": [
+      "pvp"
+    ]
+  },
+  "executablePath": "vap-plugin",
+  "sha256": "$checksum",
+  "configuration": [
+    {
+      "name": "policy-dir",
+      "description": "A directory with a ValidatingAdmissionPolicy template for each check",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "manifests-dir",
+      "description": "A directory of Kubernetes manifests the policies are evaluated against",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "output-dir",
+      "description": "The output directory for policies, bindings and params ConfigMaps",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "params-namespace",
+      "description": "The namespace of the params ConfigMaps",
+      "required": false,
+      "default": "c2p"
+    },
+    {
+      "name": "validation-actions",
+      "description": "A comma-separated list of validation actions of the generated bindings: Deny, Warn or Audit",
+      "required": false,
+      "default": "Deny"
+    }
+  ]
+}
+EOF
+
+
+checksum=$(sha256sum "${SCRIPT_DIR}/../c2p-plugins/openscap-plugin" | cut -d ' ' -f 1 )
+cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-openscap-manifest.json" << EOF
+{
+  "metadata": {
+    "id": "openscap",
+    "description": "OpenSCAP PVP Plugin",
+    "version": "0.0.1",
+    "types": [
+      "pvp"
+    ]
+  },
+  "executablePath": "openscap-plugin",
+  "sha256": "$checksum",
+  "configuration": [
+    {
+      "name": "policy-results-dir",
+      "description": "A directory where XCCDF 1.2 result files and ARF reports are located",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "output-dir",
+      "description": "The output directory for the tailoring file",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "profile",
+      "description": "The ID of the benchmark profile extended by the tailored profile",
+      "required": false
+    },
+    {
+      "name": "benchmark-href",
+      "description": "The location of the benchmark or data stream the tailoring file applies to",
+      "required": false
+    },
+    {
+      "name": "tailored-profile-id",
+      "description": "The ID of the profile defined by the tailoring file",
+      "required": false,
+      "default": "xccdf_compliance-to-policy_profile_tailored"
+    },
+    {
+      "name": "rule-id-prefix",
+      "description": "The prefix of XCCDF rule IDs prepended to check IDs",
+      "required": false,
+      "default": "xccdf_org.ssgproject.content_rule_"
+    },
+    {
+      "name": "value-id-prefix",
+      "description": "The prefix of XCCDF value IDs prepended to parameter IDs",
+      "required": false,
+      "default": "xccdf_org.ssgproject.content_value_"
+    }
+  ]
+}
+EOF
+
+
+checksum=$(sha256sum "${SCRIPT_DIR}/../c2p-plugins/compliance-operator-plugin" | cut -d ' ' -f 1 )
+cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-compliance-operator-manifest.json" << EOF
+{
+  "metadata": {
+    "id": "compliance-operator",
+    "description": "Compliance Operator PVP Plugin",
+    "version": "0.0.1",
+    "types": [
+      "pvp",
+      "remediation"
+    ]
+  },
+  "executablePath": "compliance-operator-plugin",
+  "sha256": "$checksum",
+  "configuration": [
+    {
+      "name": "policy-results-dir",
+      "description": "A directory where exported ComplianceCheckResults and ComplianceRemediations are located",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "output-dir",
+      "description": "The output directory for the TailoredProfile and ScanSettingBinding",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "namespace",
+      "description": "The namespace of the Compliance Operator",
+      "required": false,
+      "default": "openshift-compliance"
+    },
+    {
+      "name": "name",
+      "description": "The name of the TailoredProfile and ScanSettingBinding",
+      "required": false,
+      "default": "compliance-to-policy"
+    },
+    {
+      "name": "profile",
+      "description": "The name of the Profile extended by the TailoredProfile",
+      "required": false
+    },
+    {
+      "name": "product-type",
+      "description": "The product type of a TailoredProfile that does not extend a Profile (Platform or Node)",
+      "required": false,
+      "default": "Platform"
+    },
+    {
+      "name": "product",
+      "description": "The prefix of the Rule and Variable names",
+      "required": false,
+      "default": "ocp4"
+    },
+    {
+      "name": "scan-setting",
+      "description": "The name of the ScanSetting referenced by the ScanSettingBinding",
+      "required": false,
+      "default": "default"
+    }
+  ]
+}
+EOF
+
+
+checksum=$(sha256sum "${SCRIPT_DIR}/../c2p-plugins/sarif-plugin" | cut -d ' ' -f 1 )
+cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-sarif-manifest.json" << EOF
+{
+  "metadata": {
+    "id": "sarif",
+    "description": "SARIF PVP Plugin",
+    "version": "0.0.1",
+    "types": [
+      "pvp"
+    ]
+  },
+  "executablePath": "sarif-plugin",
+  "sha256": "$checksum",
+  "configuration": [
+    {
+      "name": "policy-results-dir",
+      "description": "A directory where SARIF 2.1.0 files are located",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "output-dir",
+      "description": "The output directory for the scanner configuration file",
+      "required": false,
+      "default": ".",
+      "path": true
+    },
+    {
+      "name": "rule-mapping",
+      "description": "A YAML file that maps the rule IDs of the scanners to check IDs",
+      "required": false,
+      "path": true
+    },
+    {
+      "name": "scanner",
+      "description": "The scanner a configuration file is generated for (checkov or kube-linter)",
+      "required": false
+    }
+  ]
+}
+EOF
+
+
+checksum=$(sha256sum "${SCRIPT_DIR}/../c2p-plugins/kube-bench-plugin" | cut -d ' ' -f 1 )
+cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-kube-bench-manifest.json" << EOF
+{
+  "metadata": {
+    "id": "kube-bench",
+    "description": "kube-bench PVP Plugin",
+    "version": "0.0.1",
+    "types": [
+      "pvp"
+    ]
+  },
+  "executablePath": "kube-bench-plugin",
+  "sha256": "$checksum",
+  "configuration": [
+    {
+      "name": "policy-results-dir",
+      "description": "A directory where the kube-bench JSON results of the nodes are located, one file per node",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "output-dir",
+      "description": "The output directory for the kube-bench run configuration and Job",
+      "required": false,
+      "default": ".",
+      "path": true
+    },
+    {
+      "name": "benchmark",
+      "description": "The kube-bench benchmark to run, such as cis-1.8",
+      "required": false
+    },
+    {
+      "name": "check-id-prefix",
+      "description": "The prefix prepended to kube-bench test numbers to get the check IDs",
+      "required": false
+    },
+    {
+      "name": "image",
+      "description": "The kube-bench image of the generated Job",
+      "required": false,
+      "default": "docker.io/aquasec/kube-bench:latest"
+    }
+  ]
+}
+EOF
+
+
+checksum=$(sha256sum "${SCRIPT_DIR}/../c2p-plugins/exec-plugin" | cut -d ' ' -f 1 )
+cat > "${SCRIPT_DIR}/../c2p-plugins/c2p-exec-manifest.json" << EOF
+{
+  "metadata": {
+    "id": "exec",
+    "description": "Executable script PVP Plugin",
+    "version": "0.0.1",
+    "types": [
+      "pvp"
+    ]
+  },
+  "executablePath": "exec-plugin",
+  "sha256": "$checksum",
+  "configuration": [
+    {
+      "name": "policy-dir",
+      "description": "A directory where the check scripts are located, named after the check IDs",
+      "required": true,
+      "path": true
+    },
+    {
+      "name": "evidence-dir",
+      "description": "A directory where the stdout and stderr of the scripts are written",
+      "required": false,
+      "path": true
+    },
+    {
+      "name": "timeout",
+      "description": "The maximum duration of a script run",
+      "required": false,
+      "default": "60s"
+    }
+  ]
+}
+EOF
@@ -0,0 +1,77 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pluginutil provides the configuration checks and helpers shared by the
+// C2P plugins in this repository.
+package pluginutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// CheckRequired returns an error when the value of the named option is not set.
+func CheckRequired(name, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s must be set", name)
+	}
+	return nil
+}
+
+// CheckPath returns an error when the path is set and does not exist.
+func CheckPath(path string) error {
+	if path == "" {
+		return nil
+	}
+	path = filepath.Clean(path)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("path %q: %w", path, err)
+	}
+	return nil
+}
+
+// CheckOneOf returns an error when the value of the named option is set and
+// is not one of the allowed values.
+func CheckOneOf(name, value string, allowed []string) error {
+	if value == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s %q: must be one of %s", name, value, strings.Join(allowed, ", "))
+}
+
+// CheckFileName returns an error when the check ID cannot be used as a file name,
+// so files named after checks stay in their directory.
+func CheckFileName(checkID string) error {
+	if checkID == "" || checkID == "." || checkID == ".." || filepath.Base(checkID) != checkID {
+		return fmt.Errorf("invalid check id %q: must be a file name", checkID)
+	}
+	return nil
+}
+
+// ToDNSCompliant returns the name in lower case with spaces and underscores
+// replaced by dashes, so it can be used as the name of a Kubernetes resource.
+func ToDNSCompliant(name string) string {
+	result := strings.ToLower(name)
+	result = strings.ReplaceAll(result, " ", "-")
+	result = strings.ReplaceAll(result, "_", "-")
+	return result
+}
+
+// MakeProp returns the property with the name and value.
+func MakeProp(name string, value string) policy.Property {
+	return policy.Property{
+		Name:  name,
+		Value: value,
+	}
+}
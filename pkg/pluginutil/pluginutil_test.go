@@ -0,0 +1,42 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package pluginutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRequired(t *testing.T) {
+	require.NoError(t, CheckRequired("output directory", "out"))
+	require.EqualError(t, CheckRequired("output directory", ""), "output directory must be set")
+}
+
+func TestCheckPath(t *testing.T) {
+	require.NoError(t, CheckPath(""))
+	require.NoError(t, CheckPath(t.TempDir()))
+	require.EqualError(t, CheckPath("not-exist/"), "path \"not-exist\": stat not-exist: no such file or directory")
+}
+
+func TestCheckOneOf(t *testing.T) {
+	allowed := []string{"deny", "warn"}
+	require.NoError(t, CheckOneOf("action", "", allowed))
+	require.NoError(t, CheckOneOf("action", "warn", allowed))
+	require.EqualError(t, CheckOneOf("action", "block", allowed), "invalid action \"block\": must be one of deny, warn")
+}
+
+func TestCheckFileName(t *testing.T) {
+	require.NoError(t, CheckFileName("etcd_cert_file"))
+	for _, checkID := range []string{"", ".", "..", "../escape", "nested/check"} {
+		require.Error(t, CheckFileName(checkID), checkID)
+	}
+}
+
+func TestToDNSCompliant(t *testing.T) {
+	require.Equal(t, "managed-kubernetes", ToDNSCompliant("Managed Kubernetes"))
+	require.Equal(t, "etcd-cert-file", ToDNSCompliant("etcd_cert_file"))
+}
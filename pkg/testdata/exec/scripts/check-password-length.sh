@@ -0,0 +1,21 @@
+#!/bin/sh
+# Reports the accounts whose minimum password length is lower than the rule parameter.
+cat << JSON
+{
+  "result": "pass",
+  "subjects": [
+    {
+      "title": "Account: alice",
+      "resource-id": "host-1/alice",
+      "props": {"min-length": "14", "required-length": "${C2P_PARAM_MIN_PASSWORD_LENGTH}"}
+    },
+    {
+      "title": "Account: bob",
+      "resource-id": "host-1/bob",
+      "result": "fail",
+      "reason": "minimum length 8 is lower than ${C2P_PARAM_MIN_PASSWORD_LENGTH}",
+      "props": {"required-length": "${C2P_PARAM_MIN_PASSWORD_LENGTH}", "min-length": "8"}
+    }
+  ]
+}
+JSON
@@ -0,0 +1,5 @@
+#!/bin/sh
+# Reports a failure of the check without subjects, with a non-zero exit code.
+echo "reading sshd_config of check ${C2P_CHECK_ID}" >&2
+echo '{"result": "fail", "reason": "PermitRootLogin is yes"}'
+exit 1
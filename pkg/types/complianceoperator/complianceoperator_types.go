@@ -0,0 +1,118 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package complianceoperator
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// Group is the API group of the Compliance Operator resources.
+	Group = "compliance.openshift.io"
+	// Version is the served version of the Compliance Operator resources.
+	Version = "v1alpha1"
+	// APIVersion is the apiVersion of the Compliance Operator resources.
+	APIVersion = Group + "/" + Version
+
+	// LabelCheckStatus is set on ComplianceCheckResults to their status.
+	LabelCheckStatus = "compliance.openshift.io/check-status"
+	// LabelCheckSeverity is set on ComplianceCheckResults to the severity of their rule.
+	LabelCheckSeverity = "compliance.openshift.io/check-severity"
+	// LabelScanName is set on ComplianceCheckResults to the name of the ComplianceScan that produced them.
+	LabelScanName = "compliance.openshift.io/scan-name"
+	// AnnotationRule is set on ComplianceCheckResults to the name of their rule without the product prefix.
+	AnnotationRule = "compliance.openshift.io/rule"
+	// AnnotationProductType is the product type of a TailoredProfile that does not extend a profile.
+	AnnotationProductType = "compliance.openshift.io/product-type"
+)
+
+// Statuses of a ComplianceCheckResult.
+const (
+	CheckStatusPass          = "PASS"
+	CheckStatusFail          = "FAIL"
+	CheckStatusInfo          = "INFO"
+	CheckStatusManual        = "MANUAL"
+	CheckStatusError         = "ERROR"
+	CheckStatusNotApplicable = "NOT-APPLICABLE"
+	CheckStatusInconsistent  = "INCONSISTENT"
+)
+
+// ComplianceCheckResult is the result of a rule for a ComplianceScan.
+type ComplianceCheckResult struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// ID is the XCCDF rule ID of the check.
+	ID           string   `json:"id"`
+	Status       string   `json:"status"`
+	Severity     string   `json:"severity"`
+	Description  string   `json:"description,omitempty"`
+	Instructions string   `json:"instructions,omitempty"`
+	Rationale    string   `json:"rationale,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+	ValuesUsed   []string `json:"valuesUsed,omitempty"`
+}
+
+// ComplianceRemediation is the fix of a failed ComplianceCheckResult. It has the same name as the result.
+type ComplianceRemediation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComplianceRemediationSpec   `json:"spec,omitempty"`
+	Status ComplianceRemediationStatus `json:"status,omitempty"`
+}
+
+type ComplianceRemediationSpec struct {
+	Apply bool   `json:"apply"`
+	Type  string `json:"type,omitempty"`
+}
+
+type ComplianceRemediationStatus struct {
+	// ApplicationState is one of NotApplied, Applied, Error, Outdated, NeedsReview or MissingDependencies.
+	ApplicationState string `json:"applicationState,omitempty"`
+}
+
+// TailoredProfile enables, disables and sets the variables of rules of a profile.
+type TailoredProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TailoredProfileSpec `json:"spec"`
+}
+
+type TailoredProfileSpec struct {
+	// Extends is the name of the Profile the TailoredProfile is based on.
+	Extends      string              `json:"extends,omitempty"`
+	Title        string              `json:"title"`
+	Description  string              `json:"description"`
+	EnableRules  []RuleReferenceSpec `json:"enableRules,omitempty"`
+	DisableRules []RuleReferenceSpec `json:"disableRules,omitempty"`
+	SetValues    []VariableValueSpec `json:"setValues,omitempty"`
+}
+
+type RuleReferenceSpec struct {
+	Name      string `json:"name"`
+	Rationale string `json:"rationale"`
+}
+
+type VariableValueSpec struct {
+	Name      string `json:"name"`
+	Rationale string `json:"rationale"`
+	Value     string `json:"value"`
+}
+
+// ScanSettingBinding schedules the scans of profiles with a ScanSetting.
+type ScanSettingBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Profiles    []NamedObjectReference `json:"profiles"`
+	SettingsRef *NamedObjectReference  `json:"settingsRef,omitempty"`
+}
+
+type NamedObjectReference struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	APIGroup string `json:"apiGroup"`
+}
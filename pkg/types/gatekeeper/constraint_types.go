@@ -0,0 +1,52 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package gatekeeper
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// ConstraintsGroup is the API group of the Constraints created from ConstraintTemplates.
+	ConstraintsGroup = "constraints.gatekeeper.sh"
+	// ConstraintsVersion is the served version of the Constraints.
+	ConstraintsVersion = "v1beta1"
+)
+
+// Constraint is a Gatekeeper Constraint. Its kind is defined by the ConstraintTemplate it is created from.
+type Constraint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConstraintSpec   `json:"spec,omitempty"`
+	Status ConstraintStatus `json:"status,omitempty"`
+}
+
+// ConstraintSpec defines the resources a Constraint applies to and its parameters.
+type ConstraintSpec struct {
+	// EnforcementAction is one of deny, dryrun or warn.
+	EnforcementAction string                 `json:"enforcementAction,omitempty"`
+	Match             map[string]interface{} `json:"match,omitempty"`
+	Parameters        map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// ConstraintStatus is the audit status of a Constraint.
+type ConstraintStatus struct {
+	AuditTimestamp string `json:"auditTimestamp,omitempty"`
+	// TotalViolations is the number of violations found by the last audit. Only up to
+	// the audit violations limit are listed in Violations.
+	TotalViolations *int64      `json:"totalViolations,omitempty"`
+	Violations      []Violation `json:"violations,omitempty"`
+}
+
+// Violation is a resource that violates a Constraint.
+type Violation struct {
+	EnforcementAction string `json:"enforcementAction,omitempty"`
+	Group             string `json:"group,omitempty"`
+	Version           string `json:"version,omitempty"`
+	Kind              string `json:"kind,omitempty"`
+	Namespace         string `json:"namespace,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Message           string `json:"message,omitempty"`
+}
@@ -0,0 +1,70 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubebench
+
+// Statuses of a kube-bench test result.
+const (
+	StatusPass = "PASS"
+	StatusFail = "FAIL"
+	StatusWarn = "WARN"
+	StatusInfo = "INFO"
+)
+
+// Output is the JSON output of `kube-bench run --json`.
+type Output struct {
+	Controls []Controls `json:"Controls"`
+	Totals   Summary    `json:"Totals"`
+}
+
+// Controls are the results of the checks of a target of a benchmark, such as master or node.
+type Controls struct {
+	ID              string  `json:"id"`
+	Version         string  `json:"version"`
+	DetectedVersion string  `json:"detected_version,omitempty"`
+	Text            string  `json:"text"`
+	NodeType        string  `json:"node_type"`
+	Groups          []Group `json:"tests"`
+	Summary         `json:",inline"`
+}
+
+// Group is a section of checks, such as 1.1 Control Plane Node Configuration Files.
+type Group struct {
+	ID      string  `json:"section"`
+	Type    string  `json:"type,omitempty"`
+	Text    string  `json:"desc"`
+	Checks  []Check `json:"results"`
+	Pass    int     `json:"pass"`
+	Fail    int     `json:"fail"`
+	Warn    int     `json:"warn"`
+	Info    int     `json:"info"`
+	Skipped bool    `json:"skip,omitempty"`
+}
+
+// Check is the result of a check.
+type Check struct {
+	ID             string   `json:"test_number"`
+	Text           string   `json:"test_desc"`
+	Audit          string   `json:"audit"`
+	AuditEnv       string   `json:"AuditEnv,omitempty"`
+	AuditConfig    string   `json:"AuditConfig,omitempty"`
+	Type           string   `json:"type"`
+	Remediation    string   `json:"remediation"`
+	TestInfo       []string `json:"test_info"`
+	Status         string   `json:"status"`
+	ActualValue    string   `json:"actual_value"`
+	Scored         bool     `json:"scored"`
+	IsMultiple     bool     `json:"IsMultiple"`
+	ExpectedResult string   `json:"expected_result"`
+	Reason         string   `json:"reason,omitempty"`
+}
+
+// Summary is the number of checks of each status.
+type Summary struct {
+	Pass int `json:"total_pass"`
+	Fail int `json:"total_fail"`
+	Warn int `json:"total_warn"`
+	Info int `json:"total_info"`
+}
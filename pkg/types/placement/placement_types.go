@@ -0,0 +1,45 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package placement
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Placement defines a rule to select a set of ManagedClusters from the ManagedClusterSets
+// bound to the placement namespace (cluster.open-cluster-management.io/v1beta1).
+//
+// Only the fields needed to select clusters by label are defined here.
+type Placement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the attributes of Placement.
+	Spec PlacementSpec `json:"spec"`
+}
+
+// PlacementSpec defines the attributes of Placement.
+type PlacementSpec struct {
+	// ClusterSets represent the ManagedClusterSets from which the ManagedClusters are selected.
+	// If the slice is empty, ManagedClusters will be selected from the ManagedClusterSets bound to the placement
+	// namespace.
+	ClusterSets []string `json:"clusterSets,omitempty"`
+
+	// Predicates represent a slice of predicates to select ManagedClusters. The predicates are ORed.
+	Predicates []ClusterPredicate `json:"predicates,omitempty"`
+}
+
+// ClusterPredicate represents a predicate to select ManagedClusters.
+type ClusterPredicate struct {
+	// RequiredClusterSelector represents a selector of ManagedClusters by label and claim.
+	RequiredClusterSelector ClusterSelector `json:"requiredClusterSelector,omitempty"`
+}
+
+// ClusterSelector represents the AND of the containing selectors.
+type ClusterSelector struct {
+	// LabelSelector represents a selector of ManagedClusters by label
+	LabelSelector metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
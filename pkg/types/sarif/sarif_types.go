@@ -0,0 +1,115 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package sarif
+
+// Version is the supported version of the SARIF format.
+const Version = "2.1.0"
+
+// Levels of a result.
+const (
+	LevelNone    = "none"
+	LevelNote    = "note"
+	LevelWarning = "warning"
+	LevelError   = "error"
+)
+
+// Kinds of a result.
+const (
+	KindNotApplicable = "notApplicable"
+	KindPass          = "pass"
+	KindFail          = "fail"
+	KindReview        = "review"
+	KindOpen          = "open"
+	KindInformational = "informational"
+)
+
+// Log is the top-level object of a SARIF 2.1.0 file. Only the properties used to assess results are defined.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema,omitempty"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is a single invocation of an analysis tool.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results,omitempty"`
+}
+
+type Tool struct {
+	Driver ToolComponent `json:"driver"`
+}
+
+type ToolComponent struct {
+	Name            string                `json:"name"`
+	Version         string                `json:"version,omitempty"`
+	SemanticVersion string                `json:"semanticVersion,omitempty"`
+	InformationURI  string                `json:"informationUri,omitempty"`
+	Rules           []ReportingDescriptor `json:"rules,omitempty"`
+}
+
+// ReportingDescriptor describes a rule of a tool.
+type ReportingDescriptor struct {
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name,omitempty"`
+	ShortDescription     *Message                `json:"shortDescription,omitempty"`
+	HelpURI              string                  `json:"helpUri,omitempty"`
+	DefaultConfiguration *ReportingConfiguration `json:"defaultConfiguration,omitempty"`
+}
+
+type ReportingConfiguration struct {
+	Level string `json:"level,omitempty"`
+}
+
+type Result struct {
+	RuleID string `json:"ruleId,omitempty"`
+	// RuleIndex is the index of the rule in the rules of the tool driver.
+	RuleIndex *int       `json:"ruleIndex,omitempty"`
+	Rule      *RuleRef   `json:"rule,omitempty"`
+	Kind      string     `json:"kind,omitempty"`
+	Level     string     `json:"level,omitempty"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+// RuleRef references a rule by its ID or index when the ruleId of a result is not set.
+type RuleRef struct {
+	ID    string `json:"id,omitempty"`
+	Index *int   `json:"index,omitempty"`
+}
+
+type Message struct {
+	Text     string `json:"text,omitempty"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation *ArtifactLocation `json:"artifactLocation,omitempty"`
+	Region           *Region           `json:"region,omitempty"`
+}
+
+type ArtifactLocation struct {
+	URI       string `json:"uri,omitempty"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type Region struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type LogicalLocation struct {
+	Name               string `json:"name,omitempty"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
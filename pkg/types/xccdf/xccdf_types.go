@@ -0,0 +1,145 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package xccdf
+
+import "encoding/xml"
+
+const (
+	// Namespace is the XML namespace of XCCDF 1.2.
+	Namespace = "http://checklists.nist.gov/xccdf/1.2"
+	// ARFNamespace is the XML namespace of Asset Reporting Format 1.1 reports.
+	ARFNamespace = "http://scap.nist.gov/schema/asset-reporting-format/1.1"
+
+	// FactFQDN is the target fact holding the fully qualified domain name of the target.
+	FactFQDN = "urn:xccdf:fact:asset:identifier:fqdn"
+	// FactHostname is the target fact holding the host name of the target.
+	FactHostname = "urn:xccdf:fact:asset:identifier:host_name"
+	// FactMAC is the target fact holding a MAC address of the target.
+	FactMAC = "urn:xccdf:fact:asset:identifier:mac"
+)
+
+// TestResult is the result of a scan of a single target.
+type TestResult struct {
+	XMLName       xml.Name        `xml:"TestResult"`
+	ID            string          `xml:"id,attr"`
+	StartTime     string          `xml:"start-time,attr,omitempty"`
+	EndTime       string          `xml:"end-time,attr"`
+	Title         string          `xml:"title,omitempty"`
+	Benchmark     *BenchmarkRef   `xml:"benchmark,omitempty"`
+	Profile       *IDRef          `xml:"profile,omitempty"`
+	Target        []string        `xml:"target"`
+	TargetAddress []string        `xml:"target-address,omitempty"`
+	TargetFacts   []Fact          `xml:"target-facts>fact,omitempty"`
+	RuleResults   []RuleResult    `xml:"rule-result"`
+	Scores        []Score         `xml:"score,omitempty"`
+	Identity      *IdentityResult `xml:"identity,omitempty"`
+}
+
+// BenchmarkRef references the benchmark a TestResult was produced from.
+type BenchmarkRef struct {
+	ID   string `xml:"id,attr,omitempty"`
+	Href string `xml:"href,attr,omitempty"`
+}
+
+// IDRef references an XCCDF item by ID.
+type IDRef struct {
+	IDRef string `xml:"idref,attr"`
+}
+
+// Fact is a named fact about the target of a scan.
+type Fact struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// Score is a benchmark score computed by a scoring model.
+type Score struct {
+	System  string `xml:"system,attr,omitempty"`
+	Maximum string `xml:"maximum,attr,omitempty"`
+	Value   string `xml:",chardata"`
+}
+
+// IdentityResult is the account used to perform a scan.
+type IdentityResult struct {
+	Authenticated bool   `xml:"authenticated,attr"`
+	Privileged    bool   `xml:"privileged,attr"`
+	Value         string `xml:",chardata"`
+}
+
+// RuleResult is the result of a single rule for the target of a TestResult.
+type RuleResult struct {
+	IDRef    string    `xml:"idref,attr"`
+	Role     string    `xml:"role,attr,omitempty"`
+	Severity string    `xml:"severity,attr,omitempty"`
+	Time     string    `xml:"time,attr,omitempty"`
+	Weight   string    `xml:"weight,attr,omitempty"`
+	Result   string    `xml:"result"`
+	Idents   []Ident   `xml:"ident,omitempty"`
+	Messages []Message `xml:"message,omitempty"`
+	Checks   []Check   `xml:"check,omitempty"`
+}
+
+// Ident is an identifier of a rule in an external system, such as a CCE.
+type Ident struct {
+	System string `xml:"system,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// Message is a message reported by the checking engine.
+type Message struct {
+	Severity string `xml:"severity,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+// Check references the check content used to evaluate a rule.
+type Check struct {
+	System     string       `xml:"system,attr"`
+	ContentRef []ContentRef `xml:"check-content-ref,omitempty"`
+}
+
+// ContentRef references check content in a checking system document.
+type ContentRef struct {
+	Name string `xml:"name,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+// Tailoring customizes the profiles of a benchmark without modifying it.
+type Tailoring struct {
+	XMLName   xml.Name          `xml:"http://checklists.nist.gov/xccdf/1.2 Tailoring"`
+	ID        string            `xml:"id,attr"`
+	Benchmark *BenchmarkRef     `xml:"benchmark,omitempty"`
+	Version   TailoringVersion  `xml:"version"`
+	Profiles  []TailoredProfile `xml:"Profile"`
+}
+
+// TailoringVersion is the version of a Tailoring document.
+type TailoringVersion struct {
+	Time  string `xml:"time,attr"`
+	Value string `xml:",chardata"`
+}
+
+// TailoredProfile is a profile defined by a Tailoring document.
+type TailoredProfile struct {
+	ID          string     `xml:"id,attr"`
+	Extends     string     `xml:"extends,attr,omitempty"`
+	Title       string     `xml:"title"`
+	Description string     `xml:"description,omitempty"`
+	Selects     []Select   `xml:"select"`
+	SetValues   []SetValue `xml:"set-value"`
+}
+
+// Select selects or deselects a rule or group.
+type Select struct {
+	IDRef    string `xml:"idref,attr"`
+	Selected bool   `xml:"selected,attr"`
+}
+
+// SetValue sets the value of an XCCDF Value.
+type SetValue struct {
+	IDRef string `xml:"idref,attr"`
+	Value string `xml:",chardata"`
+}
@@ -0,0 +1,36 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Client must return an implementation of the corresponding interface that communicates over an RPC client.
+var _ policy.Collector = (*collectorClient)(nil)
+
+type collectorClient struct {
+	client proto.EvidenceCollectorClient
+}
+
+func (c *collectorClient) Configure(configuration map[string]string) error {
+	request := proto.ConfigureRequest{
+		Settings: configuration,
+	}
+	_, err := c.client.Configure(context.Background(), &request)
+	return err
+}
+
+func (c *collectorClient) Collect(p policy.Policy) ([]policy.Evidence, error) {
+	resp, err := c.client.Collect(context.Background(), PolicyToProto(p))
+	if err != nil {
+		return nil, err
+	}
+	return NewEvidenceFromProto(resp.Evidence), nil
+}
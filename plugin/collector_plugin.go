@@ -0,0 +1,46 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Plugin must return an RPC server for this plugin type.
+var _ proto.EvidenceCollectorServer = (*collectorService)(nil)
+
+type collectorService struct {
+	proto.UnimplementedEvidenceCollectorServer
+	Impl policy.Collector
+}
+
+func FromCollector(c policy.Collector) proto.EvidenceCollectorServer {
+	return &collectorService{
+		Impl: c,
+	}
+}
+
+func (c *collectorService) Configure(ctx context.Context, request *proto.ConfigureRequest) (*proto.ConfigureResponse, error) {
+	if err := c.Impl.Configure(request.Settings); err != nil {
+		return &proto.ConfigureResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.ConfigureResponse{}, nil
+}
+
+func (c *collectorService) Collect(ctx context.Context, request *proto.PolicyRequest) (*proto.CollectResponse, error) {
+	policy := NewPolicyFromProto(request)
+	evidence, err := c.Impl.Collect(policy)
+	if err != nil {
+		return &proto.CollectResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.CollectResponse{Evidence: EvidenceToProto(evidence)}, nil
+}
@@ -0,0 +1,110 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"testing"
+
+	hplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var testEvidence = []policy.Evidence{
+	{
+		Name:      "policyreports.yaml",
+		MediaType: "application/yaml",
+		Content:   []byte("items: []\n"),
+		Digest:    policy.Digest([]byte("items: []\n")),
+		Href:      "evidence/test-collector/policyreports.yaml",
+		Props: []policy.Property{
+			{
+				Name:  "kind",
+				Value: "PolicyReportList",
+			},
+		},
+	},
+}
+
+// testCollector records the policy and returns the configured evidence.
+type testCollector struct {
+	configuration map[string]string
+	policy        policy.Policy
+	evidence      []policy.Evidence
+}
+
+func (c *testCollector) Configure(configuration map[string]string) error {
+	c.configuration = configuration
+	return nil
+}
+
+func (c *testCollector) Collect(p policy.Policy) ([]policy.Evidence, error) {
+	c.policy = p
+	return c.evidence, nil
+}
+
+// testEvaluator evaluates the evidence it receives.
+type testEvaluator struct {
+	configuredProvider
+	evidence []policy.Evidence
+}
+
+func (e *testEvaluator) Evaluate(p policy.Policy, evidence []policy.Evidence) (policy.PVPResult, error) {
+	e.evidence = evidence
+	return testPolicyPvpResult, nil
+}
+
+func TestCollectorPlugin(t *testing.T) {
+	impl := &testCollector{evidence: testEvidence}
+	client, _ := hplugin.TestPluginGRPCConn(t, false, map[string]hplugin.Plugin{
+		CollectorPluginName: &CollectorPlugin{Impl: impl},
+	})
+	t.Cleanup(func() { _ = client.Close() })
+	raw, err := client.Dispense(CollectorPluginName)
+	require.NoError(t, err)
+	collector := raw.(policy.Collector)
+
+	require.NoError(t, collector.Configure(map[string]string{"option": "value"}))
+	require.Equal(t, map[string]string{"option": "value"}, impl.configuration)
+
+	evidence, err := collector.Collect(testPolicy)
+	require.NoError(t, err)
+	require.Equal(t, testEvidence, evidence)
+	require.Equal(t, testPolicy, impl.policy)
+}
+
+func TestPVPPluginEvaluate(t *testing.T) {
+	impl := &testEvaluator{}
+	client, _ := hplugin.TestPluginGRPCConn(t, false, map[string]hplugin.Plugin{
+		PVPPluginName: &PVPPlugin{Impl: impl},
+	})
+	t.Cleanup(func() { _ = client.Close() })
+	raw, err := client.Dispense(PVPPluginName)
+	require.NoError(t, err)
+	evaluator, ok := raw.(policy.Evaluator)
+	require.True(t, ok)
+
+	result, err := evaluator.Evaluate(testPolicy, testEvidence)
+	require.NoError(t, err)
+	require.Equal(t, testPolicyPvpResult, result)
+	require.Equal(t, testEvidence, impl.evidence)
+
+	// A provider that is not an evaluator does not support the evaluation
+	client, _ = hplugin.TestPluginGRPCConn(t, false, map[string]hplugin.Plugin{
+		PVPPluginName: &PVPPlugin{Impl: &configuredProvider{}},
+	})
+	t.Cleanup(func() { _ = client.Close() })
+	raw, err = client.Dispense(PVPPluginName)
+	require.NoError(t, err)
+	_, err = raw.(policy.Evaluator).Evaluate(testPolicy, testEvidence)
+	require.ErrorIs(t, err, ErrEvaluationNotSupported)
+}
+
+func TestEvidenceRoundTrip(t *testing.T) {
+	require.Equal(t, testEvidence, NewEvidenceFromProto(EvidenceToProto(testEvidence)))
+	require.Nil(t, NewEvidenceFromProto(EvidenceToProto(nil)))
+}
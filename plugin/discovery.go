@@ -22,6 +22,7 @@ const (
 type findOptions struct {
 	providerIds []string
 	pluginType  string
+	verifier    *Verifier
 }
 
 // FindOption represents a filtering criteria for plugin discovery in plugin.FindPlugins.
@@ -42,28 +43,52 @@ func WithPluginType(pluginType string) FindOption {
 	}
 }
 
+// WithVerifier verifies the manifest signatures of the plugins
+// and enforces the SignaturePolicy of the Verifier.
+func WithVerifier(verifier *Verifier) FindOption {
+	return func(options *findOptions) {
+		options.verifier = verifier
+	}
+}
+
 // FindPlugins searches for plugins in the specified directory, optionally applying filters.
 //
-// The function expects plugin manifests in the format "c2p-$PLUGIN-ID-manifest.json".
+// The function expects plugin manifests in the format "c2p-$PLUGIN-ID-manifest.json", either at the top
+// level of the directory or in a per-plugin subdirectory named after the plugin ID.
 //
 // Available filters:
 //   - `WithProviderIds`: Filters by a list of provider IDs.
 //   - `WithPluginType`: Filters by plugin type.
 //
+// With `WithVerifier`, plugins with a manifest that is not signed by a trusted key
+// are refused under SignaturePolicyRequire.
+//
 // If no filters are applied, all discovered plugins are returned.
 func FindPlugins(pluginDir string, opts ...FindOption) (Manifests, error) {
+	return FindPluginsInPath([]string{pluginDir}, opts...)
+}
+
+// FindPluginsInPath searches for plugins in the directories of a search path, with the same
+// filters as FindPlugins. Directories that do not exist are skipped.
+//
+// When a plugin ID is found more than once, the directory that comes first in the search path
+// takes precedence, and a manifest at the top level of a directory takes precedence over a
+// manifest in a per-plugin subdirectory. The manifests that are ignored are listed in
+// Manifest.Shadowed, and Manifest.ManifestPath reports where the plugin was found.
+func FindPluginsInPath(searchPath []string, opts ...FindOption) (Manifests, error) {
 	config := &findOptions{}
 	for _, opt := range opts {
 		opt(config)
 	}
 
-	matchingPlugins, err := findAllPluginMatches(pluginDir)
+	location := strings.Join(searchPath, string(os.PathListSeparator))
+	matchingPlugins, err := findPluginMatchesInPath(searchPath)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(matchingPlugins) == 0 {
-		return nil, fmt.Errorf("%w in %s", ErrPluginsNotFound, pluginDir)
+		return nil, fmt.Errorf("%w in %s", ErrPluginsNotFound, location)
 	}
 
 	collectedManifests := make(Manifests)
@@ -71,7 +96,7 @@ func FindPlugins(pluginDir string, opts ...FindOption) (Manifests, error) {
 
 	// Filter plugins by provider IDs if provided
 	if len(config.providerIds) != 0 {
-		filteredIds := make(map[string]string)
+		filteredIds := make(map[string][]pluginMatch)
 		for _, providerId := range config.providerIds {
 			if _, ok := matchingPlugins[providerId]; !ok {
 				errs = append(errs, &NotFoundError{providerId})
@@ -87,9 +112,9 @@ func FindPlugins(pluginDir string, opts ...FindOption) (Manifests, error) {
 	}
 
 	// Process remaining plugins, filtering by plugin type if necessary
-	for id, manifestName := range matchingPlugins {
-		manifestPath := filepath.Join(pluginDir, manifestName)
-		manifest, err := readManifestFile(id, manifestPath)
+	for id, matches := range matchingPlugins {
+		match := matches[0]
+		manifest, manifestData, err := readManifestData(id, match.path())
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -98,7 +123,7 @@ func FindPlugins(pluginDir string, opts ...FindOption) (Manifests, error) {
 		// Ensure consistent naming for the plugin identifier and
 		// that the name meets identifier criteria.
 		if !manifest.ValidateID() || manifest.ID != id {
-			errs = append(errs, fmt.Errorf("invalid plugin id %q in manifest %s", manifest.ID, manifestName))
+			errs = append(errs, fmt.Errorf("invalid plugin id %q in manifest %s", manifest.ID, match.name))
 			continue
 		}
 
@@ -106,11 +131,20 @@ func FindPlugins(pluginDir string, opts ...FindOption) (Manifests, error) {
 			continue
 		}
 
-		// sanitize the executable path in the manifest
-		if manifestErr := manifest.ResolvePath(pluginDir); manifestErr != nil {
+		if err := config.verifier.check(&manifest, match.path(), manifestData); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// sanitize the executable path in the manifest, which must be
+		// under the directory holding the manifest
+		if manifestErr := manifest.resolve(match.dir); manifestErr != nil {
 			errs = append(errs, manifestErr)
 			continue
 		}
+		for _, shadowed := range matches[1:] {
+			manifest.Shadowed = append(manifest.Shadowed, shadowed.path())
+		}
 		collectedManifests[id] = manifest
 	}
 
@@ -119,29 +153,76 @@ func FindPlugins(pluginDir string, opts ...FindOption) (Manifests, error) {
 	}
 
 	if len(collectedManifests) == 0 {
-		return nil, fmt.Errorf("%w in %s with matching criteria", ErrPluginsNotFound, pluginDir)
+		return nil, fmt.Errorf("%w in %s with matching criteria", ErrPluginsNotFound, location)
 	}
 
 	return collectedManifests, nil
 }
 
+// pluginMatch is a plugin manifest found in a plugin directory.
+type pluginMatch struct {
+	// dir is the directory holding the manifest.
+	dir string
+	// name is the file name of the manifest.
+	name string
+}
+
+func (m pluginMatch) path() string {
+	return filepath.Join(m.dir, m.name)
+}
+
+// findPluginMatchesInPath locates the manifests in each directory of the search path and
+// returns the matches for each plugin ID in order of precedence.
+func findPluginMatchesInPath(searchPath []string) (map[string][]pluginMatch, error) {
+	matchingPlugins := make(map[string][]pluginMatch)
+	for _, pluginDir := range searchPath {
+		matches, err := findAllPluginMatches(pluginDir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for id, dirMatches := range matches {
+			matchingPlugins[id] = append(matchingPlugins[id], dirMatches...)
+		}
+	}
+	return matchingPlugins, nil
+}
+
 // findAllPluginsMatches locates the manifests in the plugin directory that match
-// the prefix naming scheme and returns the plugin ID and file name.
-func findAllPluginMatches(pluginDir string) (map[string]string, error) {
+// the prefix naming scheme and returns the matches by plugin ID. A manifest at the top level
+// comes before a manifest in the subdirectory named after the plugin ID.
+func findAllPluginMatches(pluginDir string) (map[string][]pluginMatch, error) {
 	items, err := os.ReadDir(pluginDir)
 	if err != nil {
 		return nil, err
 	}
 
-	matchingPlugins := make(map[string]string)
+	matchingPlugins := make(map[string][]pluginMatch)
+	var subdirs []string
 	for _, item := range items {
 		name := item.Name()
-		if !strings.HasPrefix(name, manifestPrefix) {
+		if item.IsDir() {
+			subdirs = append(subdirs, name)
+			continue
+		}
+		if !strings.HasPrefix(name, manifestPrefix) || !strings.HasSuffix(name, manifestSuffix) {
 			continue
 		}
 		trimmedName := strings.TrimPrefix(name, manifestPrefix)
 		trimmedName = strings.TrimSuffix(trimmedName, manifestSuffix)
-		matchingPlugins[trimmedName] = name
+		matchingPlugins[trimmedName] = append(matchingPlugins[trimmedName], pluginMatch{dir: pluginDir, name: name})
+	}
+
+	for _, id := range subdirs {
+		subdir := filepath.Join(pluginDir, id)
+		name := ManifestFileName(id)
+		info, err := os.Stat(filepath.Join(subdir, name))
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		matchingPlugins[id] = append(matchingPlugins[id], pluginMatch{dir: subdir, name: name})
 	}
 	return matchingPlugins, nil
 }
@@ -159,20 +240,25 @@ func manifestMatchesType(manifest Manifest, pluginType string) bool {
 
 // readManifestFile reads and parses the manifest from JSON.
 func readManifestFile(pluginName, manifestPath string) (Manifest, error) {
-	manifestFile, err := os.Open(manifestPath)
+	manifest, _, err := readManifestData(pluginName, manifestPath)
+	return manifest, err
+}
+
+// readManifestData reads and parses the manifest from JSON and returns
+// the content of the file for signature verification.
+func readManifestData(pluginName, manifestPath string) (Manifest, []byte, error) {
+	data, err := os.ReadFile(manifestPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return Manifest{}, &ManifestNotFoundError{File: manifestPath, PluginID: pluginName}
+			return Manifest{}, nil, &ManifestNotFoundError{File: manifestPath, PluginID: pluginName}
 		}
-		return Manifest{}, err
+		return Manifest{}, nil, err
 	}
-	defer manifestFile.Close()
 
-	jsonParser := json.NewDecoder(manifestFile)
 	var manifest Manifest
-	err = jsonParser.Decode(&manifest)
-	if err != nil {
-		return Manifest{}, err
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, nil, err
 	}
-	return manifest, nil
+	manifest.manifestPath = manifestPath
+	return manifest, data, nil
 }
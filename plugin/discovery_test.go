@@ -6,6 +6,8 @@
 package plugin
 
 import (
+	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 
@@ -129,3 +131,65 @@ func TestFindPlugins(t *testing.T) {
 		})
 	}
 }
+
+func TestFindPluginsInPath(t *testing.T) {
+	projectDir := t.TempDir()
+	userDir := t.TempDir()
+	systemDir := t.TempDir()
+	missingDir := filepath.Join(t.TempDir(), "missing")
+
+	// the same plugin at the top level and in the subdirectory of the project
+	installTestPlugin(t, projectDir, "shared")
+	installTestPlugin(t, filepath.Join(projectDir, "shared"), "shared")
+	installTestPlugin(t, userDir, "shared")
+	installTestPlugin(t, filepath.Join(userDir, "nested"), "nested")
+	installTestPlugin(t, systemDir, "system")
+	// a subdirectory that is not named after the plugin is ignored
+	installTestPlugin(t, filepath.Join(systemDir, "other"), "ignored")
+
+	searchPath := []string{missingDir, projectDir, userDir, systemDir}
+	manifests, err := FindPluginsInPath(searchPath)
+	require.NoError(t, err)
+	require.Len(t, manifests, 3)
+
+	shared := manifests["shared"]
+	require.Equal(t, filepath.Join(projectDir, "c2p-shared-manifest.json"), shared.ManifestPath())
+	require.Equal(t, []string{
+		filepath.Join(projectDir, "shared", "c2p-shared-manifest.json"),
+		filepath.Join(userDir, "c2p-shared-manifest.json"),
+	}, shared.Shadowed)
+	require.Equal(t, filepath.Join(projectDir, "shared-plugin"), shared.ExecutablePath)
+
+	// the executable is resolved in the directory holding the manifest
+	nested := manifests["nested"]
+	require.Equal(t, filepath.Join(userDir, "nested", "c2p-nested-manifest.json"), nested.ManifestPath())
+	require.Equal(t, filepath.Join(userDir, "nested", "nested-plugin"), nested.ExecutablePath)
+	require.Empty(t, nested.Shadowed)
+
+	require.Equal(t, filepath.Join(systemDir, "c2p-system-manifest.json"), manifests["system"].ManifestPath())
+
+	// precedence follows the search path order
+	manifests, err = FindPluginsInPath([]string{userDir, projectDir}, WithProviderIds([]string{"shared"}))
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(userDir, "c2p-shared-manifest.json"), manifests["shared"].ManifestPath())
+
+	_, err = FindPluginsInPath([]string{missingDir})
+	require.EqualError(t, err, "no plugins found in "+missingDir)
+}
+
+func TestFindPluginsInPathExecutableOutsideDir(t *testing.T) {
+	pluginDir := t.TempDir()
+	installTestPlugin(t, pluginDir, "outside")
+	subdir := filepath.Join(pluginDir, "escape")
+	require.NoError(t, os.MkdirAll(subdir, 0750))
+	manifest := `{"metadata": {"id": "escape", "types": ["pvp"]}, "executablePath": "../outside-plugin"}`
+	require.NoError(t, os.WriteFile(filepath.Join(subdir, "c2p-escape-manifest.json"), []byte(manifest), 0600))
+
+	_, err := FindPlugins(pluginDir, WithProviderIds([]string{"escape"}))
+	require.ErrorContains(t, err, "relative path ../outside-plugin is not under the plugin directory "+subdir)
+
+	manifest = `{"metadata": {"id": "escape", "types": ["pvp"]}, "executablePath": "` + filepath.Join(pluginDir, "outside-plugin") + `"}`
+	require.NoError(t, os.WriteFile(filepath.Join(subdir, "c2p-escape-manifest.json"), []byte(manifest), 0600))
+	_, err = FindPlugins(pluginDir, WithProviderIds([]string{"escape"}))
+	require.ErrorContains(t, err, "is not under the plugin directory "+subdir)
+}
@@ -3,12 +3,18 @@ package plugin
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // ErrPluginsNotFound should be used when there are no discoverable
 // in the defined location.
 var ErrPluginsNotFound = errors.New("no plugins found")
 
+// ErrEvaluationNotSupported is returned by Evaluate when the
+// policy plugin does not evaluate collected evidence, or cannot
+// evaluate the evidence it was given.
+var ErrEvaluationNotSupported = errors.New("plugin does not evaluate collected evidence")
+
 // NotFoundError indicates that a requested plugin if not found
 // in the list of discovered plugins.
 type NotFoundError struct {
@@ -29,3 +35,49 @@ type ManifestNotFoundError struct {
 func (e *ManifestNotFoundError) Error() string {
 	return fmt.Sprintf("failed to open manifest file %s for plugin %q", e.File, e.PluginID)
 }
+
+// ChecksumError indicates that the SHA256 hash of a plugin executable does not
+// match the checksum in its manifest.
+type ChecksumError struct {
+	PluginID string
+	Want     string
+	Got      string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum mismatch for plugin %q: manifest has %s, executable has %s", e.PluginID, e.Want, e.Got)
+}
+
+// SignatureError indicates that the manifest of a plugin does not have
+// a valid signature from a trusted key.
+type SignatureError struct {
+	PluginID string
+	Reason   string
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf("signature verification failed for plugin %q: %s", e.PluginID, e.Reason)
+}
+
+// LogTailError wraps an error returned for a plugin with the
+// last lines of the plugin log stream.
+type LogTailError struct {
+	PluginID string
+	Lines    []string
+	Err      error
+}
+
+func (e *LogTailError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Err.Error())
+	fmt.Fprintf(&b, "\nlast %d log lines of plugin %q:", len(e.Lines), e.PluginID)
+	for _, line := range e.Lines {
+		b.WriteString("\n  ")
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+func (e *LogTailError) Unwrap() error {
+	return e.Err
+}
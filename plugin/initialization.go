@@ -13,10 +13,30 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
+// MaxMessageSize is the maximum size of the gRPC messages exchanged between the host
+// and the plugins. It raises the gRPC default of 4 MiB so the evidence passed to
+// Evaluate and returned by Collect fits in a message.
+const MaxMessageSize = 256 << 20
+
+// grpcServer returns a gRPC server that accepts messages up to MaxMessageSize.
+func grpcServer(opts []grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.MaxRecvMsgSize(MaxMessageSize), grpc.MaxSendMsgSize(MaxMessageSize))
+	return grpc.NewServer(opts...)
+}
+
+// grpcDialOptions returns the options for the connections to plugins,
+// so messages up to MaxMessageSize are accepted.
+func grpcDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxMessageSize), grpc.MaxCallSendMsgSize(MaxMessageSize)),
+	}
+}
+
 // ServeConfig defines the configuration for plugin
 // registration.
 type ServeConfig struct {
@@ -31,12 +51,45 @@ func Register(config ServeConfig) {
 		HandshakeConfig: Handshake,
 		Plugins:         config.PluginSet,
 		Logger:          config.Logger,
-		GRPCServer:      plugin.DefaultGRPCServer,
+		GRPCServer:      grpcServer,
 	})
 }
 
-// Cleanup clean up all plugin clients created by the ClientFactory.
-var Cleanup func() = plugin.CleanupClients
+// Cleanup clean up all plugin clients created by the ClientFactory
+// and closes the connections to remote plugins.
+var Cleanup func() = cleanupClients
+
+type clientOptions struct {
+	verifier *Verifier
+	sandbox  *Sandbox
+	logs     *PluginLogs
+}
+
+// ClientOption represents an option for the plugin clients created by ClientFactory.
+type ClientOption func(options *clientOptions)
+
+// WithClientVerifier verifies the manifest signature again before launching a plugin and enforces
+// the SignaturePolicy of the Verifier.
+func WithClientVerifier(verifier *Verifier) ClientOption {
+	return func(options *clientOptions) {
+		options.verifier = verifier
+	}
+}
+
+// WithSandbox launches plugins in the given Sandbox.
+func WithSandbox(sandbox *Sandbox) ClientOption {
+	return func(options *clientOptions) {
+		options.sandbox = sandbox
+	}
+}
+
+// WithPluginLogs captures the stderr log stream of each plugin in the PluginLogs,
+// in addition to forwarding it to the host logger.
+func WithPluginLogs(logs *PluginLogs) ClientOption {
+	return func(options *clientOptions) {
+		options.logs = logs
+	}
+}
 
 // ClientFactoryFunc defines a function signature for creating
 // new go-plugin clients.
@@ -47,9 +100,17 @@ type ClientFactoryFunc func(manifest Manifest) (*plugin.Client, error)
 //
 // The returned factory function takes a Manifest object as input and returns
 // a new plugin client configured with the specified logger, allowed protocols,
-// and security settings.
-func ClientFactory(logger hclog.Logger) ClientFactoryFunc {
+// and security settings. With `WithSandbox`, the plugin is launched with a scrubbed
+// environment, a dedicated working directory and resource limits.
+func ClientFactory(logger hclog.Logger, opts ...ClientOption) ClientFactoryFunc {
+	options := &clientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
 	return func(manifest Manifest) (*plugin.Client, error) {
+		if err := options.verifier.recheck(manifest); err != nil {
+			return nil, err
+		}
 		manifestSum, err := hex.DecodeString(manifest.Checksum)
 		if err != nil {
 			return nil, err
@@ -61,12 +122,23 @@ func ClientFactory(logger hclog.Logger) ClientFactoryFunc {
 			Managed:          true,
 			AutoMTLS:         true,
 			AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
-			Cmd:              exec.Command(manifest.ExecutablePath),
 			Plugins:          SupportedPlugins,
-			SecureConfig: &plugin.SecureConfig{
+			GRPCDialOptions:  grpcDialOptions(),
+		}
+		if options.logs != nil {
+			config.Stderr = options.logs.Writer(manifest.ID)
+		}
+		if options.sandbox != nil {
+			// The sandbox runner verifies the checksum before launch
+			config.RunnerFunc = options.sandbox.runnerFunc(manifest, manifestSum)
+			config.SkipHostEnv = true
+		} else {
+			// #nosec G204 -- the executable and args come from the verified manifest
+			config.Cmd = exec.Command(manifest.ExecutablePath, manifest.Args...)
+			config.SecureConfig = &plugin.SecureConfig{
 				Checksum: manifestSum,
 				Hash:     sha256.New(),
-			},
+			}
 		}
 
 		client := plugin.NewClient(config)
@@ -74,22 +146,92 @@ func ClientFactory(logger hclog.Logger) ClientFactoryFunc {
 	}
 }
 
+// startClient starts the plugin and returns its protocol client. When the plugin fails
+// to start, the client is killed, which waits until the plugin stderr is read, so the
+// captured plugin logs are complete when the error is returned.
+func startClient(id string, client *plugin.Client) (plugin.ClientProtocol, error) {
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, fmt.Errorf("failed to get plugin client for %s: %w", id, err)
+	}
+	return rpcClient, nil
+}
+
 // NewPolicyPlugin dispenses a new instance of a policy plugin.
 func NewPolicyPlugin(pluginManifest Manifest, createClient ClientFactoryFunc) (policy.Provider, error) {
 	client, err := createClient(pluginManifest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create plugin client for %s: %w", pluginManifest.ID, err)
 	}
-	rpcClient, err := client.Client()
+	return dispensePolicyPlugin(pluginManifest.ID, client)
+}
+
+func dispensePolicyPlugin(id string, client *plugin.Client) (policy.Provider, error) {
+	rpcClient, err := startClient(id, client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get plugin client for %s: %w", pluginManifest.ID, err)
+		return nil, err
 	}
 
 	raw, err := rpcClient.Dispense(PVPPluginName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to dispense plugin %s: %w", pluginManifest.ID, err)
+		return nil, fmt.Errorf("failed to dispense plugin %s: %w", id, err)
 	}
 
 	p := raw.(policy.Provider)
 	return p, nil
 }
+
+// NewRemediationPlugin dispenses a new instance of a remediation plugin.
+func NewRemediationPlugin(pluginManifest Manifest, createClient ClientFactoryFunc) (policy.Remediator, error) {
+	client, err := createClient(pluginManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugin client for %s: %w", pluginManifest.ID, err)
+	}
+	rpcClient, err := startClient(pluginManifest.ID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rpcClient.Dispense(RemediationPluginName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dispense plugin %s: %w", pluginManifest.ID, err)
+	}
+	return raw.(policy.Remediator), nil
+}
+
+// NewCollectorPlugin dispenses a new instance of an evidence collector plugin.
+func NewCollectorPlugin(pluginManifest Manifest, createClient ClientFactoryFunc) (policy.Collector, error) {
+	client, err := createClient(pluginManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugin client for %s: %w", pluginManifest.ID, err)
+	}
+	rpcClient, err := startClient(pluginManifest.ID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rpcClient.Dispense(CollectorPluginName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dispense plugin %s: %w", pluginManifest.ID, err)
+	}
+	return raw.(policy.Collector), nil
+}
+
+// NewProcessorPlugin dispenses a new instance of a result processor plugin.
+func NewProcessorPlugin(pluginManifest Manifest, createClient ClientFactoryFunc) (policy.Processor, error) {
+	client, err := createClient(pluginManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugin client for %s: %w", pluginManifest.ID, err)
+	}
+	rpcClient, err := startClient(pluginManifest.ID, client)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rpcClient.Dispense(ProcessorPluginName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dispense plugin %s: %w", pluginManifest.ID, err)
+	}
+	return raw.(policy.Processor), nil
+}
@@ -0,0 +1,307 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ManifestFileName returns the name of the manifest file of a plugin in the
+// plugin directory.
+func ManifestFileName(pluginID string) string {
+	return manifestPrefix + pluginID + manifestSuffix
+}
+
+// Checksum returns the hex encoded SHA256 hash of the file content.
+func Checksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type installOptions struct {
+	overwrite bool
+}
+
+// InstallOption represents an option for plugin installation in plugin.Install.
+type InstallOption func(options *installOptions)
+
+// WithOverwrite replaces the executable and the manifest of an installed plugin
+// with the same ID.
+func WithOverwrite() InstallOption {
+	return func(options *installOptions) {
+		options.overwrite = true
+	}
+}
+
+// Install copies the plugin executable at binaryPath into the plugin directory and writes
+// its manifest with the computed checksum.
+//
+// The metadata and configuration options are taken from the given manifest. The executable
+// is named after the manifest ExecutablePath, or after the given binary if it is not set.
+func Install(pluginDir, binaryPath string, manifest Manifest, opts ...InstallOption) (Manifest, error) {
+	config := &installOptions{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if !manifest.ValidateID() {
+		return Manifest{}, fmt.Errorf("invalid plugin id %q", manifest.ID)
+	}
+	if len(manifest.Types) == 0 {
+		return Manifest{}, fmt.Errorf("plugin %s must implement at least one plugin type", manifest.ID)
+	}
+	for _, typ := range manifest.Types {
+		if _, ok := SupportedPlugins[typ]; !ok {
+			return Manifest{}, fmt.Errorf("unsupported plugin type %q for plugin %s", typ, manifest.ID)
+		}
+	}
+
+	executableName := manifest.ExecutablePath
+	if executableName == "" {
+		executableName = filepath.Base(binaryPath)
+	}
+	if filepath.Base(executableName) != executableName {
+		return Manifest{}, fmt.Errorf("executable path %s must be a file name in the plugin directory", executableName)
+	}
+
+	manifestPath := filepath.Join(pluginDir, ManifestFileName(manifest.ID))
+	executablePath := filepath.Join(pluginDir, executableName)
+	if !config.overwrite {
+		for _, path := range []string{manifestPath, executablePath} {
+			if _, err := os.Stat(path); err == nil {
+				return Manifest{}, fmt.Errorf("%s already exists in the plugin directory", filepath.Base(path))
+			}
+		}
+	}
+
+	if err := os.MkdirAll(pluginDir, 0750); err != nil {
+		return Manifest{}, fmt.Errorf("failed to create plugin directory: %w", err)
+	}
+	if err := copyExecutable(binaryPath, executablePath); err != nil {
+		return Manifest{}, fmt.Errorf("failed to copy plugin executable: %w", err)
+	}
+	checksum, err := Checksum(executablePath)
+	if err != nil {
+		return Manifest{}, err
+	}
+	manifest.ExecutablePath = executableName
+	manifest.Checksum = checksum
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := os.WriteFile(manifestPath, append(data, '\n'), 0600); err != nil {
+		return Manifest{}, fmt.Errorf("failed to write plugin manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// copyExecutable copies the file at src to dst through a temporary file in the
+// destination directory, so an installed executable is never partially written.
+func copyExecutable(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a file", src)
+	}
+
+	out, err := os.CreateTemp(filepath.Dir(dst), ".install-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name())
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	// #nosec G302 -- the plugin must be executable
+	if err := os.Chmod(out.Name(), 0755); err != nil {
+		return err
+	}
+	return os.Rename(out.Name(), dst)
+}
+
+// Uninstall removes the manifest of a plugin, its signature and its executable from the plugin directory.
+// The executable is kept if it is referenced by the manifest of another plugin. A per-plugin
+// subdirectory is removed when it is left empty.
+func Uninstall(pluginDir, pluginID string) error {
+	matchingPlugins, err := findAllPluginMatches(pluginDir)
+	if err != nil {
+		return err
+	}
+	matches, ok := matchingPlugins[pluginID]
+	if !ok {
+		return &NotFoundError{PluginID: pluginID}
+	}
+	match := matches[0]
+	manifest, err := readManifestFile(pluginID, match.path())
+	if err != nil {
+		return err
+	}
+
+	executablePath, err := executablePathInDir(match.dir, manifest.ExecutablePath)
+	if err != nil {
+		return err
+	}
+
+	shared := false
+	for id, others := range matchingPlugins {
+		for _, other := range others {
+			if other == match {
+				continue
+			}
+			otherManifest, err := readManifestFile(id, other.path())
+			if err != nil {
+				continue
+			}
+			otherPath, err := executablePathInDir(other.dir, otherManifest.ExecutablePath)
+			if err == nil && otherPath == executablePath {
+				shared = true
+			}
+		}
+	}
+
+	if !shared {
+		if err := os.Remove(executablePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove plugin executable: %w", err)
+		}
+	}
+	if err := os.Remove(match.path()); err != nil {
+		return fmt.Errorf("failed to remove plugin manifest: %w", err)
+	}
+	if err := os.Remove(match.path() + signatureSuffix); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove plugin manifest signature: %w", err)
+	}
+	if match.dir != pluginDir {
+		// fails if the subdirectory holds other files
+		_ = os.Remove(match.dir)
+	}
+	return nil
+}
+
+// executablePathInDir returns the absolute path of a manifest executable path and
+// checks that it is under the plugin directory.
+func executablePathInDir(pluginDir, executablePath string) (string, error) {
+	absPluginDir, err := filepath.Abs(pluginDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute plugin directory: %w", err)
+	}
+	path := filepath.Clean(filepath.Join(absPluginDir, executablePath))
+	if filepath.IsAbs(executablePath) {
+		path = filepath.Clean(executablePath)
+	}
+	if !strings.HasPrefix(path, absPluginDir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("executable path %s is not under the plugin directory %s", executablePath, absPluginDir)
+	}
+	return path, nil
+}
+
+// Verification is the outcome of the verification of an installed plugin.
+type Verification struct {
+	// PluginID is the ID of the plugin the manifest is named after.
+	PluginID string
+	// Manifest is the manifest of the plugin, with the resolved executable path.
+	Manifest Manifest
+	// Checksum is the computed SHA256 hash of the executable.
+	Checksum string
+	// Err is set when the plugin cannot be launched.
+	Err error
+}
+
+// VerifyPlugins checks the manifest of each plugin in the search path and computes the SHA256 hash of
+// the executable it references. Like with FindPluginsInPath, the manifest that comes first in the search
+// path is verified when several directories hold a plugin with the same ID. Unlike FindPluginsInPath, an
+// invalid plugin does not stop the verification of the others. The verifications are sorted by plugin ID.
+//
+// Remote plugins have no executable, so only their manifest is verified.
+//
+// With `WithVerifier`, the manifest signatures are verified as well. The other find options are ignored.
+func VerifyPlugins(searchPath []string, opts ...FindOption) ([]Verification, error) {
+	config := &findOptions{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	matchingPlugins, err := findPluginMatchesInPath(searchPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(matchingPlugins) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrPluginsNotFound, strings.Join(searchPath, string(os.PathListSeparator)))
+	}
+
+	verifications := make([]Verification, 0, len(matchingPlugins))
+	for id, matches := range matchingPlugins {
+		verifications = append(verifications, verifyPlugin(id, matches[0], config.verifier))
+	}
+	sort.Slice(verifications, func(i, j int) bool {
+		return verifications[i].PluginID < verifications[j].PluginID
+	})
+	return verifications, nil
+}
+
+func verifyPlugin(pluginID string, match pluginMatch, verifier *Verifier) Verification {
+	manifest, checksum, err := verifyManifest(pluginID, match, verifier)
+	return Verification{PluginID: pluginID, Manifest: manifest, Checksum: checksum, Err: err}
+}
+
+// verifyManifest reads and verifies the manifest of the match and the checksum of its executable.
+// The manifest is returned as far as it was read and resolved when it is invalid.
+func verifyManifest(pluginID string, match pluginMatch, verifier *Verifier) (Manifest, string, error) {
+	manifestPath := match.path()
+	manifest, manifestData, err := readManifestData(pluginID, manifestPath)
+	if err != nil {
+		return manifest, "", err
+	}
+	if !manifest.ValidateID() || manifest.ID != pluginID {
+		return manifest, "", fmt.Errorf("invalid plugin id %q in manifest %s", manifest.ID, match.name)
+	}
+	if err := manifest.resolve(match.dir); err != nil {
+		return manifest, "", err
+	}
+	if manifest.IsRemote() {
+		// There is no executable to check for remote plugins
+		err := verifier.check(&manifest, manifestPath, manifestData)
+		return manifest, "", err
+	}
+	checksum, err := Checksum(manifest.ExecutablePath)
+	if err != nil {
+		return manifest, "", err
+	}
+	if checksum != manifest.Checksum {
+		return manifest, checksum, &ChecksumError{PluginID: pluginID, Want: manifest.Checksum, Got: checksum}
+	}
+	err = verifier.check(&manifest, manifestPath, manifestData)
+	return manifest, checksum, err
+}
@@ -0,0 +1,156 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstall(t *testing.T) {
+	pluginDir := filepath.Join(t.TempDir(), "plugins")
+	binaryPath := filepath.Join(t.TempDir(), "myplugin-linux-amd64")
+	require.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\n"), 0600))
+
+	defaultValue := "value"
+	manifest := Manifest{
+		Metadata: Metadata{
+			ID:          "myplugin",
+			Description: "My plugin",
+			Version:     "0.0.1",
+			Types:       []string{PVPPluginName},
+		},
+		Configuration: []ConfigurationOption{
+			{Name: "option", Description: "An option", Default: &defaultValue},
+		},
+	}
+	installed, err := Install(pluginDir, binaryPath, manifest)
+	require.NoError(t, err)
+	require.Equal(t, "myplugin-linux-amd64", installed.ExecutablePath)
+	wantChecksum, err := Checksum(binaryPath)
+	require.NoError(t, err)
+	require.Equal(t, wantChecksum, installed.Checksum)
+
+	info, err := os.Stat(filepath.Join(pluginDir, "myplugin-linux-amd64"))
+	require.NoError(t, err)
+	require.NotZero(t, info.Mode()&0100)
+
+	// The installed plugin can be found and launched
+	manifests, err := FindPlugins(pluginDir)
+	require.NoError(t, err)
+	require.Equal(t, manifest.Metadata, manifests["myplugin"].Metadata)
+	require.Equal(t, manifest.Configuration, manifests["myplugin"].Configuration)
+	require.Equal(t, installed.Checksum, manifests["myplugin"].Checksum)
+
+	_, err = Install(pluginDir, binaryPath, manifest)
+	require.EqualError(t, err, "c2p-myplugin-manifest.json already exists in the plugin directory")
+
+	// Overwriting with another executable name
+	manifest.ExecutablePath = "myplugin"
+	require.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\nexit 0\n"), 0600))
+	installed, err = Install(pluginDir, binaryPath, manifest, WithOverwrite())
+	require.NoError(t, err)
+	require.Equal(t, "myplugin", installed.ExecutablePath)
+	require.NotEqual(t, wantChecksum, installed.Checksum)
+
+	manifest.ExecutablePath = "../myplugin"
+	_, err = Install(pluginDir, binaryPath, manifest, WithOverwrite())
+	require.EqualError(t, err, "executable path ../myplugin must be a file name in the plugin directory")
+
+	manifest.ExecutablePath = ""
+	manifest.Types = []string{"unknown"}
+	_, err = Install(pluginDir, binaryPath, manifest)
+	require.EqualError(t, err, "unsupported plugin type \"unknown\" for plugin myplugin")
+
+	manifest.ID = "MyPlugin"
+	_, err = Install(pluginDir, binaryPath, manifest)
+	require.EqualError(t, err, "invalid plugin id \"MyPlugin\"")
+}
+
+func TestUninstall(t *testing.T) {
+	pluginDir := t.TempDir()
+	binaryPath := filepath.Join(t.TempDir(), "shared-plugin")
+	require.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\n"), 0600))
+	for _, id := range []string{"first", "second"} {
+		_, err := Install(pluginDir, binaryPath, Manifest{
+			Metadata: Metadata{ID: id, Types: []string{PVPPluginName}},
+		}, WithOverwrite())
+		require.NoError(t, err)
+	}
+
+	// The executable is still used by the second plugin
+	require.NoError(t, Uninstall(pluginDir, "first"))
+	require.NoFileExists(t, filepath.Join(pluginDir, "c2p-first-manifest.json"))
+	require.FileExists(t, filepath.Join(pluginDir, "shared-plugin"))
+
+	require.NoError(t, Uninstall(pluginDir, "second"))
+	entries, err := os.ReadDir(pluginDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	err = Uninstall(pluginDir, "second")
+	var notFound *NotFoundError
+	require.True(t, errors.As(err, &notFound))
+}
+
+func TestVerifyPlugins(t *testing.T) {
+	verifications, err := VerifyPlugins([]string{"testdata/plugins"})
+	require.NoError(t, err)
+	require.Len(t, verifications, 2)
+	for _, verification := range verifications {
+		require.NoError(t, verification.Err, verification.PluginID)
+		require.Equal(t, verification.Manifest.Checksum, verification.Checksum)
+	}
+
+	pluginDir := t.TempDir()
+	binaryPath := filepath.Join(t.TempDir(), "myplugin")
+	require.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\n"), 0600))
+	installed, err := Install(pluginDir, binaryPath, Manifest{Metadata: Metadata{ID: "myplugin", Types: []string{PVPPluginName}}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "c2p-INVALID-manifest.json"), []byte("{}"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "myplugin"), []byte("#!/bin/sh\nexit 1\n"), 0700))
+
+	verifications, err = VerifyPlugins([]string{pluginDir})
+	require.NoError(t, err)
+	require.Len(t, verifications, 2)
+	require.EqualError(t, verifications[0].Err, "invalid plugin id \"\" in manifest c2p-INVALID-manifest.json")
+
+	var checksumErr *ChecksumError
+	require.True(t, errors.As(verifications[1].Err, &checksumErr))
+	require.Equal(t, installed.Checksum, checksumErr.Want)
+	require.Equal(t, verifications[1].Checksum, checksumErr.Got)
+
+	// The plugin that comes first in the search path is verified
+	firstDir := t.TempDir()
+	installTestPlugin(t, firstDir, "myplugin")
+	verifications, err = VerifyPlugins([]string{firstDir, pluginDir})
+	require.NoError(t, err)
+	require.Len(t, verifications, 2)
+	require.NoError(t, verifications[1].Err)
+
+	_, err = VerifyPlugins([]string{t.TempDir()})
+	require.ErrorIs(t, err, ErrPluginsNotFound)
+}
+
+func TestUninstallSubdirectory(t *testing.T) {
+	pluginDir := t.TempDir()
+	installTestPlugin(t, filepath.Join(pluginDir, "nested"), "nested")
+	_, privateKey := generateTestKey(t, "release")
+	require.NoError(t, SignManifest(pluginDir, "nested", privateKey))
+	require.FileExists(t, filepath.Join(pluginDir, "nested", "c2p-nested-manifest.json.sig"))
+
+	verifications, err := VerifyPlugins([]string{pluginDir})
+	require.NoError(t, err)
+	require.Len(t, verifications, 1)
+	require.NoError(t, verifications[0].Err)
+
+	require.NoError(t, Uninstall(pluginDir, "nested"))
+	require.NoDirExists(t, filepath.Join(pluginDir, "nested"))
+}
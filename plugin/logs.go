@@ -0,0 +1,319 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLogMaxFileSize = 10 * 1024 * 1024
+	defaultLogMaxFiles    = 3
+	defaultLogBufferLines = 1000
+	defaultLogTailLines   = 20
+)
+
+// LogCapture configures the capture of the log streams of launched plugins.
+//
+// Each plugin log stream is kept in an in-memory buffer that can be queried. When Dir is set,
+// it is also written to a rotating `<plugin id>.log` file, with each line tagged with the
+// plugin ID and the run ID. A zero value uses the defaults.
+type LogCapture struct {
+	// Dir is the directory the plugin log files are written to.
+	// The logs are only kept in memory when it is empty.
+	Dir string
+	// MaxFileSize is the size in bytes a log file is rotated at.
+	// It defaults to 10 MiB.
+	MaxFileSize int64
+	// MaxFiles is the number of rotated files kept for each
+	// plugin. It defaults to 3.
+	MaxFiles int
+	// BufferLines is the number of lines kept in memory for
+	// each plugin. It defaults to 1000.
+	BufferLines int
+	// TailLines is the number of last lines included in the errors
+	// returned for a plugin. It defaults to 20.
+	TailLines int
+	// RunID tags the captured lines. It defaults to a generated ID.
+	RunID string
+}
+
+// Validate returns an error if the LogCapture has invalid fields.
+func (c *LogCapture) Validate() error {
+	if c.MaxFileSize < 0 || c.MaxFiles < 0 || c.BufferLines < 0 || c.TailLines < 0 {
+		return errors.New("plugin log capture sizes cannot be negative")
+	}
+	if c.TailLines > 0 && c.BufferLines > 0 && c.TailLines > c.BufferLines {
+		return fmt.Errorf("plugin log tail lines %d exceed the buffer lines %d", c.TailLines, c.BufferLines)
+	}
+	return nil
+}
+
+// NewRunID returns a new ID for tagging the plugin logs of a run.
+func NewRunID() string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102T150405Z"), hex.EncodeToString(suffix))
+}
+
+// PluginLogs holds the captured log streams of the plugins of a run.
+// A nil PluginLogs does not capture logs.
+type PluginLogs struct {
+	capture LogCapture
+	mu      sync.Mutex
+	streams map[string]*logStream
+}
+
+// NewPluginLogs creates the plugin log directory, if set, and
+// returns PluginLogs with the defaults applied to the LogCapture.
+func NewPluginLogs(capture LogCapture) (*PluginLogs, error) {
+	if err := capture.Validate(); err != nil {
+		return nil, err
+	}
+	if capture.MaxFileSize == 0 {
+		capture.MaxFileSize = defaultLogMaxFileSize
+	}
+	if capture.MaxFiles == 0 {
+		capture.MaxFiles = defaultLogMaxFiles
+	}
+	if capture.BufferLines == 0 {
+		capture.BufferLines = defaultLogBufferLines
+	}
+	if capture.TailLines == 0 {
+		capture.TailLines = min(defaultLogTailLines, capture.BufferLines)
+	}
+	if capture.RunID == "" {
+		capture.RunID = NewRunID()
+	}
+	if capture.Dir != "" {
+		if err := os.MkdirAll(capture.Dir, 0750); err != nil {
+			return nil, fmt.Errorf("failed to create plugin log directory: %w", err)
+		}
+	}
+	return &PluginLogs{
+		capture: capture,
+		streams: make(map[string]*logStream),
+	}, nil
+}
+
+// RunID returns the ID the captured lines are tagged with.
+func (l *PluginLogs) RunID() string {
+	if l == nil {
+		return ""
+	}
+	return l.capture.RunID
+}
+
+// Writer returns the writer for the log stream of the plugin.
+func (l *PluginLogs) Writer(pluginID string) io.Writer {
+	if l == nil {
+		return io.Discard
+	}
+	return l.stream(pluginID)
+}
+
+// Lines returns the lines of the plugin log stream kept in memory, oldest first.
+func (l *PluginLogs) Lines(pluginID string) []string {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	stream, ok := l.streams[pluginID]
+	l.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return stream.lines(l.capture.BufferLines)
+}
+
+// WrapError returns the error with the last lines of the plugin log stream,
+// or the error as is when the plugin has not logged anything.
+func (l *PluginLogs) WrapError(pluginID string, err error) error {
+	if l == nil || err == nil {
+		return err
+	}
+	l.mu.Lock()
+	stream, ok := l.streams[pluginID]
+	l.mu.Unlock()
+	if !ok {
+		return err
+	}
+	lines := stream.lines(l.capture.TailLines)
+	if len(lines) == 0 {
+		return err
+	}
+	return &LogTailError{PluginID: pluginID, Lines: lines, Err: err}
+}
+
+// Close closes the plugin log files. The lines kept in memory can still be queried.
+func (l *PluginLogs) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var errs []error
+	for _, stream := range l.streams {
+		errs = append(errs, stream.close())
+	}
+	return errors.Join(errs...)
+}
+
+func (l *PluginLogs) stream(pluginID string) *logStream {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stream, ok := l.streams[pluginID]
+	if !ok {
+		stream = &logStream{
+			pluginID: pluginID,
+			capture:  &l.capture,
+		}
+		if l.capture.Dir != "" {
+			stream.path = filepath.Join(l.capture.Dir, pluginID+".log")
+		}
+		l.streams[pluginID] = stream
+	}
+	return stream
+}
+
+// logStream splits the log stream of a plugin into lines, keeps the last
+// lines in memory and writes the tagged lines to the rotating log file.
+type logStream struct {
+	pluginID string
+	capture  *LogCapture
+	path     string
+
+	mu      sync.Mutex
+	partial []byte
+	buffer  []string
+	file    *os.File
+	size    int64
+	err     error
+}
+
+func (s *logStream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.partial = append(s.partial, p...)
+	for {
+		i := bytes.IndexByte(s.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := bytes.TrimRight(s.partial[:i], "\r")
+		if len(line) > 0 {
+			s.addLine(line)
+		}
+		s.partial = s.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// addLine keeps the line in memory and writes it to the log file. A failure
+// to write the log file is reported once in memory, so the plugin is not
+// affected by it.
+func (s *logStream) addLine(line []byte) {
+	s.keep(string(line))
+	if s.path == "" || s.err != nil {
+		return
+	}
+	if err := s.writeFile(tagLogLine(line, s.pluginID, s.capture.RunID)); err != nil {
+		s.err = err
+		s.keep(fmt.Sprintf("failed to write plugin log file: %v", err))
+	}
+}
+
+func (s *logStream) keep(line string) {
+	s.buffer = append(s.buffer, line)
+	if over := len(s.buffer) - s.capture.BufferLines; over > 0 {
+		s.buffer = append(s.buffer[:0], s.buffer[over:]...)
+	}
+}
+
+func (s *logStream) writeFile(line []byte) error {
+	if s.file != nil && s.size > 0 && s.size+int64(len(line)) > s.capture.MaxFileSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	if s.file == nil {
+		file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+		s.file, s.size = file, info.Size()
+		if s.size > 0 && s.size+int64(len(line)) > s.capture.MaxFileSize {
+			return s.writeFile(line)
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+// rotate shifts the rotated files, dropping the oldest one, and
+// moves the current log file to `<plugin id>.log.1`.
+func (s *logStream) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file, s.size = nil, 0
+	for i := s.capture.MaxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", s.path, i), fmt.Sprintf("%s.%d", s.path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(s.path, s.path+".1")
+}
+
+func (s *logStream) lines(n int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	start := max(len(s.buffer)-n, 0)
+	return append([]string(nil), s.buffer[start:]...)
+}
+
+func (s *logStream) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// tagLogLine returns the JSON log line with the plugin and run ID. Lines that
+// are not JSON objects are tagged as the message of a new object.
+func tagLogLine(line []byte, pluginID, runID string) []byte {
+	entry := make(map[string]interface{})
+	if err := json.Unmarshal(line, &entry); err != nil || entry == nil {
+		entry = map[string]interface{}{"@message": string(line)}
+	}
+	entry["@plugin"] = pluginID
+	entry["@run"] = runID
+	data, err := json.Marshal(entry)
+	if err != nil {
+		data = line
+	}
+	return append(data, '\n')
+}
@@ -0,0 +1,127 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogCapture_Validate(t *testing.T) {
+	require.NoError(t, (&LogCapture{}).Validate())
+	require.EqualError(t, (&LogCapture{MaxFiles: -1}).Validate(), "plugin log capture sizes cannot be negative")
+	require.EqualError(t, (&LogCapture{BufferLines: 5, TailLines: 10}).Validate(), "plugin log tail lines 10 exceed the buffer lines 5")
+}
+
+func TestPluginLogs(t *testing.T) {
+	logs, err := NewPluginLogs(LogCapture{BufferLines: 3, TailLines: 2, RunID: "run-1"})
+	require.NoError(t, err)
+	require.Equal(t, "run-1", logs.RunID())
+
+	// Lines can be split across writes
+	writer := logs.Writer("myplugin")
+	_, err = io.WriteString(writer, "first\nsec")
+	require.NoError(t, err)
+	_, err = io.WriteString(writer, "ond\n\nthird\nfourth\npartial")
+	require.NoError(t, err)
+	require.Equal(t, []string{"second", "third", "fourth"}, logs.Lines("myplugin"))
+	require.Nil(t, logs.Lines("other"))
+
+	cause := errors.New("plugin myplugin: failed")
+	err = logs.WrapError("myplugin", cause)
+	var tailErr *LogTailError
+	require.True(t, errors.As(err, &tailErr))
+	require.ErrorIs(t, err, cause)
+	require.Equal(t, []string{"third", "fourth"}, tailErr.Lines)
+	require.EqualError(t, err, "plugin myplugin: failed\nlast 2 log lines of plugin \"myplugin\":\n  third\n  fourth")
+	require.Same(t, cause, logs.WrapError("other", cause))
+	require.NoError(t, logs.WrapError("myplugin", nil))
+	require.NoError(t, logs.Close())
+
+	// A nil PluginLogs does not capture logs
+	var disabled *PluginLogs
+	_, err = io.WriteString(disabled.Writer("myplugin"), "line\n")
+	require.NoError(t, err)
+	require.Nil(t, disabled.Lines("myplugin"))
+	require.Same(t, cause, disabled.WrapError("myplugin", cause))
+	require.Empty(t, disabled.RunID())
+	require.NoError(t, disabled.Close())
+}
+
+func TestPluginLogs_Files(t *testing.T) {
+	logDir := filepath.Join(t.TempDir(), "logs")
+	logs, err := NewPluginLogs(LogCapture{Dir: logDir, MaxFileSize: 200, MaxFiles: 2, RunID: "run-1"})
+	require.NoError(t, err)
+	require.DirExists(t, logDir)
+
+	writer := logs.Writer("myplugin")
+	_, err = io.WriteString(writer, `{"@level":"info","@message":"starting","namespace":"default"}`+"\n")
+	require.NoError(t, err)
+	_, err = io.WriteString(writer, "not json\n")
+	require.NoError(t, err)
+	require.NoError(t, logs.Close())
+
+	data, err := os.ReadFile(filepath.Join(logDir, "myplugin.log"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	require.Equal(t, map[string]interface{}{
+		"@level":    "info",
+		"@message":  "starting",
+		"@plugin":   "myplugin",
+		"@run":      "run-1",
+		"namespace": "default",
+	}, entry)
+	entry = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	require.Equal(t, map[string]interface{}{"@message": "not json", "@plugin": "myplugin", "@run": "run-1"}, entry)
+
+	// The files are rotated at the maximum size and only
+	// the last rotated files are kept
+	logs, err = NewPluginLogs(LogCapture{Dir: logDir, MaxFileSize: 200, MaxFiles: 2, RunID: "run-2"})
+	require.NoError(t, err)
+	writer = logs.Writer("myplugin")
+	for i := 0; i < 10; i++ {
+		_, err = fmt.Fprintf(writer, "line %d\n", i)
+		require.NoError(t, err)
+	}
+	require.NoError(t, logs.Close())
+	for _, name := range []string{"myplugin.log", "myplugin.log.1", "myplugin.log.2"} {
+		info, err := os.Stat(filepath.Join(logDir, name))
+		require.NoError(t, err)
+		require.LessOrEqual(t, info.Size(), int64(200))
+	}
+	require.NoFileExists(t, filepath.Join(logDir, "myplugin.log.3"))
+	data, err = os.ReadFile(filepath.Join(logDir, "myplugin.log"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"@message":"line 9"`)
+	require.Contains(t, string(data), `"@run":"run-2"`)
+}
+
+func TestClientFactory_PluginLogs(t *testing.T) {
+	// The plugin logs and exits before the handshake
+	manifest := sandboxTestManifest(t, `echo '{"@level":"error","@message":"missing kubeconfig"}' >&2; exit 1`)
+	logs, err := NewPluginLogs(LogCapture{})
+	require.NoError(t, err)
+
+	factory := ClientFactory(hclog.NewNullLogger(), WithPluginLogs(logs))
+	_, err = NewPolicyPlugin(manifest, factory)
+	require.Error(t, err)
+	err = logs.WrapError(manifest.ID, err)
+	require.ErrorContains(t, err, "last 1 log lines of plugin \"sandboxed\":\n  {\"@level\":\"error\",\"@message\":\"missing kubeconfig\"}")
+	require.Equal(t, []string{`{"@level":"error","@message":"missing kubeconfig"}`}, logs.Lines(manifest.ID))
+}
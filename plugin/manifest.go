@@ -25,13 +25,48 @@ type Manifest struct {
 	// Configuration is an optional section to add plugin
 	// configuration options and default values.
 	Configuration []ConfigurationOption `json:"configuration,omitempty"`
+	// Collectors lists the IDs of the collector plugins whose evidence a policy
+	// plugin evaluates. Only the evidence of these collectors is passed to Evaluate.
+	Collectors []string `json:"collectors,omitempty"`
+	// Args are the arguments passed to the plugin executable.
+	Args []string `json:"args,omitempty"`
+	// Environment lists the names of the host environment variables the
+	// plugin needs when it is launched in a Sandbox. Only the variables in the
+	// Sandbox allowlist are passed.
+	Environment []string `json:"environment,omitempty"`
+	// Limits are the resource limits requested by the plugin. They are
+	// applied when the plugin is launched in a Sandbox.
+	Limits *ResourceLimits `json:"limits,omitempty"`
+	// Remote describes the endpoint of a plugin that is not launched by the host.
+	// The ExecutablePath and Checksum are not used for remote plugins.
+	Remote *Remote `json:"remote,omitempty"`
+	// Signature is the verified signature of the manifest. It is
+	// only set when signatures are verified during discovery.
+	Signature *Signature `json:"-"`
+	// Shadowed lists the manifests of plugins with the same ID that
+	// come later in the search path and are ignored.
+	Shadowed []string `json:"-"`
+	// manifestPath is the file the manifest was read from.
+	manifestPath string
+}
+
+// ManifestPath returns the file the manifest was read from during
+// plugin discovery.
+func (m Manifest) ManifestPath() string {
+	return m.manifestPath
+}
+
+// IsRemote returns true when the plugin is reached over the network
+// instead of being launched by the host.
+func (m Manifest) IsRemote() bool {
+	return m.Remote != nil
 }
 
 // ResolvePath validates and sanitizes the Manifest.ExecutablePath.
 //
 // If the path is not absolute, it updates Manifest.ExecutablePath field
-// to a location under the given plugin directory. If the path is absolute,
-// it validates it is under the given plugin directory.
+// to a location under the given plugin directory. In both cases, it validates
+// the path is under the given plugin directory.
 func (m *Manifest) ResolvePath(pluginDir string) error {
 	absPluginDir, err := filepath.Abs(pluginDir)
 	if err != nil {
@@ -47,6 +82,9 @@ func (m *Manifest) ResolvePath(pluginDir string) error {
 		cleanedPath = filepath.Clean(m.ExecutablePath)
 	} else {
 		cleanedPath = filepath.Clean(filepath.Join(absPluginDir, m.ExecutablePath))
+		if !strings.HasPrefix(cleanedPath, absPluginDir+string(os.PathSeparator)) {
+			return fmt.Errorf("relative path %s is not under the plugin directory %s", m.ExecutablePath, absPluginDir)
+		}
 	}
 
 	fileInfo, err := os.Stat(cleanedPath)
@@ -69,6 +107,23 @@ func (m *Manifest) ResolvePath(pluginDir string) error {
 	return nil
 }
 
+// resolve validates a manifest found in the given plugin directory. The executable path is
+// resolved for launched plugins, and the certificate paths are resolved for remote plugins.
+func (m *Manifest) resolve(pluginDir string) error {
+	if !m.IsRemote() {
+		return m.ResolvePath(pluginDir)
+	}
+	if err := m.Remote.Validate(); err != nil {
+		return fmt.Errorf("invalid remote plugin %s: %w", m.ID, err)
+	}
+	absPluginDir, err := filepath.Abs(pluginDir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute plugin directory: %w", err)
+	}
+	m.Remote.resolveFiles(absPluginDir)
+	return nil
+}
+
 // ResolveOptions validates and applies given configuration selections against the manifest
 // declared configuration and returns the resolved options.
 func (m *Manifest) ResolveOptions(configSelections map[string]string) (map[string]string, error) {
@@ -119,6 +174,10 @@ type ConfigurationOption struct {
 	Required bool `json:"required"`
 	// Default is an optional parameter with the default selected value.
 	Default *string `json:"default,omitempty"`
+	// Path is whether the value of the option is a file or directory path.
+	// Relative paths are resolved against the host working directory
+	// when the plugin runs in a sandbox.
+	Path bool `json:"path,omitempty"`
 }
 
 // ValidateID ensure the plugin id is valid based on the
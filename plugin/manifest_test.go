@@ -57,6 +57,13 @@ func TestManifest_ResolvePath(t *testing.T) {
 			},
 			wantError: fmt.Sprintf("absolute path /dir/testplugin is not under the plugin directory %s", tmpDir),
 		},
+		{
+			name: "Invalid/RelativePathOutsideDir",
+			testManifest: Manifest{
+				ExecutablePath: "../testplugin",
+			},
+			wantError: fmt.Sprintf("relative path ../testplugin is not under the plugin directory %s", tmpDir),
+		},
 		{
 			name: "Invalid/PluginDoesNotExist",
 			testManifest: Manifest{
@@ -19,6 +19,12 @@ import (
 const (
 	// PVPPluginName is used to dispense policy validation point plugin type
 	PVPPluginName = "pvp"
+	// RemediationPluginName is used to dispense remediation plugin type
+	RemediationPluginName = "remediation"
+	// CollectorPluginName is used to dispense evidence collector plugin type
+	CollectorPluginName = "collector"
+	// ProcessorPluginName is used to dispense result processor plugin type
+	ProcessorPluginName = "processor"
 	// The ProtocolVersion is the version that must match between the core
 	// and plugins.
 	ProtocolVersion = 1
@@ -42,7 +48,10 @@ var Handshake = plugin.HandshakeConfig{
 
 // SupportedPlugins is the map of plugins we can dispense.
 var SupportedPlugins = map[string]plugin.Plugin{
-	PVPPluginName: &PVPPlugin{},
+	PVPPluginName:         &PVPPlugin{},
+	RemediationPluginName: &RemediationPlugin{},
+	CollectorPluginName:   &CollectorPlugin{},
+	ProcessorPluginName:   &ProcessorPlugin{},
 }
 
 var _ plugin.GRPCPlugin = (*PVPPlugin)(nil)
@@ -62,3 +71,57 @@ func (p *PVPPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error
 func (p *PVPPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
 	return &pvpClient{client: proto.NewPolicyEngineClient(c)}, nil
 }
+
+var _ plugin.GRPCPlugin = (*RemediationPlugin)(nil)
+
+// RemediationPlugin is concrete implementation of the policy.Remediator written in Go for use
+// with go-plugin.
+type RemediationPlugin struct {
+	plugin.Plugin
+	Impl policy.Remediator
+}
+
+func (p *RemediationPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterRemediationEngineServer(s, FromRemediator(p.Impl))
+	return nil
+}
+
+func (p *RemediationPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &remediationClient{client: proto.NewRemediationEngineClient(c)}, nil
+}
+
+var _ plugin.GRPCPlugin = (*CollectorPlugin)(nil)
+
+// CollectorPlugin is concrete implementation of the policy.Collector written in Go for use
+// with go-plugin.
+type CollectorPlugin struct {
+	plugin.Plugin
+	Impl policy.Collector
+}
+
+func (p *CollectorPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterEvidenceCollectorServer(s, FromCollector(p.Impl))
+	return nil
+}
+
+func (p *CollectorPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &collectorClient{client: proto.NewEvidenceCollectorClient(c)}, nil
+}
+
+var _ plugin.GRPCPlugin = (*ProcessorPlugin)(nil)
+
+// ProcessorPlugin is concrete implementation of the policy.Processor written in Go for use
+// with go-plugin.
+type ProcessorPlugin struct {
+	plugin.Plugin
+	Impl policy.Processor
+}
+
+func (p *ProcessorPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterResultProcessorServer(s, FromProcessor(p.Impl))
+	return nil
+}
+
+func (p *ProcessorPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &processorClient{client: proto.NewResultProcessorClient(c)}, nil
+}
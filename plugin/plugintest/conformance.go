@@ -0,0 +1,286 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+const (
+	// UnknownRuleID is the ID of the rule added to the policy in the unknown check case.
+	UnknownRuleID = "plugintest-unknown-rule"
+	// UnknownCheckID is the ID of the check added to the policy in the unknown check case.
+	UnknownCheckID = "plugintest-unknown-check"
+)
+
+// Options configures the conformance cases run against a provider.
+type Options struct {
+	// Manifest declares the configuration options of the provider. Only the
+	// configuration section of the manifest is used.
+	Manifest plugin.Manifest
+	// Configuration is a valid configuration of the provider. It is resolved
+	// against the manifest configuration options before being sent to the provider.
+	Configuration map[string]string
+	// InvalidConfiguration is an optional configuration that the provider must reject.
+	InvalidConfiguration map[string]string
+	// Policy is the policy the provider generates and gets results for. It should
+	// contain a rule with a parameter.
+	Policy policy.Policy
+	// Results are the results the provider must report for the policy.
+	Results []policy.Result
+}
+
+// Run serves the provider over an in-process gRPC connection and runs the
+// conformance cases against it.
+//
+// The provider is called through the gRPC transport. What it receives and
+// returns is recorded to check that the transport is lossless.
+func Run(t *testing.T, impl policy.Provider, opts Options) {
+	t.Helper()
+	rec := &recorder{impl: impl}
+	run(t, ServeGRPC(t, rec), rec, opts)
+}
+
+// RunBinary launches the plugin binary at the given path and runs the conformance cases
+// against it. The cases that need access to the provider implementation are skipped.
+func RunBinary(t *testing.T, path string, opts Options) {
+	t.Helper()
+	run(t, LaunchBinary(t, path), nil, opts)
+}
+
+func run(t *testing.T, provider policy.Provider, rec *recorder, opts Options) {
+	configuration, err := opts.Manifest.ResolveOptions(opts.Configuration)
+	require.NoError(t, err, "resolving the configuration against the manifest")
+
+	t.Run("Configure", func(t *testing.T) {
+		testConfigure(t, provider, rec, opts, configuration)
+	})
+	t.Run("GenerateEmptyPolicy", func(t *testing.T) {
+		require.NoError(t, provider.Configure(configuration))
+		require.NoError(t, provider.Generate(policy.Policy{}))
+	})
+	t.Run("UnknownCheck", func(t *testing.T) {
+		require.NoError(t, provider.Configure(configuration))
+		testUnknownCheck(t, provider, opts)
+	})
+	t.Run("ParameterRoundTrip", func(t *testing.T) {
+		if rec == nil {
+			t.Skip("the received policy is only recorded for in-process providers")
+		}
+		require.NoError(t, provider.Configure(configuration))
+		testParameterRoundTrip(t, provider, rec, opts)
+	})
+	t.Run("ResultEnumCoverage", func(t *testing.T) {
+		require.NoError(t, provider.Configure(configuration))
+		testResultEnumCoverage(t, provider, opts)
+	})
+	t.Run("TimestampPreservation", func(t *testing.T) {
+		require.NoError(t, provider.Configure(configuration))
+		testTimestampPreservation(t, provider, rec, opts)
+	})
+	t.Run("ErrorPropagation", func(t *testing.T) {
+		testErrorPropagation(t, provider, rec, opts, configuration)
+	})
+}
+
+// testConfigure checks that the provider accepts the configuration with the manifest defaults
+// and rejects the configuration without one of the required options.
+func testConfigure(t *testing.T, provider policy.Provider, rec *recorder, opts Options, configuration map[string]string) {
+	declared := make(map[string]struct{}, len(opts.Manifest.Configuration))
+	for _, option := range opts.Manifest.Configuration {
+		declared[option.Name] = struct{}{}
+	}
+	for name := range opts.Configuration {
+		_, ok := declared[name]
+		require.True(t, ok, "option %q is not declared in the manifest and would not be sent to the provider", name)
+	}
+
+	require.NoError(t, provider.Configure(configuration))
+	if rec != nil {
+		require.Equal(t, configuration, nonNilMap(rec.lastConfiguration()))
+	}
+
+	for _, option := range opts.Manifest.Configuration {
+		if !option.Required {
+			continue
+		}
+		missing := make(map[string]string, len(configuration))
+		for name, value := range configuration {
+			if name != option.Name {
+				missing[name] = value
+			}
+		}
+		require.Error(t, provider.Configure(missing), "the configuration without the required option %q was accepted", option.Name)
+	}
+}
+
+// testUnknownCheck checks that a check the provider does not know does not fail the
+// results of the others and is not reported as passing.
+func testUnknownCheck(t *testing.T, provider policy.Provider, opts Options) {
+	pl := append(policy.Policy{}, opts.Policy...)
+	pl = append(pl, policy.RuleSet{RuleSet: extensions.RuleSet{
+		Rule: extensions.Rule{
+			ID:          UnknownRuleID,
+			Description: "Rule added by the plugin conformance tests",
+		},
+		Checks: []extensions.Check{
+			{
+				ID:          UnknownCheckID,
+				Description: "Check unknown to the provider",
+			},
+		},
+	}})
+	result, err := provider.GetResults(pl)
+	require.NoError(t, err)
+
+	checkIDs := make(map[string]struct{})
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			checkIDs[check.ID] = struct{}{}
+		}
+	}
+	for _, observation := range result.ObservationsByCheck {
+		_, ok := checkIDs[observation.CheckID]
+		require.True(t, ok, "observation of check %q that is not in the policy", observation.CheckID)
+		if observation.CheckID != UnknownCheckID {
+			continue
+		}
+		for _, subject := range observation.Subjects {
+			require.NotEqual(t, policy.ResultPass, subject.Result, "unknown check reported as passing for %s", subject.ResourceID)
+		}
+	}
+}
+
+// testParameterRoundTrip checks that the provider receives the policy as it was sent.
+func testParameterRoundTrip(t *testing.T, provider policy.Provider, rec *recorder, opts Options) {
+	hasParameter := false
+	for _, ruleSet := range opts.Policy {
+		if ruleSet.Rule.Parameter != nil {
+			hasParameter = true
+		}
+	}
+	if !hasParameter {
+		t.Skip("the policy has no rule with a parameter")
+	}
+	_, err := provider.GetResults(opts.Policy)
+	require.NoError(t, err)
+	require.Equal(t, normalizePolicy(opts.Policy), normalizePolicy(rec.lastPolicy()))
+}
+
+// testResultEnumCoverage checks that the provider reports the expected results and only
+// results the framework knows.
+func testResultEnumCoverage(t *testing.T, provider policy.Provider, opts Options) {
+	result, err := provider.GetResults(opts.Policy)
+	require.NoError(t, err)
+
+	seen := make(map[policy.Result]struct{})
+	for _, observation := range result.ObservationsByCheck {
+		for _, subject := range observation.Subjects {
+			require.NotEqual(t, policy.ResultInvalid, subject.Result,
+				"invalid result for subject %s of check %s", subject.ResourceID, observation.CheckID)
+			require.LessOrEqual(t, subject.Result, policy.ResultSkipped,
+				"unknown result for subject %s of check %s", subject.ResourceID, observation.CheckID)
+			seen[subject.Result] = struct{}{}
+		}
+	}
+	for _, want := range opts.Results {
+		_, ok := seen[want]
+		require.True(t, ok, "no subject with result %s", want)
+	}
+}
+
+// testTimestampPreservation checks that the collection and evaluation times are set
+// and not altered by the transport.
+func testTimestampPreservation(t *testing.T, provider policy.Provider, rec *recorder, opts Options) {
+	result, err := provider.GetResults(opts.Policy)
+	require.NoError(t, err)
+	for _, observation := range result.ObservationsByCheck {
+		require.False(t, observation.Collected.IsZero(), "collected time of check %s is not set", observation.CheckID)
+		for _, subject := range observation.Subjects {
+			require.False(t, subject.EvaluatedOn.IsZero(), "evaluation time of subject %s of check %s is not set",
+				subject.ResourceID, observation.CheckID)
+		}
+	}
+	if rec == nil {
+		return
+	}
+
+	sent, err := rec.lastResult()
+	require.NoError(t, err)
+	require.Len(t, result.ObservationsByCheck, len(sent.ObservationsByCheck))
+	for i, observation := range result.ObservationsByCheck {
+		want := sent.ObservationsByCheck[i]
+		require.True(t, want.Collected.Equal(observation.Collected),
+			"collected time of check %s: sent %s, received %s", want.CheckID, want.Collected, observation.Collected)
+		require.Len(t, observation.Subjects, len(want.Subjects))
+		for j, subject := range observation.Subjects {
+			require.True(t, want.Subjects[j].EvaluatedOn.Equal(subject.EvaluatedOn),
+				"evaluation time of subject %s: sent %s, received %s", subject.ResourceID, want.Subjects[j].EvaluatedOn, subject.EvaluatedOn)
+		}
+	}
+	wantJSON, err := MarshalResult(sent)
+	require.NoError(t, err)
+	gotJSON, err := MarshalResult(result)
+	require.NoError(t, err)
+	require.Equal(t, string(wantJSON), string(gotJSON), "result altered by the transport")
+}
+
+// testErrorPropagation checks that errors of the provider are returned to the caller.
+func testErrorPropagation(t *testing.T, provider policy.Provider, rec *recorder, opts Options, configuration map[string]string) {
+	if opts.InvalidConfiguration == nil && rec == nil {
+		t.Skip("no invalid configuration to check")
+	}
+	if opts.InvalidConfiguration != nil {
+		invalid, err := opts.Manifest.ResolveOptions(opts.InvalidConfiguration)
+		require.NoError(t, err, "resolving the invalid configuration against the manifest")
+		if rec != nil {
+			rec.reset()
+		}
+		err = provider.Configure(invalid)
+		require.Error(t, err, "the invalid configuration was accepted")
+		if rec != nil {
+			_, want := rec.lastResult()
+			require.Error(t, want, "the invalid configuration did not reach the provider")
+			require.ErrorContains(t, err, want.Error())
+		}
+	}
+	if rec != nil {
+		require.NoError(t, provider.Configure(configuration))
+		rec.reset()
+		rec.setFail(true)
+		defer rec.reset()
+		_, err := provider.GetResults(opts.Policy)
+		require.ErrorContains(t, err, errInjected.Error())
+		_, want := rec.lastResult()
+		require.ErrorIs(t, want, errInjected)
+	}
+}
+
+// normalizePolicy returns a copy of the policy with empty slices set to nil, as
+// they are not distinguished by the transport.
+func normalizePolicy(p policy.Policy) policy.Policy {
+	var normalized policy.Policy
+	for _, ruleSet := range p {
+		if len(ruleSet.Checks) == 0 {
+			ruleSet.Checks = nil
+		}
+		normalized = append(normalized, ruleSet)
+	}
+	return normalized
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
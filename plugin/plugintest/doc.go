@@ -0,0 +1,8 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package plugintest provides conformance tests, golden file helpers and fixtures for
+// authors of policy.Provider plugins.
+package plugintest
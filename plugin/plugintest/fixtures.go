@@ -0,0 +1,45 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugintest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/oscal-compass/oscal-sdk-go/models"
+	"github.com/oscal-compass/oscal-sdk-go/models/components"
+	"github.com/oscal-compass/oscal-sdk-go/rules"
+	"github.com/oscal-compass/oscal-sdk-go/settings"
+	"github.com/oscal-compass/oscal-sdk-go/validation"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// LoadPolicy returns the policy of the component in the component definition at the
+// given path, with the settings applied as the framework does before calling a plugin.
+func LoadPolicy(t testing.TB, path, componentTitle string, testSettings settings.Settings) policy.Policy {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	compDef, err := models.NewComponentDefinition(file, validation.NoopValidator{})
+	require.NoError(t, err)
+	require.NotNil(t, compDef.Components)
+
+	var allComponents []components.Component
+	for _, comp := range *compDef.Components {
+		allComponents = append(allComponents, components.NewDefinedComponentAdapter(comp))
+	}
+	store := rules.NewMemoryStore()
+	require.NoError(t, store.IndexAll(allComponents))
+
+	ruleSets, err := settings.ApplyToComponent(context.TODO(), componentTitle, store, testSettings)
+	require.NoError(t, err)
+	return policy.FromRuleSets(ruleSets)
+}
@@ -0,0 +1,171 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugintest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertGolden write the golden
+// files instead of comparing them, e.g. C2P_UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "C2P_UPDATE_GOLDEN"
+
+// NormalizeResult returns a copy of the result that can be compared across runs. The times
+// are cleared and the observations and inventory items are sorted by ID. The order of the
+// subjects is kept.
+func NormalizeResult(result policy.PVPResult) policy.PVPResult {
+	normalized := policy.PVPResult{
+		Links:          result.Links,
+		InventoryItems: append([]policy.InventoryItem(nil), result.InventoryItems...),
+	}
+	for _, observation := range result.ObservationsByCheck {
+		observation.Collected = time.Time{}
+		subjects := make([]policy.Subject, 0, len(observation.Subjects))
+		for _, subject := range observation.Subjects {
+			subject.EvaluatedOn = time.Time{}
+			subjects = append(subjects, subject)
+		}
+		observation.Subjects = subjects
+		normalized.ObservationsByCheck = append(normalized.ObservationsByCheck, observation)
+	}
+	sort.SliceStable(normalized.ObservationsByCheck, func(i, j int) bool {
+		return normalized.ObservationsByCheck[i].CheckID < normalized.ObservationsByCheck[j].CheckID
+	})
+	sort.SliceStable(normalized.InventoryItems, func(i, j int) bool {
+		return normalized.InventoryItems[i].ID < normalized.InventoryItems[j].ID
+	})
+	return normalized
+}
+
+// MarshalResult returns the JSON representation of the normalized result used in golden files.
+// Results are written by name.
+func MarshalResult(result policy.PVPResult) ([]byte, error) {
+	result = NormalizeResult(result)
+	golden := goldenResult{
+		Links:          goldenLinks(result.Links),
+		InventoryItems: make([]goldenInventoryItem, 0, len(result.InventoryItems)),
+		Observations:   make([]goldenObservation, 0, len(result.ObservationsByCheck)),
+	}
+	for _, item := range result.InventoryItems {
+		golden.InventoryItems = append(golden.InventoryItems, goldenInventoryItem{
+			ID:          item.ID,
+			Description: item.Description,
+			Props:       goldenProps(item.Props),
+			Links:       goldenLinks(item.Links),
+		})
+	}
+	for _, observation := range result.ObservationsByCheck {
+		o := goldenObservation{
+			Title:             observation.Title,
+			Description:       observation.Description,
+			CheckID:           observation.CheckID,
+			Methods:           observation.Methods,
+			Props:             goldenProps(observation.Props),
+			RelevantEvidences: goldenLinks(observation.RelevantEvidences),
+			Subjects:          make([]goldenSubject, 0, len(observation.Subjects)),
+		}
+		for _, subject := range observation.Subjects {
+			o.Subjects = append(o.Subjects, goldenSubject{
+				Title:      subject.Title,
+				Type:       subject.Type,
+				ResourceID: subject.ResourceID,
+				Result:     subject.Result.String(),
+				Reason:     subject.Reason,
+				Props:      goldenProps(subject.Props),
+			})
+		}
+		golden.Observations = append(golden.Observations, o)
+	}
+	data, err := json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// AssertGolden compares the result with the golden file at the given path. When the
+// UpdateGoldenEnv environment variable is set, the golden file is written instead.
+func AssertGolden(t testing.TB, path string, result policy.PVPResult) {
+	t.Helper()
+	got, err := MarshalResult(result)
+	require.NoError(t, err)
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, got, 0600))
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "reading golden file, set %s=1 to create it", UpdateGoldenEnv)
+	require.Equal(t, string(want), string(got), "result differs from golden file %s, set %s=1 to update it", path, UpdateGoldenEnv)
+}
+
+type goldenResult struct {
+	Observations   []goldenObservation   `json:"observations"`
+	Links          []goldenLink          `json:"links,omitempty"`
+	InventoryItems []goldenInventoryItem `json:"inventory-items,omitempty"`
+}
+
+type goldenObservation struct {
+	Title             string          `json:"title"`
+	Description       string          `json:"description,omitempty"`
+	CheckID           string          `json:"check-id"`
+	Methods           []string        `json:"methods,omitempty"`
+	Props             []goldenProp    `json:"props,omitempty"`
+	RelevantEvidences []goldenLink    `json:"relevant-evidences,omitempty"`
+	Subjects          []goldenSubject `json:"subjects"`
+}
+
+type goldenSubject struct {
+	Title      string       `json:"title"`
+	Type       string       `json:"type"`
+	ResourceID string       `json:"resource-id"`
+	Result     string       `json:"result"`
+	Reason     string       `json:"reason,omitempty"`
+	Props      []goldenProp `json:"props,omitempty"`
+}
+
+type goldenInventoryItem struct {
+	ID          string       `json:"id"`
+	Description string       `json:"description,omitempty"`
+	Props       []goldenProp `json:"props,omitempty"`
+	Links       []goldenLink `json:"links,omitempty"`
+}
+
+type goldenLink struct {
+	Description string       `json:"description,omitempty"`
+	Href        string       `json:"href"`
+	Props       []goldenProp `json:"props,omitempty"`
+}
+
+type goldenProp struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func goldenProps(props []policy.Property) []goldenProp {
+	var golden []goldenProp
+	for _, prop := range props {
+		golden = append(golden, goldenProp{Name: prop.Name, Value: prop.Value})
+	}
+	return golden
+}
+
+func goldenLinks(links []policy.Link) []goldenLink {
+	var golden []goldenLink
+	for _, link := range links {
+		golden = append(golden, goldenLink{Description: link.Description, Href: link.Href, Props: goldenProps(link.Props)})
+	}
+	return golden
+}
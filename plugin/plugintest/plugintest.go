@@ -0,0 +1,148 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugintest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	hplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// ServeGRPC serves the provider over an in-process gRPC connection and returns
+// the client side of the connection. Calls to the returned provider go through
+// the same transport as calls to a plugin binary.
+func ServeGRPC(t testing.TB, impl policy.Provider) policy.Provider {
+	t.Helper()
+	client, _ := hplugin.TestPluginGRPCConn(t, false, map[string]hplugin.Plugin{
+		plugin.PVPPluginName: &plugin.PVPPlugin{Impl: impl},
+	})
+	t.Cleanup(func() { _ = client.Close() })
+	raw, err := client.Dispense(plugin.PVPPluginName)
+	require.NoError(t, err)
+	return raw.(policy.Provider)
+}
+
+// LaunchBinary launches the plugin binary at the given path and returns the
+// client of the plugin. The plugin is stopped when the test completes.
+func LaunchBinary(t testing.TB, path string) policy.Provider {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	sum := sha256.Sum256(content)
+	manifest := plugin.Manifest{
+		Metadata:       plugin.Metadata{ID: "plugintest", Types: []string{plugin.PVPPluginName}},
+		ExecutablePath: path,
+		Checksum:       hex.EncodeToString(sum[:]),
+	}
+
+	logger := hclog.New(&hclog.LoggerOptions{Name: "plugintest", Output: io.Discard})
+	createClient := plugin.ClientFactory(logger)
+	var client *hplugin.Client
+	provider, err := plugin.NewPolicyPlugin(manifest, func(manifest plugin.Manifest) (*hplugin.Client, error) {
+		var err error
+		client, err = createClient(manifest)
+		return client, err
+	})
+	if client != nil {
+		t.Cleanup(client.Kill)
+	}
+	require.NoError(t, err)
+	return provider
+}
+
+// errInjected is returned by the recorder when a case checks that errors
+// are propagated through the transport.
+var errInjected = errors.New("plugintest: injected failure")
+
+// recorder wraps a policy.Provider to record what it receives from and returns to
+// the transport.
+type recorder struct {
+	impl policy.Provider
+
+	mu            sync.Mutex
+	configuration map[string]string
+	policy        policy.Policy
+	result        policy.PVPResult
+	err           error
+	fail          bool
+}
+
+var _ policy.Provider = (*recorder)(nil)
+
+func (r *recorder) Configure(configuration map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configuration = configuration
+	r.err = r.impl.Configure(configuration)
+	return r.err
+}
+
+func (r *recorder) Generate(p policy.Policy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = p
+	r.err = r.impl.Generate(p)
+	return r.err
+}
+
+func (r *recorder) GetResults(p policy.Policy) (policy.PVPResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = p
+	if r.fail {
+		r.err = errInjected
+		return policy.PVPResult{}, r.err
+	}
+	r.result, r.err = r.impl.GetResults(p)
+	return r.result, r.err
+}
+
+func (r *recorder) lastPolicy() policy.Policy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.policy
+}
+
+func (r *recorder) lastConfiguration() map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.configuration
+}
+
+func (r *recorder) lastResult() (policy.PVPResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.result, r.err
+}
+
+// reset clears what the recorder recorded so that a case does not see the state of
+// the previous one.
+func (r *recorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configuration = nil
+	r.policy = nil
+	r.result = policy.PVPResult{}
+	r.err = nil
+	r.fail = false
+}
+
+func (r *recorder) setFail(fail bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fail = fail
+}
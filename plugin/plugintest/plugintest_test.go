@@ -0,0 +1,181 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugintest
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oscal-compass/oscal-sdk-go/extensions"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var (
+	testPolicy = policy.FromRuleSets([]extensions.RuleSet{
+		{
+			Rule: extensions.Rule{
+				ID:          "etcd_key_file",
+				Description: "Ensure that the --key-file argument is set as appropriate",
+				Parameter: &extensions.Parameter{
+					ID:          "file_name",
+					Description: "A parameter for a file name",
+					Value:       "my_file",
+				},
+			},
+			Checks: []extensions.Check{
+				{
+					ID:          "etcd_key_file",
+					Description: "Check that the --key-file argument is set as appropriate",
+				},
+			},
+		},
+		{
+			Rule: extensions.Rule{
+				ID:          "etcd_cert_file",
+				Description: "Ensure that the --cert-file argument is set as appropriate",
+			},
+			Checks: []extensions.Check{
+				{
+					ID:          "etcd_cert_file",
+					Description: "Check that the --cert-file argument is set as appropriate",
+				},
+			},
+		},
+	})
+	defaultValue = "yes"
+	testOptions  = Options{
+		Manifest: plugin.Manifest{
+			Configuration: []plugin.ConfigurationOption{
+				{Name: "results-dir", Required: true},
+				{Name: "strict", Default: &defaultValue},
+			},
+		},
+		Configuration:        map[string]string{"results-dir": "results"},
+		InvalidConfiguration: map[string]string{"results-dir": ""},
+		Policy:               testPolicy,
+		Results:              []policy.Result{policy.ResultPass, policy.ResultFail},
+	}
+)
+
+// fakeProvider passes the checks with a parameter and fails the others.
+type fakeProvider struct {
+	configuration map[string]string
+	collected     time.Time
+}
+
+func (p *fakeProvider) Configure(configuration map[string]string) error {
+	if configuration["results-dir"] == "" {
+		return errors.New("results-dir is required")
+	}
+	p.configuration = configuration
+	return nil
+}
+
+func (p *fakeProvider) Generate(policy.Policy) error {
+	return nil
+}
+
+func (p *fakeProvider) GetResults(pl policy.Policy) (policy.PVPResult, error) {
+	result := policy.PVPResult{}
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			if check.ID == UnknownCheckID {
+				continue
+			}
+			subject := policy.Subject{
+				Title:       fmt.Sprintf("File: %s", check.ID),
+				Type:        "resource",
+				ResourceID:  check.ID,
+				Result:      policy.ResultFail,
+				EvaluatedOn: p.collected.Add(time.Second),
+				Reason:      "no parameter",
+			}
+			if ruleSet.Rule.Parameter != nil {
+				subject.Result = policy.ResultPass
+				subject.Reason = fmt.Sprintf("%s is %s", ruleSet.Rule.Parameter.ID, ruleSet.Rule.Parameter.Value)
+			}
+			result.ObservationsByCheck = append(result.ObservationsByCheck, policy.ObservationByCheck{
+				Title:     ruleSet.Rule.ID,
+				CheckID:   check.ID,
+				Methods:   []string{"TEST-AUTOMATED"},
+				Collected: p.collected,
+				Subjects:  []policy.Subject{subject},
+				Props:     []policy.Property{{Name: "strict", Value: p.configuration["strict"]}},
+			})
+		}
+	}
+	return result, nil
+}
+
+func TestRun(t *testing.T) {
+	// nanoseconds and a non-UTC location must survive the transport
+	collected := time.Date(2025, 3, 24, 10, 0, 0, 123456789, time.FixedZone("CET", 3600))
+	Run(t, &fakeProvider{collected: collected}, testOptions)
+}
+
+func TestRunBinary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testplugin")
+	build := exec.Command("go", "build", "-o", path, "./testdata/testplugin")
+	output, err := build.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	provider := LaunchBinary(t, path)
+	require.ErrorContains(t, provider.Configure(map[string]string{}), "results-dir is required")
+
+	RunBinary(t, path, testOptions)
+}
+
+func TestServeGRPC(t *testing.T) {
+	provider := ServeGRPC(t, &fakeProvider{collected: time.Now()})
+	err := provider.Configure(map[string]string{})
+	require.ErrorContains(t, err, "results-dir is required")
+
+	require.NoError(t, provider.Configure(map[string]string{"results-dir": "results", "strict": "no"}))
+	result, err := provider.GetResults(testPolicy)
+	require.NoError(t, err)
+	require.Len(t, result.ObservationsByCheck, 2)
+	require.Equal(t, policy.ResultPass, result.ObservationsByCheck[0].Subjects[0].Result)
+	require.Equal(t, "no", result.ObservationsByCheck[1].Props[0].Value)
+}
+
+func TestAssertGolden(t *testing.T) {
+	provider := &fakeProvider{collected: time.Now()}
+	require.NoError(t, provider.Configure(map[string]string{"results-dir": "results", "strict": "yes"}))
+	result, err := provider.GetResults(testPolicy)
+	require.NoError(t, err)
+	AssertGolden(t, filepath.Join("testdata", "result.golden.json"), result)
+
+	// the golden file does not depend on the order of the observations
+	result.ObservationsByCheck[0], result.ObservationsByCheck[1] = result.ObservationsByCheck[1], result.ObservationsByCheck[0]
+	AssertGolden(t, filepath.Join("testdata", "result.golden.json"), result)
+}
+
+func TestNormalizeResult(t *testing.T) {
+	result := policy.PVPResult{
+		ObservationsByCheck: []policy.ObservationByCheck{
+			{CheckID: "b", Collected: time.Now(), Subjects: []policy.Subject{{ResourceID: "s", EvaluatedOn: time.Now()}}},
+			{CheckID: "a", Collected: time.Now()},
+		},
+		InventoryItems: []policy.InventoryItem{{ID: "2"}, {ID: "1"}},
+	}
+	normalized := NormalizeResult(result)
+	require.Equal(t, "a", normalized.ObservationsByCheck[0].CheckID)
+	require.True(t, normalized.ObservationsByCheck[1].Collected.IsZero())
+	require.True(t, normalized.ObservationsByCheck[1].Subjects[0].EvaluatedOn.IsZero())
+	require.Equal(t, "1", normalized.InventoryItems[0].ID)
+
+	// the given result is not modified
+	require.Equal(t, "b", result.ObservationsByCheck[0].CheckID)
+	require.False(t, result.ObservationsByCheck[0].Subjects[0].EvaluatedOn.IsZero())
+	require.Equal(t, "2", result.InventoryItems[0].ID)
+}
@@ -0,0 +1,69 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+// Command testplugin is a PVP plugin used to test running the conformance cases
+// against a plugin binary. It passes the checks with a parameter and fails the others.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	hplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/plugin"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+type provider struct{}
+
+func (p *provider) Configure(configuration map[string]string) error {
+	if configuration["results-dir"] == "" {
+		return errors.New("results-dir is required")
+	}
+	return nil
+}
+
+func (p *provider) Generate(policy.Policy) error {
+	return nil
+}
+
+func (p *provider) GetResults(pl policy.Policy) (policy.PVPResult, error) {
+	now := time.Now()
+	result := policy.PVPResult{}
+	for _, ruleSet := range pl {
+		for _, check := range ruleSet.Checks {
+			subject := policy.Subject{
+				Title:       fmt.Sprintf("File: %s", check.ID),
+				Type:        "resource",
+				ResourceID:  check.ID,
+				Result:      policy.ResultFail,
+				EvaluatedOn: now,
+				Reason:      "no parameter",
+			}
+			if ruleSet.Rule.Parameter != nil {
+				subject.Result = policy.ResultPass
+				subject.Reason = fmt.Sprintf("%s is %s", ruleSet.Rule.Parameter.ID, ruleSet.Rule.Parameter.Value)
+			}
+			result.ObservationsByCheck = append(result.ObservationsByCheck, policy.ObservationByCheck{
+				Title:     ruleSet.Rule.ID,
+				CheckID:   check.ID,
+				Methods:   []string{"TEST-AUTOMATED"},
+				Collected: now,
+				Subjects:  []policy.Subject{subject},
+			})
+		}
+	}
+	return result, nil
+}
+
+func main() {
+	plugin.Register(plugin.ServeConfig{
+		PluginSet: map[string]hplugin.Plugin{
+			plugin.PVPPluginName: &plugin.PVPPlugin{Impl: &provider{}},
+		},
+	})
+}
@@ -7,13 +7,20 @@ package plugin
 
 import (
 	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
 	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
 )
 
 // Client must return an implementation of the corresponding interface that communicates over an RPC client.
-var _ policy.Provider = (*pvpClient)(nil)
+var (
+	_ policy.Provider  = (*pvpClient)(nil)
+	_ policy.Evaluator = (*pvpClient)(nil)
+)
 
 type pvpClient struct {
 	client proto.PolicyEngineClient
@@ -48,3 +55,21 @@ func (pvp *pvpClient) GetResults(p policy.Policy) (policy.PVPResult, error) {
 	pvpResult := NewResultFromProto(resp.Result)
 	return pvpResult, nil
 }
+
+// Evaluate returns ErrEvaluationNotSupported when the plugin does not
+// evaluate collected evidence, including plugins built before the
+// Evaluate method was added to the service.
+func (pvp *pvpClient) Evaluate(p policy.Policy, evidence []policy.Evidence) (policy.PVPResult, error) {
+	request := &proto.EvaluateRequest{
+		Rule:     PolicyToProto(p).Rule,
+		Evidence: EvidenceToProto(evidence),
+	}
+	resp, err := pvp.client.Evaluate(context.Background(), request)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return policy.PVPResult{}, fmt.Errorf("%w: %v", ErrEvaluationNotSupported, err)
+		}
+		return policy.PVPResult{}, err
+	}
+	return NewResultFromProto(resp.Result), nil
+}
@@ -7,6 +7,7 @@ package plugin
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc/status"
 
@@ -57,3 +58,19 @@ func (p *pvpService) GetResults(ctx context.Context, request *proto.PolicyReques
 	}
 	return &proto.ResultsResponse{Result: ResultsToProto(result)}, nil
 }
+
+func (p *pvpService) Evaluate(ctx context.Context, request *proto.EvaluateRequest) (*proto.ResultsResponse, error) {
+	evaluator, ok := p.Impl.(policy.Evaluator)
+	if !ok {
+		return &proto.ResultsResponse{}, status.Error(codes.Unimplemented, ErrEvaluationNotSupported.Error())
+	}
+	policy := NewPolicyFromProto(&proto.PolicyRequest{Rule: request.Rule})
+	result, err := evaluator.Evaluate(policy, NewEvidenceFromProto(request.Evidence))
+	if errors.Is(err, ErrEvaluationNotSupported) {
+		return &proto.ResultsResponse{}, status.Error(codes.Unimplemented, err.Error())
+	}
+	if err != nil {
+		return &proto.ResultsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.ResultsResponse{Result: ResultsToProto(result)}, nil
+}
@@ -0,0 +1,39 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Client must return an implementation of the corresponding interface that communicates over an RPC client.
+var _ policy.Processor = (*processorClient)(nil)
+
+type processorClient struct {
+	client proto.ResultProcessorClient
+}
+
+func (p *processorClient) Configure(configuration map[string]string) error {
+	request := proto.ConfigureRequest{
+		Settings: configuration,
+	}
+	_, err := p.client.Configure(context.Background(), &request)
+	return err
+}
+
+func (p *processorClient) Process(results []policy.PVPResult) ([]policy.PVPResult, error) {
+	request := &proto.ProcessRequest{
+		Results: ResultListToProto(results),
+	}
+	resp, err := p.client.Process(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+	return NewResultListFromProto(resp.Results), nil
+}
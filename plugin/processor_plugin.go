@@ -0,0 +1,45 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Plugin must return an RPC server for this plugin type.
+var _ proto.ResultProcessorServer = (*processorService)(nil)
+
+type processorService struct {
+	proto.UnimplementedResultProcessorServer
+	Impl policy.Processor
+}
+
+func FromProcessor(p policy.Processor) proto.ResultProcessorServer {
+	return &processorService{
+		Impl: p,
+	}
+}
+
+func (p *processorService) Configure(ctx context.Context, request *proto.ConfigureRequest) (*proto.ConfigureResponse, error) {
+	if err := p.Impl.Configure(request.Settings); err != nil {
+		return &proto.ConfigureResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.ConfigureResponse{}, nil
+}
+
+func (p *processorService) Process(ctx context.Context, request *proto.ProcessRequest) (*proto.ProcessResponse, error) {
+	results, err := p.Impl.Process(NewResultListFromProto(request.Results))
+	if err != nil {
+		return &proto.ProcessResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.ProcessResponse{Results: ResultListToProto(results)}, nil
+}
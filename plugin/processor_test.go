@@ -0,0 +1,52 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"testing"
+
+	hplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// testProcessor records the results and keeps the first one.
+type testProcessor struct {
+	configuration map[string]string
+	results       []policy.PVPResult
+}
+
+func (p *testProcessor) Configure(configuration map[string]string) error {
+	p.configuration = configuration
+	return nil
+}
+
+func (p *testProcessor) Process(results []policy.PVPResult) ([]policy.PVPResult, error) {
+	p.results = results
+	return results[:1], nil
+}
+
+func TestProcessorPlugin(t *testing.T) {
+	impl := &testProcessor{}
+	client, _ := hplugin.TestPluginGRPCConn(t, false, map[string]hplugin.Plugin{
+		ProcessorPluginName: &ProcessorPlugin{Impl: impl},
+	})
+	t.Cleanup(func() { _ = client.Close() })
+	raw, err := client.Dispense(ProcessorPluginName)
+	require.NoError(t, err)
+	processor := raw.(policy.Processor)
+
+	require.NoError(t, processor.Configure(map[string]string{"option": "value"}))
+	require.Equal(t, map[string]string{"option": "value"}, impl.configuration)
+
+	other := policy.PVPResult{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "other"}}}
+	results, err := processor.Process([]policy.PVPResult{testPolicyPvpResult, other})
+	require.NoError(t, err)
+	require.Equal(t, []policy.PVPResult{testPolicyPvpResult}, results)
+	require.Len(t, impl.results, 2)
+	require.Equal(t, "other", impl.results[1].ObservationsByCheck[0].CheckID)
+}
@@ -0,0 +1,40 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Client must return an implementation of the corresponding interface that communicates over an RPC client.
+var _ policy.Remediator = (*remediationClient)(nil)
+
+type remediationClient struct {
+	client proto.RemediationEngineClient
+}
+
+func (r *remediationClient) Configure(configuration map[string]string) error {
+	request := proto.ConfigureRequest{
+		Settings: configuration,
+	}
+	_, err := r.client.Configure(context.Background(), &request)
+	return err
+}
+
+func (r *remediationClient) Remediate(p policy.Policy, result policy.PVPResult) ([]policy.Remediation, error) {
+	request := &proto.RemediateRequest{
+		Rule:   PolicyToProto(p).Rule,
+		Result: ResultsToProto(result),
+	}
+	resp, err := r.client.Remediate(context.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemediationsFromProto(resp.Remediations), nil
+}
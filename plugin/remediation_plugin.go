@@ -0,0 +1,47 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Plugin must return an RPC server for this plugin type.
+var _ proto.RemediationEngineServer = (*remediationService)(nil)
+
+type remediationService struct {
+	proto.UnimplementedRemediationEngineServer
+	Impl policy.Remediator
+}
+
+func FromRemediator(r policy.Remediator) proto.RemediationEngineServer {
+	return &remediationService{
+		Impl: r,
+	}
+}
+
+func (r *remediationService) Configure(ctx context.Context, request *proto.ConfigureRequest) (*proto.ConfigureResponse, error) {
+	if err := r.Impl.Configure(request.Settings); err != nil {
+		return &proto.ConfigureResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.ConfigureResponse{}, nil
+}
+
+func (r *remediationService) Remediate(ctx context.Context, request *proto.RemediateRequest) (*proto.RemediateResponse, error) {
+	policy := NewPolicyFromProto(&proto.PolicyRequest{Rule: request.Rule})
+	result := NewResultFromProto(request.GetResult())
+	remediations, err := r.Impl.Remediate(policy, result)
+	if err != nil {
+		return &proto.RemediateResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.RemediateResponse{Remediations: RemediationsToProto(remediations)}, nil
+}
@@ -0,0 +1,87 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"errors"
+	"testing"
+
+	hplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+var testRemediations = []policy.Remediation{
+	{
+		Title:       "Set the cert file",
+		Description: "Set --cert-file on etcd",
+		CheckID:     "test-check-1",
+		ResourceID:  "test-resource-1",
+		Artifacts: []policy.Artifact{
+			{
+				Name:      "etcd-patch.yaml",
+				MediaType: "application/yaml",
+				Content:   []byte("spec:\n  certFile: /etc/etcd/cert.pem\n"),
+			},
+		},
+		Props: []policy.Property{
+			{
+				Name:  "remediation-type",
+				Value: "patch",
+			},
+		},
+	},
+}
+
+// testRemediator records the requests and returns the configured remediations.
+type testRemediator struct {
+	configuration map[string]string
+	policy        policy.Policy
+	result        policy.PVPResult
+	remediations  []policy.Remediation
+	err           error
+}
+
+func (r *testRemediator) Configure(configuration map[string]string) error {
+	r.configuration = configuration
+	return nil
+}
+
+func (r *testRemediator) Remediate(p policy.Policy, result policy.PVPResult) ([]policy.Remediation, error) {
+	r.policy = p
+	r.result = result
+	return r.remediations, r.err
+}
+
+func TestRemediationPlugin(t *testing.T) {
+	impl := &testRemediator{remediations: testRemediations}
+	client, _ := hplugin.TestPluginGRPCConn(t, false, map[string]hplugin.Plugin{
+		RemediationPluginName: &RemediationPlugin{Impl: impl},
+	})
+	t.Cleanup(func() { _ = client.Close() })
+	raw, err := client.Dispense(RemediationPluginName)
+	require.NoError(t, err)
+	remediator := raw.(policy.Remediator)
+
+	require.NoError(t, remediator.Configure(map[string]string{"option": "value"}))
+	require.Equal(t, map[string]string{"option": "value"}, impl.configuration)
+
+	remediations, err := remediator.Remediate(testPolicy, testPolicyPvpResult)
+	require.NoError(t, err)
+	require.Equal(t, testRemediations, remediations)
+	require.Equal(t, testPolicy, impl.policy)
+	require.Equal(t, testPolicyPvpResult, impl.result)
+
+	impl.err = errors.New("no fix available")
+	_, err = remediator.Remediate(testPolicy, testPolicyPvpResult)
+	require.ErrorContains(t, err, "no fix available")
+}
+
+func TestRemediationsRoundTrip(t *testing.T) {
+	require.Equal(t, testRemediations, NewRemediationsFromProto(RemediationsToProto(testRemediations)))
+	require.Nil(t, NewRemediationsFromProto(RemediationsToProto(nil)))
+}
@@ -0,0 +1,252 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// Remote describes a plugin that is already running and is reached over the network
+// instead of being launched by the host.
+//
+// The plugin is reached either over TCP with mutual TLS at Address, or through the go-plugin
+// reattach configuration printed by a plugin started with `serve --reattach`.
+type Remote struct {
+	// Address is the host:port of the plugin gRPC services.
+	Address string `json:"address,omitempty"`
+	// CAFile is the PEM encoded CA certificate the server certificate is verified against.
+	CAFile string `json:"caFile,omitempty"`
+	// CertFile is the PEM encoded client certificate.
+	CertFile string `json:"certFile,omitempty"`
+	// KeyFile is the PEM encoded client private key.
+	KeyFile string `json:"keyFile,omitempty"`
+	// ServerName overrides the name the server certificate is verified for.
+	// It defaults to the host of the Address.
+	ServerName string `json:"serverName,omitempty"`
+	// Reattach connects to a plugin serving in reattach mode.
+	Reattach *Reattach `json:"reattach,omitempty"`
+}
+
+// Reattach is the address of a plugin serving in reattach mode.
+type Reattach struct {
+	// Network is the network of the plugin listener, unix or tcp.
+	Network string `json:"network"`
+	// Address is the socket path or host:port of the plugin listener.
+	Address string `json:"address"`
+	// Pid is the process ID of the plugin, which must run on the same host.
+	Pid int `json:"pid"`
+}
+
+// Validate returns an error if the Remote has invalid fields.
+func (r *Remote) Validate() error {
+	if r.Reattach != nil {
+		if r.Address != "" {
+			return errors.New("remote plugin cannot set both an address and a reattach configuration")
+		}
+		switch r.Reattach.Network {
+		case "unix", "tcp":
+		default:
+			return fmt.Errorf("invalid reattach network %q: must be one of unix, tcp", r.Reattach.Network)
+		}
+		if r.Reattach.Address == "" {
+			return errors.New("reattach address cannot be empty")
+		}
+		if r.Reattach.Pid <= 0 {
+			return errors.New("reattach pid must be the process ID of the plugin")
+		}
+		return nil
+	}
+	if r.Address == "" {
+		return errors.New("remote plugin address cannot be empty")
+	}
+	if r.CAFile == "" || r.CertFile == "" || r.KeyFile == "" {
+		return fmt.Errorf("remote plugin %s requires a CA file, a client certificate and a client key", r.Address)
+	}
+	return nil
+}
+
+// resolveFiles makes the relative certificate and key
+// paths relative to the given directory.
+func (r *Remote) resolveFiles(dir string) {
+	for _, file := range []*string{&r.CAFile, &r.CertFile, &r.KeyFile} {
+		if *file != "" && !filepath.IsAbs(*file) {
+			*file = filepath.Join(dir, *file)
+		}
+	}
+}
+
+// clientTLSConfig returns the mutual TLS configuration for the Remote.
+func (r *Remote) clientTLSConfig() (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(r.CertFile, r.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	pool, err := loadCertPool(r.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	serverName := r.ServerName
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(r.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote plugin address %s: %w", r.Address, err)
+		}
+		serverName = host
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      pool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// reattachConfig returns the go-plugin configuration for the Reattach.
+func (r *Reattach) reattachConfig() (*plugin.ReattachConfig, error) {
+	var addr net.Addr
+	var err error
+	switch r.Network {
+	case "unix":
+		addr, err = net.ResolveUnixAddr("unix", r.Address)
+	default:
+		addr, err = net.ResolveTCPAddr("tcp", r.Address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ReattachConfig{
+		Protocol:        plugin.ProtocolGRPC,
+		ProtocolVersion: ProtocolVersion,
+		Addr:            addr,
+		Pid:             r.Pid,
+		// The host does not own the plugin process
+		Test: true,
+	}, nil
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	caData, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caData) {
+		return nil, fmt.Errorf("no PEM encoded certificates found in %s", caFile)
+	}
+	return pool, nil
+}
+
+// remoteConns holds the connections to remote plugins until Cleanup.
+var remoteConns = struct {
+	sync.Mutex
+	conns []*grpc.ClientConn
+}{}
+
+func trackRemoteConn(conn *grpc.ClientConn) {
+	remoteConns.Lock()
+	defer remoteConns.Unlock()
+	remoteConns.conns = append(remoteConns.conns, conn)
+}
+
+func cleanupClients() {
+	plugin.CleanupClients()
+	remoteConns.Lock()
+	defer remoteConns.Unlock()
+	for _, conn := range remoteConns.conns {
+		_ = conn.Close()
+	}
+	remoteConns.conns = nil
+}
+
+// RemoteFactoryFunc defines a function signature for connecting
+// to remote policy plugins.
+type RemoteFactoryFunc func(manifest Manifest) (policy.Provider, error)
+
+// RemoteFactory returns a factory function for connecting to remote policy plugins. It supports
+// the same options as ClientFactory, except `WithSandbox` and `WithPluginLogs` which are ignored
+// as the host does not launch remote plugins.
+func RemoteFactory(logger hclog.Logger, opts ...ClientOption) RemoteFactoryFunc {
+	options := &clientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return func(manifest Manifest) (policy.Provider, error) {
+		if err := options.verifier.recheck(manifest); err != nil {
+			return nil, err
+		}
+		return NewRemotePolicyPlugin(manifest, logger)
+	}
+}
+
+// NewRemotePolicyPlugin connects to the remote policy plugin described in the manifest.
+// The connection is closed by Cleanup.
+func NewRemotePolicyPlugin(manifest Manifest, logger hclog.Logger) (policy.Provider, error) {
+	remote := manifest.Remote
+	if remote == nil {
+		return nil, fmt.Errorf("plugin %s is not a remote plugin", manifest.ID)
+	}
+	if err := remote.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid remote plugin %s: %w", manifest.ID, err)
+	}
+
+	if remote.Reattach != nil {
+		reattach, err := remote.Reattach.reattachConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid reattach configuration for plugin %s: %w", manifest.ID, err)
+		}
+		// The plugin process is not owned by the host, so the client is not managed
+		// and only the connection is closed by Cleanup.
+		client := plugin.NewClient(&plugin.ClientConfig{
+			HandshakeConfig:  Handshake,
+			Logger:           logger.Named(manifest.ID),
+			AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+			Plugins:          SupportedPlugins,
+			Reattach:         reattach,
+			GRPCDialOptions:  grpcDialOptions(),
+		})
+		rpcClient, err := client.Client()
+		if err != nil {
+			return nil, fmt.Errorf("failed to reattach to plugin %s at %s: %w", manifest.ID, remote.Reattach.Address, err)
+		}
+		if grpcClient, ok := rpcClient.(*plugin.GRPCClient); ok {
+			trackRemoteConn(grpcClient.Conn)
+		}
+		raw, err := rpcClient.Dispense(PVPPluginName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dispense plugin %s: %w", manifest.ID, err)
+		}
+		logger.Debug(fmt.Sprintf("Reattached to plugin %s at %s", manifest.ID, remote.Reattach.Address))
+		return raw.(policy.Provider), nil
+	}
+
+	tlsConfig, err := remote.clientTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS for plugin %s: %w", manifest.ID, err)
+	}
+	dialOptions := append(grpcDialOptions(), grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	conn, err := grpc.NewClient(remote.Address, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to plugin %s at %s: %w", manifest.ID, remote.Address, err)
+	}
+	trackRemoteConn(conn)
+	logger.Debug(fmt.Sprintf("Connected to remote plugin %s at %s", manifest.ID, remote.Address))
+	return &pvpClient{client: proto.NewPolicyEngineClient(conn)}, nil
+}
@@ -0,0 +1,330 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	hplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oscal-compass/compliance-to-policy-go/v2/policy"
+)
+
+// configuredProvider records the configuration it receives.
+type configuredProvider struct {
+	configuration map[string]string
+}
+
+func (p *configuredProvider) Configure(configuration map[string]string) error {
+	p.configuration = configuration
+	return nil
+}
+
+func (p *configuredProvider) Generate(policy.Policy) error {
+	return nil
+}
+
+func (p *configuredProvider) GetResults(policy.Policy) (policy.PVPResult, error) {
+	return policy.PVPResult{ObservationsByCheck: []policy.ObservationByCheck{{CheckID: "check", Title: "remote"}}}, nil
+}
+
+// testPKI holds the PEM files of a test CA and of the
+// server and client certificates it issued.
+type testPKI struct {
+	caFile, serverCert, serverKey, clientCert, clientKey string
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+	dir := t.TempDir()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "c2p test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	pki := testPKI{caFile: filepath.Join(dir, "ca.crt")}
+	require.NoError(t, os.WriteFile(pki.caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0600))
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			DNSNames:     []string{"localhost"},
+			IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		certFile := filepath.Join(dir, name+".crt")
+		keyFile := filepath.Join(dir, name+".key")
+		require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+		require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600))
+		return certFile, keyFile
+	}
+	pki.serverCert, pki.serverKey = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	pki.clientCert, pki.clientKey = issue("client", 3, x509.ExtKeyUsageClientAuth)
+	return pki
+}
+
+// serveTestListener serves the provider on a localhost listener
+// with mutual TLS and returns the listener address.
+func serveTestListener(t *testing.T, pki testPKI, provider policy.Provider) string {
+	t.Helper()
+	tlsConfig, err := serverTLSConfig(pki.serverCert, pki.serverKey, pki.caFile)
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serveListener(ctx, testServeConfig(provider), listener, tlsConfig)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+	return listener.Addr().String()
+}
+
+func testServeConfig(provider policy.Provider) ServeConfig {
+	return ServeConfig{
+		PluginSet: map[string]hplugin.Plugin{PVPPluginName: &PVPPlugin{Impl: provider}},
+		Logger:    hclog.NewNullLogger(),
+	}
+}
+
+func TestRemote_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		remote  Remote
+		wantErr string
+	}{
+		{
+			name:   "Valid/TLS",
+			remote: Remote{Address: "localhost:9443", CAFile: "ca.crt", CertFile: "client.crt", KeyFile: "client.key"},
+		},
+		{
+			name:   "Valid/Reattach",
+			remote: Remote{Reattach: &Reattach{Network: "unix", Address: "/tmp/plugin.sock", Pid: 42}},
+		},
+		{
+			name:    "Invalid/MissingAddress",
+			remote:  Remote{},
+			wantErr: "remote plugin address cannot be empty",
+		},
+		{
+			name:    "Invalid/MissingCertificates",
+			remote:  Remote{Address: "localhost:9443", CAFile: "ca.crt"},
+			wantErr: "remote plugin localhost:9443 requires a CA file, a client certificate and a client key",
+		},
+		{
+			name:    "Invalid/AddressAndReattach",
+			remote:  Remote{Address: "localhost:9443", Reattach: &Reattach{Network: "unix", Address: "/tmp/plugin.sock", Pid: 42}},
+			wantErr: "remote plugin cannot set both an address and a reattach configuration",
+		},
+		{
+			name:    "Invalid/ReattachNetwork",
+			remote:  Remote{Reattach: &Reattach{Network: "udp", Address: "localhost:1234", Pid: 42}},
+			wantErr: "invalid reattach network \"udp\": must be one of unix, tcp",
+		},
+		{
+			name:    "Invalid/ReattachPid",
+			remote:  Remote{Reattach: &Reattach{Network: "unix", Address: "/tmp/plugin.sock"}},
+			wantErr: "reattach pid must be the process ID of the plugin",
+		},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.remote.Validate()
+			if c.wantErr != "" {
+				require.EqualError(t, err, c.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewRemotePolicyPlugin(t *testing.T) {
+	pki := newTestPKI(t)
+	provider := &configuredProvider{}
+	address := serveTestListener(t, pki, provider)
+	t.Cleanup(Cleanup)
+
+	manifest := Manifest{
+		Metadata: Metadata{ID: "remote", Types: []string{PVPPluginName}},
+		Remote: &Remote{
+			Address:  address,
+			CAFile:   pki.caFile,
+			CertFile: pki.clientCert,
+			KeyFile:  pki.clientKey,
+		},
+	}
+	remote, err := NewRemotePolicyPlugin(manifest, hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.NoError(t, remote.Configure(map[string]string{"namespace": "default"}))
+	require.Equal(t, map[string]string{"namespace": "default"}, provider.configuration)
+	results, err := remote.GetResults(policy.Policy{})
+	require.NoError(t, err)
+	require.Len(t, results.ObservationsByCheck, 1)
+	require.Equal(t, "remote", results.ObservationsByCheck[0].Title)
+
+	// The server only accepts certificates issued for client authentication
+	manifest.Remote = &Remote{Address: address, CAFile: pki.caFile, CertFile: pki.serverCert, KeyFile: pki.serverKey}
+	remote, err = NewRemotePolicyPlugin(manifest, hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.Error(t, remote.Configure(map[string]string{}))
+
+	// The server certificate must be issued for the address
+	manifest.Remote = &Remote{Address: address, CAFile: pki.caFile, CertFile: pki.clientCert, KeyFile: pki.clientKey, ServerName: "example.com"}
+	remote, err = NewRemotePolicyPlugin(manifest, hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.Error(t, remote.Configure(map[string]string{}))
+
+	_, err = NewRemotePolicyPlugin(Manifest{Metadata: Metadata{ID: "local"}}, hclog.NewNullLogger())
+	require.EqualError(t, err, "plugin local is not a remote plugin")
+}
+
+func TestRemoteEvaluateLargeEvidence(t *testing.T) {
+	pki := newTestPKI(t)
+	provider := &testEvaluator{}
+	address := serveTestListener(t, pki, provider)
+	t.Cleanup(Cleanup)
+
+	manifest := Manifest{
+		Metadata: Metadata{ID: "remote", Types: []string{PVPPluginName}},
+		Remote: &Remote{
+			Address:  address,
+			CAFile:   pki.caFile,
+			CertFile: pki.clientCert,
+			KeyFile:  pki.clientKey,
+		},
+	}
+	remote, err := NewRemotePolicyPlugin(manifest, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	// The evidence is larger than the gRPC default message size
+	evidence := []policy.Evidence{policy.NewEvidence("dump.json", "application/json", make([]byte, 5<<20))}
+	_, err = remote.(policy.Evaluator).Evaluate(policy.Policy{}, evidence)
+	require.NoError(t, err)
+	require.Len(t, provider.evidence, 1)
+	require.Equal(t, evidence[0].Digest, policy.Digest(provider.evidence[0].Content))
+}
+
+func TestServe(t *testing.T) {
+	ctx := context.Background()
+	err := Serve(ctx, testServeConfig(&configuredProvider{}), []string{}, nil)
+	require.EqualError(t, err, "one of --listen or --reattach is required")
+	err = Serve(ctx, testServeConfig(&configuredProvider{}), []string{"--listen", "127.0.0.1:0", "--reattach"}, nil)
+	require.EqualError(t, err, "--listen and --reattach cannot be used together")
+	err = Serve(ctx, testServeConfig(&configuredProvider{}), []string{"--listen", "127.0.0.1:0"}, nil)
+	require.EqualError(t, err, "--tls-cert, --tls-key and --client-ca are required with --listen")
+}
+
+func TestServeReattach(t *testing.T) {
+	provider := &configuredProvider{}
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, testServeConfig(provider), []string{"--reattach"}, writer)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	var reattach Reattach
+	require.NoError(t, json.NewDecoder(reader).Decode(&reattach))
+	require.Equal(t, os.Getpid(), reattach.Pid)
+
+	manifest := Manifest{
+		Metadata: Metadata{ID: "reattach", Types: []string{PVPPluginName}},
+		Remote:   &Remote{Reattach: &reattach},
+	}
+	remote, err := NewRemotePolicyPlugin(manifest, hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.NoError(t, remote.Configure(map[string]string{"namespace": "default"}))
+	require.Equal(t, map[string]string{"namespace": "default"}, provider.configuration)
+
+	// The plugin keeps serving after the host closes the connection
+	Cleanup()
+	remote, err = NewRemotePolicyPlugin(manifest, hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.NoError(t, remote.Configure(map[string]string{"namespace": "other"}))
+	require.Equal(t, map[string]string{"namespace": "other"}, provider.configuration)
+	Cleanup()
+}
+
+func TestFindPluginsRemote(t *testing.T) {
+	pluginDir := t.TempDir()
+	manifest := Manifest{
+		Metadata: Metadata{ID: "remote", Types: []string{PVPPluginName}},
+		Remote:   &Remote{Address: "localhost:9443", CAFile: "ca.crt", CertFile: "/etc/c2p/client.crt", KeyFile: "client.key"},
+	}
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, ManifestFileName("remote")), data, 0600))
+
+	manifests, err := FindPlugins(pluginDir)
+	require.NoError(t, err)
+	require.True(t, manifests["remote"].IsRemote())
+	absPluginDir, err := filepath.Abs(pluginDir)
+	require.NoError(t, err)
+	require.Equal(t, &Remote{
+		Address:  "localhost:9443",
+		CAFile:   filepath.Join(absPluginDir, "ca.crt"),
+		CertFile: "/etc/c2p/client.crt",
+		KeyFile:  filepath.Join(absPluginDir, "client.key"),
+	}, manifests["remote"].Remote)
+
+	verifications, err := VerifyPlugins([]string{pluginDir})
+	require.NoError(t, err)
+	require.Len(t, verifications, 1)
+	require.NoError(t, verifications[0].Err)
+
+	manifest.Remote.KeyFile = ""
+	data, err = json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, ManifestFileName("remote")), data, 0600))
+	_, err = FindPlugins(pluginDir)
+	require.EqualError(t, err, "invalid remote plugin remote: remote plugin localhost:9443 requires a CA file, a client certificate and a client key")
+}
@@ -0,0 +1,261 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/go-plugin/runner"
+)
+
+// defaultSandboxEnvironment lists the host environment variables
+// passed to every sandboxed plugin.
+var defaultSandboxEnvironment = []string{"PATH"}
+
+// ResourceLimits are resource limits for a plugin process. A zero value
+// does not limit the resource. Resource limits are only supported on Linux.
+type ResourceLimits struct {
+	// CPUSeconds is the maximum CPU time of the process in seconds.
+	CPUSeconds uint64 `json:"cpuSeconds,omitempty"`
+	// MemoryBytes is the maximum size of the process virtual memory in bytes.
+	MemoryBytes uint64 `json:"memoryBytes,omitempty"`
+	// OpenFiles is the maximum number of open file descriptors.
+	OpenFiles uint64 `json:"openFiles,omitempty"`
+}
+
+// IsZero returns true when no resource is limited.
+func (l ResourceLimits) IsZero() bool {
+	return l == ResourceLimits{}
+}
+
+// Merge returns the lower of each pair of limits, ignoring the resources that
+// are not limited, so a plugin manifest can only tighten the limits of the host.
+func (l ResourceLimits) Merge(other ResourceLimits) ResourceLimits {
+	return ResourceLimits{
+		CPUSeconds:  minLimit(l.CPUSeconds, other.CPUSeconds),
+		MemoryBytes: minLimit(l.MemoryBytes, other.MemoryBytes),
+		OpenFiles:   minLimit(l.OpenFiles, other.OpenFiles),
+	}
+}
+
+func minLimit(a, b uint64) uint64 {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// Sandbox configures how the host restricts plugin processes.
+//
+// A sandboxed plugin does not inherit the host environment. It only gets the PATH and the
+// variables of the manifest environment that are in the Sandbox allowlist. Each launch runs in a
+// dedicated directory, which is also the plugin TMPDIR and is removed when the plugin exits.
+type Sandbox struct {
+	// Environment lists the names of host environment variables plugins
+	// may request in their manifest environment.
+	Environment []string
+	// WorkDir is the directory the per-launch working directories are created
+	// in. It defaults to the host temporary directory.
+	WorkDir string
+	// Limits are the resource limits for all plugins. The limits requested
+	// in a manifest are applied when they are lower.
+	Limits ResourceLimits
+}
+
+// Validate returns an error if the Sandbox has invalid fields.
+func (s *Sandbox) Validate() error {
+	for _, name := range s.Environment {
+		if name == "" || strings.ContainsAny(name, "= ") {
+			return fmt.Errorf("invalid sandbox environment variable name %q", name)
+		}
+	}
+	if s.WorkDir != "" {
+		info, err := os.Stat(s.WorkDir)
+		if err != nil {
+			return fmt.Errorf("sandbox working directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("sandbox working directory %s is not a directory", s.WorkDir)
+		}
+	}
+	if !s.Limits.IsZero() && !resourceLimitsSupported {
+		return errors.New("resource limits are only supported on Linux")
+	}
+	return nil
+}
+
+// runnerFunc returns a plugin.ClientConfig RunnerFunc that launches the plugin
+// of the manifest in the sandbox.
+func (s *Sandbox) runnerFunc(manifest Manifest, checksum []byte) func(hclog.Logger, *exec.Cmd, string) (runner.Runner, error) {
+	return func(logger hclog.Logger, spec *exec.Cmd, _ string) (runner.Runner, error) {
+		// The checksum is verified here as the host does not know the
+		// executable path of a custom runner.
+		secure := &plugin.SecureConfig{Checksum: checksum, Hash: sha256.New()}
+		if ok, err := secure.Check(manifest.ExecutablePath); err != nil {
+			return nil, fmt.Errorf("error verifying checksum: %w", err)
+		} else if !ok {
+			return nil, plugin.ErrChecksumsDoNotMatch
+		}
+
+		workDir, err := os.MkdirTemp(s.WorkDir, fmt.Sprintf("c2p-%s-", manifest.ID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create plugin working directory: %w", err)
+		}
+
+		// #nosec G204 -- the executable and args come from the verified manifest
+		cmd := exec.Command(manifest.ExecutablePath, manifest.Args...)
+		cmd.Dir = workDir
+		cmd.Stdin = spec.Stdin
+		cmd.Env = append(s.environment(manifest, os.Getenv), spec.Env...)
+		cmd.Env = append(cmd.Env, "TMPDIR="+workDir)
+
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			_ = os.RemoveAll(workDir)
+			return nil, err
+		}
+		stderr, err := cmd.StderrPipe()
+		if err != nil {
+			_ = os.RemoveAll(workDir)
+			return nil, err
+		}
+		limits := s.Limits
+		if manifest.Limits != nil {
+			limits = limits.Merge(*manifest.Limits)
+		}
+		return &sandboxRunner{
+			logger:  logger,
+			cmd:     cmd,
+			stdout:  stdout,
+			stderr:  stderr,
+			workDir: workDir,
+			limits:  limits,
+		}, nil
+	}
+}
+
+// environment returns the host environment variables that are set among the default variables
+// and the variables of the manifest environment allowed by the Sandbox.
+func (s *Sandbox) environment(manifest Manifest, getenv func(string) string) []string {
+	allowed := make(map[string]struct{}, len(s.Environment))
+	for _, name := range s.Environment {
+		allowed[name] = struct{}{}
+	}
+	var requested []string
+	for _, name := range manifest.Environment {
+		if _, ok := allowed[name]; ok {
+			requested = append(requested, name)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	var env []string
+	for _, names := range [][]string{defaultSandboxEnvironment, requested} {
+		for _, name := range names {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			if value := getenv(name); value != "" {
+				env = append(env, name+"="+value)
+			}
+		}
+	}
+	return env
+}
+
+var _ runner.Runner = (*sandboxRunner)(nil)
+
+// sandboxRunner runs a plugin as a subprocess in a dedicated working
+// directory and applies the resource limits once it is started.
+type sandboxRunner struct {
+	logger  hclog.Logger
+	cmd     *exec.Cmd
+	stdout  io.ReadCloser
+	stderr  io.ReadCloser
+	workDir string
+	limits  ResourceLimits
+	pid     int
+}
+
+func (r *sandboxRunner) Start(_ context.Context) error {
+	r.logger.Debug("starting sandboxed plugin", "path", r.cmd.Path, "args", r.cmd.Args, "dir", r.workDir)
+	if err := r.cmd.Start(); err != nil {
+		_ = os.RemoveAll(r.workDir)
+		return err
+	}
+	r.pid = r.cmd.Process.Pid
+
+	// There is no portable way to set the limits between fork and exec, so they are
+	// applied before the plugin completes the handshake.
+	if !r.limits.IsZero() {
+		if err := applyResourceLimits(r.pid, r.limits); err != nil {
+			_ = r.cmd.Process.Kill()
+			_ = r.cmd.Wait()
+			_ = os.RemoveAll(r.workDir)
+			return fmt.Errorf("failed to apply resource limits: %w", err)
+		}
+	}
+	r.logger.Debug("sandboxed plugin started", "path", r.cmd.Path, "pid", r.pid)
+	return nil
+}
+
+func (r *sandboxRunner) Wait(_ context.Context) error {
+	err := r.cmd.Wait()
+	if removeErr := os.RemoveAll(r.workDir); removeErr != nil {
+		r.logger.Warn("failed to remove plugin working directory", "dir", r.workDir, "error", removeErr)
+	}
+	return err
+}
+
+func (r *sandboxRunner) Kill(_ context.Context) error {
+	if r.cmd.Process != nil {
+		err := r.cmd.Process.Kill()
+		// Kill can be called multiple times
+		if !errors.Is(err, os.ErrProcessDone) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *sandboxRunner) Stdout() io.ReadCloser {
+	return r.stdout
+}
+
+func (r *sandboxRunner) Stderr() io.ReadCloser {
+	return r.stderr
+}
+
+func (r *sandboxRunner) Name() string {
+	return r.cmd.Path
+}
+
+func (r *sandboxRunner) ID() string {
+	return fmt.Sprintf("%d", r.pid)
+}
+
+func (r *sandboxRunner) Diagnose(_ context.Context) string {
+	return fmt.Sprintf("the sandboxed plugin %s failed to start or to negotiate the handshake", r.cmd.Path)
+}
+
+// The host and the plugin share the network and the file system.
+func (r *sandboxRunner) PluginToHost(pluginNet, pluginAddr string) (string, string, error) {
+	return pluginNet, pluginAddr, nil
+}
+
+func (r *sandboxRunner) HostToPlugin(hostNet, hostAddr string) (string, string, error) {
+	return hostNet, hostAddr, nil
+}
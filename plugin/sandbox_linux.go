@@ -0,0 +1,34 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+const resourceLimitsSupported = true
+
+// applyResourceLimits sets the soft and hard limits of a running process.
+func applyResourceLimits(pid int, limits ResourceLimits) error {
+	resources := []struct {
+		resource int
+		value    uint64
+	}{
+		{unix.RLIMIT_CPU, limits.CPUSeconds},
+		{unix.RLIMIT_AS, limits.MemoryBytes},
+		{unix.RLIMIT_NOFILE, limits.OpenFiles},
+	}
+	for _, r := range resources {
+		if r.value == 0 {
+			continue
+		}
+		limit := &unix.Rlimit{Cur: r.value, Max: r.value}
+		if err := unix.Prlimit(pid, r.resource, limit, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestSandbox_ResourceLimits(t *testing.T) {
+	manifest := sandboxTestManifest(t, "read line || exit 0")
+	manifest.Limits = &ResourceLimits{OpenFiles: 32}
+	sandbox := &Sandbox{Limits: ResourceLimits{CPUSeconds: 30, OpenFiles: 64}}
+
+	// the plugin waits for stdin to be closed
+	stdin, stdinWriter := io.Pipe()
+	r := startSandboxed(t, sandbox, manifest, stdin)
+
+	var limit unix.Rlimit
+	require.NoError(t, unix.Prlimit(r.pid, unix.RLIMIT_NOFILE, nil, &limit))
+	require.Equal(t, uint64(32), limit.Cur)
+	require.Equal(t, uint64(32), limit.Max)
+	require.NoError(t, unix.Prlimit(r.pid, unix.RLIMIT_CPU, nil, &limit))
+	require.Equal(t, uint64(30), limit.Cur)
+
+	require.NoError(t, stdinWriter.Close())
+	_, _ = io.Copy(io.Discard, r.Stdout())
+	require.NoError(t, r.Wait(context.Background()))
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"errors"
+)
+
+const resourceLimitsSupported = false
+
+func applyResourceLimits(_ int, _ ResourceLimits) error {
+	return errors.New("resource limits are only supported on Linux")
+}
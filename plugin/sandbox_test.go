@@ -0,0 +1,107 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+// sandboxTestManifest returns the manifest of a shell script plugin.
+func sandboxTestManifest(t *testing.T, script string, args ...string) Manifest {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sandboxed")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0700)) // #nosec G306
+	checksum, err := Checksum(path)
+	require.NoError(t, err)
+	return Manifest{
+		Metadata:       Metadata{ID: "sandboxed", Types: []string{PVPPluginName}},
+		ExecutablePath: path,
+		Checksum:       checksum,
+		Args:           args,
+	}
+}
+
+func startSandboxed(t *testing.T, sandbox *Sandbox, manifest Manifest, stdin io.Reader) *sandboxRunner {
+	t.Helper()
+	checksum, err := hex.DecodeString(manifest.Checksum)
+	require.NoError(t, err)
+	spec := exec.Command("")
+	spec.Env = []string{"PLUGIN_PROTOCOL_VERSIONS=1"}
+	spec.Stdin = stdin
+	r, err := sandbox.runnerFunc(manifest, checksum)(hclog.NewNullLogger(), spec, "")
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background()))
+	return r.(*sandboxRunner)
+}
+
+func TestResourceLimits_Merge(t *testing.T) {
+	host := ResourceLimits{CPUSeconds: 60, MemoryBytes: 1 << 30}
+	requested := ResourceLimits{CPUSeconds: 120, MemoryBytes: 1 << 20, OpenFiles: 64}
+	require.Equal(t, ResourceLimits{CPUSeconds: 60, MemoryBytes: 1 << 20, OpenFiles: 64}, host.Merge(requested))
+	require.Equal(t, host, host.Merge(ResourceLimits{}))
+	require.True(t, ResourceLimits{}.IsZero())
+}
+
+func TestSandbox_Validate(t *testing.T) {
+	require.NoError(t, (&Sandbox{Environment: []string{"HOME"}}).Validate())
+	require.EqualError(t, (&Sandbox{Environment: []string{"A=B"}}).Validate(), "invalid sandbox environment variable name \"A=B\"")
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	require.EqualError(t, (&Sandbox{WorkDir: file}).Validate(), "sandbox working directory "+file+" is not a directory")
+}
+
+func TestSandbox_Launch(t *testing.T) {
+	t.Setenv("C2P_TEST_SECRET", "secret")
+	t.Setenv("C2P_TEST_HOST", "host")
+	t.Setenv("C2P_TEST_PLUGIN", "plugin")
+
+	outDir := t.TempDir()
+	manifest := sandboxTestManifest(t, `env > "$1/env"; pwd > "$1/pwd"; touch created`, outDir)
+	manifest.Environment = []string{"C2P_TEST_PLUGIN", "C2P_TEST_SECRET"}
+	sandbox := &Sandbox{Environment: []string{"C2P_TEST_HOST", "C2P_TEST_PLUGIN"}, WorkDir: t.TempDir()}
+
+	r := startSandboxed(t, sandbox, manifest, nil)
+	workDir := r.workDir
+	require.Equal(t, sandbox.WorkDir, filepath.Dir(workDir))
+	require.True(t, strings.HasPrefix(filepath.Base(workDir), "c2p-sandboxed-"))
+	_, _ = io.Copy(io.Discard, r.Stdout())
+	require.NoError(t, r.Wait(context.Background()))
+
+	env, err := os.ReadFile(filepath.Join(outDir, "env"))
+	require.NoError(t, err)
+	// only the variables of the manifest that the host allows are passed
+	require.NotContains(t, string(env), "C2P_TEST_HOST")
+	require.Contains(t, string(env), "C2P_TEST_PLUGIN=plugin\n")
+	require.Contains(t, string(env), "PLUGIN_PROTOCOL_VERSIONS=1\n")
+	require.Contains(t, string(env), "TMPDIR="+workDir+"\n")
+	require.Contains(t, string(env), "PATH=")
+	require.NotContains(t, string(env), "C2P_TEST_SECRET")
+
+	pwd, err := os.ReadFile(filepath.Join(outDir, "pwd"))
+	require.NoError(t, err)
+	require.Equal(t, workDir, strings.TrimSpace(string(pwd)))
+	// the working directory is removed when the plugin exits
+	require.NoDirExists(t, workDir)
+}
+
+func TestSandbox_Checksum(t *testing.T) {
+	manifest := sandboxTestManifest(t, "exit 0")
+	sum := sha256.Sum256([]byte("other"))
+	_, err := (&Sandbox{}).runnerFunc(manifest, sum[:])(hclog.NewNullLogger(), exec.Command(""), "")
+	require.EqualError(t, err, "checksums did not match")
+}
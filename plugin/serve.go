@@ -0,0 +1,184 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ServeCommand is the plugin subcommand that serves the
+// plugins without being launched by a host.
+const ServeCommand = "serve"
+
+// Run serves the plugins in the config. This function should be called last during
+// plugin initialization in the main function instead of Register.
+//
+// When the plugin binary is launched by a host, the plugins are served like with Register.
+// When the binary is run with the `serve` subcommand, the plugins are served as a remote plugin:
+//
+//	c2p-plugin serve --listen 0.0.0.0:9443 --tls-cert server.crt --tls-key server.key --client-ca ca.crt
+//	c2p-plugin serve --reattach
+//
+// With `--listen`, the gRPC services are served over TCP with mutual TLS. With `--reattach`, the
+// plugins are served like a launched plugin and the reattach configuration for the host is printed
+// to stdout. The plugin is served until it receives SIGINT or SIGTERM.
+func Run(config ServeConfig) {
+	if len(os.Args) < 2 || os.Args[1] != ServeCommand {
+		Register(config)
+		return
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := Serve(ctx, config, os.Args[2:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+// Serve serves the plugins in the config as a remote plugin with the given `serve` subcommand
+// arguments until the context is cancelled. In reattach mode, the reattach configuration is
+// written to out.
+func Serve(ctx context.Context, config ServeConfig, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(ServeCommand, flag.ContinueOnError)
+	listen := flags.String("listen", "", "TCP address to serve the plugin gRPC services on with mutual TLS")
+	certFile := flags.String("tls-cert", "", "PEM encoded server certificate")
+	keyFile := flags.String("tls-key", "", "PEM encoded server private key")
+	clientCA := flags.String("client-ca", "", "PEM encoded CA certificate the client certificates are verified against")
+	reattach := flags.Bool("reattach", false, "serve in reattach mode and print the reattach configuration")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	switch {
+	case *reattach && *listen != "":
+		return errors.New("--listen and --reattach cannot be used together")
+	case *reattach:
+		return serveReattach(ctx, config, out)
+	case *listen == "":
+		return errors.New("one of --listen or --reattach is required")
+	}
+
+	tlsConfig, err := serverTLSConfig(*certFile, *keyFile, *clientCA)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return err
+	}
+	logger.Info("serving plugin", "address", listener.Addr().String())
+	return serveListener(ctx, config, listener, tlsConfig)
+}
+
+// serverTLSConfig returns the mutual TLS configuration
+// that requires verified client certificates.
+func serverTLSConfig(certFile, keyFile, clientCA string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" || clientCA == "" {
+		return nil, errors.New("--tls-cert, --tls-key and --client-ca are required with --listen")
+	}
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	pool, err := loadCertPool(clientCA)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// serveListener serves the gRPC services of the plugins on the listener
+// until the context is cancelled.
+func serveListener(ctx context.Context, config ServeConfig, listener net.Listener, tlsConfig *tls.Config) error {
+	server := grpcServer([]grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))})
+	for name, p := range config.PluginSet {
+		grpcPlugin, ok := p.(plugin.GRPCPlugin)
+		if !ok {
+			return fmt.Errorf("plugin %s does not support gRPC", name)
+		}
+		// The broker is only available to plugins launched by the host
+		if err := grpcPlugin.GRPCServer(nil, server); err != nil {
+			return fmt.Errorf("failed to register plugin %s: %w", name, err)
+		}
+	}
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(plugin.GRPCServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+	select {
+	case <-ctx.Done():
+		server.GracefulStop()
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// serveReattach serves the plugins like a launched plugin without the handshake with
+// the host and writes the reattach configuration to out.
+func serveReattach(ctx context.Context, config ServeConfig, out io.Writer) error {
+	reattachCh := make(chan *plugin.ReattachConfig, 1)
+	closeCh := make(chan struct{})
+	go plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         config.PluginSet,
+		Logger:          config.Logger,
+		GRPCServer:      grpcServer,
+		Test: &plugin.ServeTestConfig{
+			Context:          ctx,
+			ReattachConfigCh: reattachCh,
+			CloseCh:          closeCh,
+		},
+	})
+
+	select {
+	case reattachConfig := <-reattachCh:
+		reattach := Reattach{
+			Network: reattachConfig.Addr.Network(),
+			Address: reattachConfig.Addr.String(),
+			Pid:     reattachConfig.Pid,
+		}
+		if err := json.NewEncoder(out).Encode(reattach); err != nil {
+			return err
+		}
+	case <-closeCh:
+		return errors.New("plugin server exited before serving")
+	}
+	<-closeCh
+	return nil
+}
@@ -0,0 +1,247 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+const signatureSuffix = ".sig"
+
+// SignaturePolicy defines how plugin manifest signatures are enforced.
+type SignaturePolicy string
+
+const (
+	// SignaturePolicyOff does not verify manifest signatures.
+	SignaturePolicyOff SignaturePolicy = "off"
+	// SignaturePolicyWarn verifies manifest signatures and logs a warning
+	// for plugins that are not signed by a trusted key.
+	SignaturePolicyWarn SignaturePolicy = "warn"
+	// SignaturePolicyRequire refuses plugins that are not signed by a trusted key.
+	SignaturePolicyRequire SignaturePolicy = "require-signed"
+)
+
+// Validate returns an error if the SignaturePolicy is unknown. An empty
+// policy is the same as SignaturePolicyOff.
+func (p SignaturePolicy) Validate() error {
+	switch p {
+	case "", SignaturePolicyOff, SignaturePolicyWarn, SignaturePolicyRequire:
+		return nil
+	default:
+		return fmt.Errorf("invalid signature policy %q: must be one of %s, %s, %s",
+			p, SignaturePolicyOff, SignaturePolicyWarn, SignaturePolicyRequire)
+	}
+}
+
+// Signature identifies the trusted key that signed a plugin manifest.
+type Signature struct {
+	// Signer is the name of the trusted key.
+	Signer string
+	// KeyID is the SHA256 fingerprint of the public key.
+	KeyID string
+}
+
+// TrustedKey is a public key plugin manifest signatures are verified against.
+type TrustedKey struct {
+	// Name identifies the signer in logs and assessment results.
+	Name      string
+	PublicKey ed25519.PublicKey
+}
+
+// ID returns the SHA256 fingerprint of the public key.
+func (k TrustedKey) ID() string {
+	sum := sha256.Sum256(k.PublicKey)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ParsePublicKey parses a PEM encoded ed25519 public key.
+func ParsePublicKey(name string, data []byte) (TrustedKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return TrustedKey{}, fmt.Errorf("trusted key %s: no PEM encoded public key found", name)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return TrustedKey{}, fmt.Errorf("trusted key %s: %w", name, err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return TrustedKey{}, fmt.Errorf("trusted key %s: not an ed25519 public key", name)
+	}
+	return TrustedKey{Name: name, PublicKey: publicKey}, nil
+}
+
+// LoadTrustedKeys reads PEM encoded ed25519 public keys from files. Each key
+// is named after its file name without extension.
+func LoadTrustedKeys(paths ...string) ([]TrustedKey, error) {
+	var keys []TrustedKey
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		key, err := ParsePublicKey(name, data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS #8 ed25519 private key, as generated
+// with `openssl genpkey -algorithm ed25519`.
+func ParsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an ed25519 private key")
+	}
+	return privateKey, nil
+}
+
+// SignatureFileName returns the name of the detached signature file of a plugin
+// manifest in the plugin directory.
+func SignatureFileName(pluginID string) string {
+	return ManifestFileName(pluginID) + signatureSuffix
+}
+
+// SignManifest signs the manifest file of a plugin and writes the base64 encoded
+// signature next to it.
+func SignManifest(pluginDir, pluginID string, key ed25519.PrivateKey) error {
+	matchingPlugins, err := findAllPluginMatches(pluginDir)
+	if err != nil {
+		return err
+	}
+	matches, ok := matchingPlugins[pluginID]
+	if !ok {
+		return &NotFoundError{PluginID: pluginID}
+	}
+	data, err := os.ReadFile(matches[0].path())
+	if err != nil {
+		return err
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))
+	return os.WriteFile(matches[0].path()+signatureSuffix, []byte(signature+"\n"), 0600)
+}
+
+// Verifier verifies plugin manifest signatures against trusted keys
+// and enforces a SignaturePolicy.
+type Verifier struct {
+	policy SignaturePolicy
+	keys   []TrustedKey
+	log    hclog.Logger
+}
+
+// NewVerifier returns a Verifier for the policy. Failed verifications are logged
+// with the given logger under SignaturePolicyWarn.
+func NewVerifier(policy SignaturePolicy, keys []TrustedKey, logger hclog.Logger) (*Verifier, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if policy == "" {
+		policy = SignaturePolicyOff
+	}
+	if policy == SignaturePolicyRequire && len(keys) == 0 {
+		return nil, fmt.Errorf("signature policy %s requires at least one trusted key", policy)
+	}
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	return &Verifier{policy: policy, keys: keys, log: logger}, nil
+}
+
+// Policy returns the enforced SignaturePolicy.
+func (v *Verifier) Policy() SignaturePolicy {
+	return v.policy
+}
+
+// Verify checks the detached signature of the manifest content read from manifestPath and
+// returns the trusted key that signed it.
+func (v *Verifier) Verify(pluginID, manifestPath string, manifestData []byte) (*Signature, error) {
+	signaturePath := manifestPath + signatureSuffix
+	encoded, err := os.ReadFile(signaturePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, &SignatureError{PluginID: pluginID, Reason: "manifest is not signed"}
+		}
+		return nil, &SignatureError{PluginID: pluginID, Reason: err.Error()}
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return nil, &SignatureError{PluginID: pluginID, Reason: fmt.Sprintf("invalid signature file %s: %v", filepath.Base(signaturePath), err)}
+	}
+	for _, key := range v.keys {
+		if ed25519.Verify(key.PublicKey, manifestData, signature) {
+			return &Signature{Signer: key.Name, KeyID: key.ID()}, nil
+		}
+	}
+	return nil, &SignatureError{PluginID: pluginID, Reason: "manifest is not signed by a trusted key"}
+}
+
+// check verifies the manifest signature and applies the policy. A verified signature
+// is recorded in the manifest.
+func (v *Verifier) check(manifest *Manifest, manifestPath string, manifestData []byte) error {
+	if v == nil || v.policy == SignaturePolicyOff {
+		return nil
+	}
+	signature, err := v.Verify(manifest.ID, manifestPath, manifestData)
+	if err != nil {
+		if v.policy == SignaturePolicyRequire {
+			return err
+		}
+		v.log.Warn(err.Error())
+		return nil
+	}
+	manifest.Signature = signature
+	v.log.Debug(fmt.Sprintf("manifest of plugin %s is signed by %s (%s)", manifest.ID, signature.Signer, signature.KeyID))
+	return nil
+}
+
+// recheck verifies the manifest of a plugin again before launch, so a manifest replaced
+// after discovery is not trusted. The manifest on disk must declare the same checksum.
+func (v *Verifier) recheck(manifest Manifest) error {
+	if v == nil || v.policy == SignaturePolicyOff {
+		return nil
+	}
+	if manifest.manifestPath == "" {
+		err := &SignatureError{PluginID: manifest.ID, Reason: "manifest was not loaded from the plugin directory"}
+		if v.policy == SignaturePolicyRequire {
+			return err
+		}
+		v.log.Warn(err.Error())
+		return nil
+	}
+	onDisk, data, err := readManifestData(manifest.ID, manifest.manifestPath)
+	if err != nil {
+		return err
+	}
+	if onDisk.Checksum != manifest.Checksum {
+		return &SignatureError{PluginID: manifest.ID, Reason: "manifest changed since the plugin was discovered"}
+	}
+	return v.check(&onDisk, manifest.manifestPath, data)
+}
@@ -0,0 +1,174 @@
+/*
+ Copyright 2025 The OSCAL Compass Authors
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugin
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestKey(t *testing.T, name string) (TrustedKey, ed25519.PrivateKey) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return TrustedKey{Name: name, PublicKey: publicKey}, privateKey
+}
+
+func installTestPlugin(t *testing.T, pluginDir, id string) Manifest {
+	t.Helper()
+	binaryPath := filepath.Join(t.TempDir(), id+"-plugin")
+	require.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\n"), 0600))
+	manifest, err := Install(pluginDir, binaryPath, Manifest{Metadata: Metadata{ID: id, Types: []string{PVPPluginName}}})
+	require.NoError(t, err)
+	return manifest
+}
+
+func TestLoadTrustedKeys(t *testing.T) {
+	key, privateKey := generateTestKey(t, "")
+	dir := t.TempDir()
+
+	publicDER, err := x509.MarshalPKIXPublicKey(key.PublicKey)
+	require.NoError(t, err)
+	publicPath := filepath.Join(dir, "release.pub")
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0600))
+
+	keys, err := LoadTrustedKeys(publicPath)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, "release", keys[0].Name)
+	require.Equal(t, key.PublicKey, keys[0].PublicKey)
+	require.Regexp(t, "^sha256:[0-9a-f]{64}$", keys[0].ID())
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+	parsed, err := ParsePrivateKey(privatePEM)
+	require.NoError(t, err)
+	require.Equal(t, privateKey, parsed)
+
+	_, err = ParsePublicKey("private", privatePEM)
+	require.EqualError(t, err, "trusted key private: no PEM encoded public key found")
+	_, err = ParsePrivateKey([]byte("not a key"))
+	require.EqualError(t, err, "no PEM encoded private key found")
+}
+
+func TestNewVerifier(t *testing.T) {
+	_, err := NewVerifier("strict", nil, nil)
+	require.EqualError(t, err, "invalid signature policy \"strict\": must be one of off, warn, require-signed")
+	_, err = NewVerifier(SignaturePolicyRequire, nil, nil)
+	require.EqualError(t, err, "signature policy require-signed requires at least one trusted key")
+
+	verifier, err := NewVerifier("", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, SignaturePolicyOff, verifier.Policy())
+}
+
+func TestFindPluginsWithVerifier(t *testing.T) {
+	trusted, trustedPrivate := generateTestKey(t, "release")
+	_, untrustedPrivate := generateTestKey(t, "untrusted")
+
+	pluginDir := t.TempDir()
+	installTestPlugin(t, pluginDir, "signed")
+	installTestPlugin(t, pluginDir, "unsigned")
+	installTestPlugin(t, pluginDir, "untrusted")
+	require.NoError(t, SignManifest(pluginDir, "signed", trustedPrivate))
+	require.NoError(t, SignManifest(pluginDir, "untrusted", untrustedPrivate))
+	require.FileExists(t, filepath.Join(pluginDir, "c2p-signed-manifest.json.sig"))
+
+	var notFound *NotFoundError
+	require.True(t, errors.As(SignManifest(pluginDir, "missing", trustedPrivate), &notFound))
+
+	tests := []struct {
+		name       string
+		policy     SignaturePolicy
+		wantErrors []string
+		wantIDs    []string
+	}{
+		{
+			name:    "Valid/Off",
+			policy:  SignaturePolicyOff,
+			wantIDs: []string{"signed", "unsigned", "untrusted"},
+		},
+		{
+			name:    "Valid/Warn",
+			policy:  SignaturePolicyWarn,
+			wantIDs: []string{"signed", "unsigned", "untrusted"},
+		},
+		{
+			name:   "Invalid/RequireSigned",
+			policy: SignaturePolicyRequire,
+			wantErrors: []string{
+				"signature verification failed for plugin \"unsigned\": manifest is not signed",
+				"signature verification failed for plugin \"untrusted\": manifest is not signed by a trusted key",
+			},
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			verifier, err := NewVerifier(c.policy, []TrustedKey{trusted}, hclog.NewNullLogger())
+			require.NoError(t, err)
+			manifests, err := FindPlugins(pluginDir, WithVerifier(verifier))
+			if len(c.wantErrors) > 0 {
+				require.Error(t, err)
+				// the plugins are searched in no particular order
+				require.ElementsMatch(t, c.wantErrors, strings.Split(err.Error(), "\n"))
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, manifests, len(c.wantIDs))
+			for _, id := range c.wantIDs {
+				require.Contains(t, manifests, id)
+			}
+			if c.policy == SignaturePolicyOff {
+				require.Nil(t, manifests["signed"].Signature)
+				return
+			}
+			require.Equal(t, &Signature{Signer: "release", KeyID: trusted.ID()}, manifests["signed"].Signature)
+			require.Nil(t, manifests["unsigned"].Signature)
+			require.Nil(t, manifests["untrusted"].Signature)
+		})
+	}
+}
+
+func TestVerifierRecheck(t *testing.T) {
+	trusted, trustedPrivate := generateTestKey(t, "release")
+	pluginDir := t.TempDir()
+	installTestPlugin(t, pluginDir, "signed")
+	require.NoError(t, SignManifest(pluginDir, "signed", trustedPrivate))
+
+	verifier, err := NewVerifier(SignaturePolicyRequire, []TrustedKey{trusted}, hclog.NewNullLogger())
+	require.NoError(t, err)
+	manifests, err := FindPlugins(pluginDir, WithVerifier(verifier))
+	require.NoError(t, err)
+	manifest := manifests["signed"]
+	require.NoError(t, verifier.recheck(manifest))
+
+	// The manifest is replaced after discovery
+	manifestPath := filepath.Join(pluginDir, ManifestFileName("signed"))
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestPath, append(data, ' '), 0600))
+	require.EqualError(t, verifier.recheck(manifest), "signature verification failed for plugin \"signed\": manifest is not signed by a trusted key")
+
+	_, err = ClientFactory(hclog.NewNullLogger(), WithClientVerifier(verifier))(manifest)
+	var signatureErr *SignatureError
+	require.True(t, errors.As(err, &signatureErr))
+
+	// Manifests not found in a plugin directory cannot be verified
+	err = verifier.recheck(Manifest{Metadata: Metadata{ID: "signed"}})
+	require.EqualError(t, err, "signature verification failed for plugin \"signed\": manifest was not loaded from the plugin directory")
+}
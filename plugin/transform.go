@@ -23,6 +23,8 @@ func PolicyToProto(p policy.Policy) *proto.PolicyRequest {
 				Name:          rs.Rule.Parameter.ID,
 				Description:   rs.Rule.Parameter.Description,
 				SelectedValue: rs.Rule.Parameter.Value,
+				Values:        rs.ParameterDetails.Values,
+				Alternatives:  rs.ParameterDetails.Alternatives,
 			}
 		}
 
@@ -31,14 +33,29 @@ func PolicyToProto(p policy.Policy) *proto.PolicyRequest {
 			check := &proto.Check{
 				Name:        ch.ID,
 				Description: ch.Description,
+				Props:       propsToProto(rs.CheckProps[ch.ID]),
 			}
 			checks = append(checks, check)
 		}
+
+		var component *proto.Component
+		if rs.Component != nil {
+			component = &proto.Component{
+				Uuid:        rs.Component.UUID,
+				Title:       rs.Component.Title,
+				Type:        rs.Component.Type,
+				Description: rs.Component.Description,
+				Props:       propsToProto(rs.Component.Props),
+			}
+		}
 		ruleSet := &proto.Rule{
 			Name:        rs.Rule.ID,
 			Description: rs.Rule.Description,
 			Checks:      checks,
 			Parameter:   parameter,
+			Props:       propsToProto(rs.Props),
+			ControlIds:  rs.ControlIDs,
+			Component:   component,
 		}
 		policyRequest.Rule = append(policyRequest.Rule, ruleSet)
 	}
@@ -51,30 +68,58 @@ func NewPolicyFromProto(pb *proto.PolicyRequest) policy.Policy {
 
 	for _, r := range pb.Rule {
 		var parameter *extensions.Parameter
+		var parameterDetails policy.ParameterDetails
 		if r.Parameter != nil {
 			parameter = &extensions.Parameter{
 				ID:          r.Parameter.Name,
 				Description: r.Parameter.Description,
 				Value:       r.Parameter.SelectedValue,
 			}
+			parameterDetails.Values = r.Parameter.Values
+			parameterDetails.Alternatives = r.Parameter.Alternatives
 		}
 
 		var checks []extensions.Check
+		var checkProps map[string][]policy.Property
 		for _, ch := range r.Checks {
 			check := extensions.Check{
 				ID:          ch.Name,
 				Description: ch.Description,
 			}
 			checks = append(checks, check)
+			if len(ch.Props) > 0 {
+				if checkProps == nil {
+					checkProps = make(map[string][]policy.Property)
+				}
+				checkProps[ch.Name] = propsFromProto(ch.Props)
+			}
+		}
+
+		var component *policy.Component
+		if r.Component != nil {
+			component = &policy.Component{
+				UUID:        r.Component.Uuid,
+				Title:       r.Component.Title,
+				Type:        r.Component.Type,
+				Description: r.Component.Description,
+				Props:       propsFromProto(r.Component.Props),
+			}
 		}
 
-		rule := extensions.RuleSet{
-			Rule: extensions.Rule{
-				ID:          r.Name,
-				Description: r.Description,
-				Parameter:   parameter,
+		rule := policy.RuleSet{
+			RuleSet: extensions.RuleSet{
+				Rule: extensions.Rule{
+					ID:          r.Name,
+					Description: r.Description,
+					Parameter:   parameter,
+				},
+				Checks: checks,
 			},
-			Checks: checks,
+			Props:            propsFromProto(r.Props),
+			CheckProps:       checkProps,
+			ParameterDetails: parameterDetails,
+			ControlIDs:       r.ControlIds,
+			Component:        component,
 		}
 
 		p = append(p, rule)
@@ -88,6 +133,7 @@ var protoByResult = map[policy.Result]proto.Result{
 	policy.ResultError:   proto.Result_RESULT_ERROR,
 	policy.ResultWarning: proto.Result_RESULT_WARNING,
 	policy.ResultFail:    proto.Result_RESULT_FAILURE,
+	policy.ResultSkipped: proto.Result_RESULT_SKIPPED,
 }
 
 var resultByProto = map[proto.Result]policy.Result{
@@ -96,6 +142,7 @@ var resultByProto = map[proto.Result]policy.Result{
 	proto.Result_RESULT_WARNING:     policy.ResultWarning,
 	proto.Result_RESULT_PASS:        policy.ResultPass,
 	proto.Result_RESULT_FAILURE:     policy.ResultFail,
+	proto.Result_RESULT_SKIPPED:     policy.ResultSkipped,
 }
 
 func NewResultFromProto(pb *proto.PVPResult) policy.PVPResult {
@@ -109,12 +156,7 @@ func NewResultFromProto(pb *proto.PVPResult) policy.PVPResult {
 			Collected:   o.CollectedAt.AsTime(),
 			CheckID:     o.CheckId,
 		}
-		var links []policy.Link
-		for _, ref := range o.EvidenceRefs {
-			link := policy.Link{Description: ref.Description, Href: ref.Href}
-			links = append(links, link)
-		}
-		observation.RelevantEvidences = links
+		observation.RelevantEvidences = linksFromProto(o.EvidenceRefs)
 
 		var subjects []policy.Subject
 		for _, s := range o.Subjects {
@@ -145,9 +187,16 @@ func NewResultFromProto(pb *proto.PVPResult) policy.PVPResult {
 		result.ObservationsByCheck = append(result.ObservationsByCheck, observation)
 	}
 
-	for _, l := range pb.Links {
-		link := policy.Link{Description: l.Description, Href: l.Href}
-		result.Links = append(result.Links, link)
+	result.Links = linksFromProto(pb.Links)
+
+	for _, i := range pb.InventoryItems {
+		item := policy.InventoryItem{
+			ID:          i.Id,
+			Description: i.Description,
+			Props:       propsFromProto(i.Props),
+			Links:       linksFromProto(i.Links),
+		}
+		result.InventoryItems = append(result.InventoryItems, item)
 	}
 	return result
 }
@@ -181,11 +230,7 @@ func ResultsToProto(result policy.PVPResult) *proto.PVPResult {
 			subject.Props = subjectProps
 			subjects = append(subjects, subject)
 		}
-		var evidences []*proto.Link
-		for _, evidence := range o.RelevantEvidences {
-			link := &proto.Link{Description: evidence.Description, Href: evidence.Href}
-			evidences = append(evidences, link)
-		}
+		evidences := linksToProto(o.RelevantEvidences)
 		var props []*proto.Property
 		for _, p := range o.Props {
 			prop := &proto.Property{Name: p.Name, Value: p.Value}
@@ -197,13 +242,142 @@ func ResultsToProto(result policy.PVPResult) *proto.PVPResult {
 		pvpResult.Observations = append(pvpResult.Observations, observation)
 	}
 
-	for _, l := range result.Links {
-		link := &proto.Link{
-			Description: l.Description,
-			Href:        l.Href,
-		}
-		pvpResult.Links = append(pvpResult.Links, link)
+	pvpResult.Links = linksToProto(result.Links)
 
+	for _, i := range result.InventoryItems {
+		item := &proto.InventoryItem{
+			Id:          i.ID,
+			Description: i.Description,
+			Props:       propsToProto(i.Props),
+			Links:       linksToProto(i.Links),
+		}
+		pvpResult.InventoryItems = append(pvpResult.InventoryItems, item)
 	}
 	return pvpResult
 }
+
+func linksFromProto(pb []*proto.Link) []policy.Link {
+	var links []policy.Link
+	for _, l := range pb {
+		link := policy.Link{Description: l.Description, Href: l.Href, Props: propsFromProto(l.Props)}
+		links = append(links, link)
+	}
+	return links
+}
+
+func linksToProto(links []policy.Link) []*proto.Link {
+	var pb []*proto.Link
+	for _, l := range links {
+		link := &proto.Link{Description: l.Description, Href: l.Href, Props: propsToProto(l.Props)}
+		pb = append(pb, link)
+	}
+	return pb
+}
+
+func propsFromProto(pb []*proto.Property) []policy.Property {
+	var props []policy.Property
+	for _, p := range pb {
+		props = append(props, policy.Property{Name: p.Name, Value: p.Value})
+	}
+	return props
+}
+
+func propsToProto(props []policy.Property) []*proto.Property {
+	var pb []*proto.Property
+	for _, p := range props {
+		pb = append(pb, &proto.Property{Name: p.Name, Value: p.Value})
+	}
+	return pb
+}
+
+// RemediationsToProto transforms proposed remediations to protobuf Remediations.
+func RemediationsToProto(remediations []policy.Remediation) []*proto.Remediation {
+	var pb []*proto.Remediation
+	for _, r := range remediations {
+		remediation := &proto.Remediation{
+			Title:       r.Title,
+			Description: r.Description,
+			CheckId:     r.CheckID,
+			ResourceId:  r.ResourceID,
+			Props:       propsToProto(r.Props),
+		}
+		for _, a := range r.Artifacts {
+			artifact := &proto.Artifact{Name: a.Name, MediaType: a.MediaType, Content: a.Content}
+			remediation.Artifacts = append(remediation.Artifacts, artifact)
+		}
+		pb = append(pb, remediation)
+	}
+	return pb
+}
+
+// NewRemediationsFromProto transforms protobuf Remediations into proposed remediations.
+func NewRemediationsFromProto(pb []*proto.Remediation) []policy.Remediation {
+	var remediations []policy.Remediation
+	for _, r := range pb {
+		remediation := policy.Remediation{
+			Title:       r.Title,
+			Description: r.Description,
+			CheckID:     r.CheckId,
+			ResourceID:  r.ResourceId,
+			Props:       propsFromProto(r.Props),
+		}
+		for _, a := range r.Artifacts {
+			artifact := policy.Artifact{Name: a.Name, MediaType: a.MediaType, Content: a.Content}
+			remediation.Artifacts = append(remediation.Artifacts, artifact)
+		}
+		remediations = append(remediations, remediation)
+	}
+	return remediations
+}
+
+// EvidenceToProto transforms collected evidence to protobuf Evidence.
+func EvidenceToProto(evidence []policy.Evidence) []*proto.Evidence {
+	var pb []*proto.Evidence
+	for _, e := range evidence {
+		pb = append(pb, &proto.Evidence{
+			Name:      e.Name,
+			MediaType: e.MediaType,
+			Content:   e.Content,
+			Digest:    e.Digest,
+			Href:      e.Href,
+			Props:     propsToProto(e.Props),
+			Collector: e.Collector,
+		})
+	}
+	return pb
+}
+
+// NewEvidenceFromProto transforms protobuf Evidence into collected evidence.
+func NewEvidenceFromProto(pb []*proto.Evidence) []policy.Evidence {
+	var evidence []policy.Evidence
+	for _, e := range pb {
+		evidence = append(evidence, policy.Evidence{
+			Name:      e.Name,
+			MediaType: e.MediaType,
+			Content:   e.Content,
+			Digest:    e.Digest,
+			Href:      e.Href,
+			Props:     propsFromProto(e.Props),
+			Collector: e.Collector,
+		})
+	}
+	return evidence
+}
+
+// ResultListToProto transforms a list of PVPResults to protobuf PVPResults.
+func ResultListToProto(results []policy.PVPResult) []*proto.PVPResult {
+	var pb []*proto.PVPResult
+	for _, result := range results {
+		pb = append(pb, ResultsToProto(result))
+	}
+	return pb
+}
+
+// NewResultListFromProto transforms protobuf PVPResults into a list of PVPResults.
+func NewResultListFromProto(pb []*proto.PVPResult) []policy.PVPResult {
+	var results []policy.PVPResult
+	for _, result := range pb {
+		results = append(results, NewResultFromProto(result))
+	}
+	return results
+}
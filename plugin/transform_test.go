@@ -11,6 +11,7 @@ import (
 
 	"github.com/oscal-compass/oscal-sdk-go/extensions"
 	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/oscal-compass/compliance-to-policy-go/v2/api/proto"
@@ -20,20 +21,65 @@ import (
 var testTimeString, _ = time.Parse("00:00:00", "12:00:00")
 
 var testPolicy = policy.Policy{
-	extensions.RuleSet{
-		Rule: extensions.Rule{
-			ID:          "test-rule-1",
-			Description: "test rule 1",
-			Parameter: &extensions.Parameter{
-				ID:          "test-param-1",
-				Description: "test param 1",
-				Value:       "test param value",
+	{
+		RuleSet: extensions.RuleSet{
+			Rule: extensions.Rule{
+				ID:          "test-rule-1",
+				Description: "test rule 1",
+				Parameter: &extensions.Parameter{
+					ID:          "test-param-1",
+					Description: "test param 1",
+					Value:       "test param value",
+				},
+			},
+			Checks: []extensions.Check{
+				{
+					ID:          "test-check-1",
+					Description: "test check 1",
+				},
+				{
+					ID:          "test-check-2",
+					Description: "test check 2",
+				},
 			},
 		},
-		Checks: []extensions.Check{
+		Props: []policy.Property{
 			{
-				ID:          "test-check-1",
-				Description: "test check 1",
+				Name:  "severity",
+				Value: "high",
+			},
+		},
+		CheckProps: map[string][]policy.Property{
+			"test-check-1": {
+				{
+					Name:  "test-check-prop-1",
+					Value: "test-check-value-1",
+				},
+			},
+		},
+		ParameterDetails: policy.ParameterDetails{
+			Values:       []string{"test param value", "other value"},
+			Alternatives: []string{"test param value", "other value", "third value"},
+		},
+		ControlIDs: []string{"ac-1", "ac-2"},
+		Component: &policy.Component{
+			UUID:        "c8106bc8-5174-4e86-91a4-52f2fe0ed027",
+			Title:       "test component",
+			Type:        "service",
+			Description: "test component description",
+			Props: []policy.Property{
+				{
+					Name:  "test-component-prop-1",
+					Value: "test-component-value-1",
+				},
+			},
+		},
+	},
+	{
+		RuleSet: extensions.RuleSet{
+			Rule: extensions.Rule{
+				ID:          "test-rule-2",
+				Description: "test rule 2",
 			},
 		},
 	},
@@ -48,14 +94,49 @@ var testPolicyRequest = &proto.PolicyRequest{
 				{
 					Name:        "test-check-1",
 					Description: "test check 1",
+					Props: []*proto.Property{
+						{
+							Name:  "test-check-prop-1",
+							Value: "test-check-value-1",
+						},
+					},
+				},
+				{
+					Name:        "test-check-2",
+					Description: "test check 2",
 				},
 			},
 			Parameter: &proto.Parameter{
 				Name:          "test-param-1",
 				Description:   "test param 1",
 				SelectedValue: "test param value",
+				Values:        []string{"test param value", "other value"},
+				Alternatives:  []string{"test param value", "other value", "third value"},
+			},
+			Props: []*proto.Property{
+				{
+					Name:  "severity",
+					Value: "high",
+				},
+			},
+			ControlIds: []string{"ac-1", "ac-2"},
+			Component: &proto.Component{
+				Uuid:        "c8106bc8-5174-4e86-91a4-52f2fe0ed027",
+				Title:       "test component",
+				Type:        "service",
+				Description: "test component description",
+				Props: []*proto.Property{
+					{
+						Name:  "test-component-prop-1",
+						Value: "test-component-value-1",
+					},
+				},
 			},
 		},
+		{
+			Name:        "test-rule-2",
+			Description: "test rule 2",
+		},
 	},
 }
 
@@ -87,6 +168,12 @@ var testProtoPvpResult = &proto.PVPResult{
 				{
 					Description: "test-evidence-ref-1",
 					Href:        "https://test-evidence-ref-1",
+					Props: []*proto.Property{
+						{
+							Name:  "test-evidence-prop-1",
+							Value: "test-evidence-value-1",
+						},
+					},
 				},
 			},
 			Props: []*proto.Property{
@@ -103,6 +190,24 @@ var testProtoPvpResult = &proto.PVPResult{
 			Href:        "https://test-link-1",
 		},
 	},
+	InventoryItems: []*proto.InventoryItem{
+		{
+			Id:          "test-resource-1",
+			Description: "test inventory item 1",
+			Props: []*proto.Property{
+				{
+					Name:  "test-item-prop-1",
+					Value: "test-item-value-1",
+				},
+			},
+			Links: []*proto.Link{
+				{
+					Description: "test-item-link-1",
+					Href:        "https://test-item-link-1",
+				},
+			},
+		},
+	},
 }
 
 var testPolicyPvpResult = policy.PVPResult{
@@ -133,6 +238,12 @@ var testPolicyPvpResult = policy.PVPResult{
 				{
 					Description: "test-evidence-ref-1",
 					Href:        "https://test-evidence-ref-1",
+					Props: []policy.Property{
+						{
+							Name:  "test-evidence-prop-1",
+							Value: "test-evidence-value-1",
+						},
+					},
 				},
 			},
 			Props: []policy.Property{
@@ -149,6 +260,24 @@ var testPolicyPvpResult = policy.PVPResult{
 			Href:        "https://test-link-1",
 		},
 	},
+	InventoryItems: []policy.InventoryItem{
+		{
+			ID:          "test-resource-1",
+			Description: "test inventory item 1",
+			Props: []policy.Property{
+				{
+					Name:  "test-item-prop-1",
+					Value: "test-item-value-1",
+				},
+			},
+			Links: []policy.Link{
+				{
+					Description: "test-item-link-1",
+					Href:        "https://test-item-link-1",
+				},
+			},
+		},
+	},
 }
 
 func TestPolicyToProto(t *testing.T) {
@@ -161,6 +290,15 @@ func TestNewPolicyFromProto(t *testing.T) {
 	require.Equal(t, testPolicy, output)
 }
 
+func TestPolicyRoundTrip(t *testing.T) {
+	// The policy is unchanged after being sent over the wire
+	data, err := protobuf.Marshal(PolicyToProto(testPolicy))
+	require.NoError(t, err)
+	var request proto.PolicyRequest
+	require.NoError(t, protobuf.Unmarshal(data, &request))
+	require.Equal(t, testPolicy, NewPolicyFromProto(&request))
+}
+
 func TestResultToProto(t *testing.T) {
 	output := ResultsToProto(testPolicyPvpResult)
 	require.Equal(t, testProtoPvpResult, output)
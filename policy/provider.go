@@ -16,3 +16,40 @@ type Provider interface {
 	// PVPResults.
 	GetResults(Policy) (PVPResult, error)
 }
+
+// Evaluator is implemented by a Provider that can evaluate evidence
+// collected by collector plugins instead of collecting it in GetResults.
+type Evaluator interface {
+	// Evaluate the collected Evidence against the Policy and
+	// transform the outcome into PVPResults.
+	Evaluate(Policy, []Evidence) (PVPResult, error)
+}
+
+// Collector defines methods for an evidence collector C2P plugin.
+type Collector interface {
+	// Configure send configuration options and selected values to the
+	// plugin.
+	Configure(map[string]string) error
+	// Collect raw evidence for the Policy without evaluating it.
+	Collect(Policy) ([]Evidence, error)
+}
+
+// Remediator defines methods for a remediation C2P plugin.
+type Remediator interface {
+	// Configure send configuration options and selected values to the
+	// plugin.
+	Configure(map[string]string) error
+	// Remediate proposes fixes for the failed subjects in the PVPResult
+	// of the Policy. The fixes are not applied.
+	Remediate(Policy, PVPResult) ([]Remediation, error)
+}
+
+// Processor defines methods for a result processor C2P plugin.
+type Processor interface {
+	// Configure send configuration options and selected values to the
+	// plugin.
+	Configure(map[string]string) error
+	// Process transforms the PVPResults before they are reported, for
+	// example to enrich, filter, deduplicate or re-map them.
+	Process([]PVPResult) ([]PVPResult, error)
+}
@@ -6,6 +6,9 @@
 package policy
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/oscal-compass/oscal-sdk-go/extensions"
@@ -20,6 +23,9 @@ const (
 	ResultError
 	ResultPass
 	ResultWarning
+	// ResultSkipped indicates the check was not evaluated for the subject
+	// because it was not selected or is not applicable.
+	ResultSkipped
 )
 
 // String prints a string representation of the Result.
@@ -35,6 +41,8 @@ func (r Result) String() string {
 		return "pass"
 	case ResultWarning:
 		return "warning"
+	case ResultSkipped:
+		return "skipped"
 	default:
 		panic("invalid result")
 	}
@@ -52,6 +60,8 @@ type Property struct {
 type Link struct {
 	Description string
 	Href        string
+	// Props carry structured data about the linked artifact.
+	Props []Property
 }
 
 // Subject represents a specific resource that is evaluated
@@ -80,11 +90,168 @@ type ObservationByCheck struct {
 	Props             []Property
 }
 
+// InventoryItem represents an assessed item, such as a cluster or a host,
+// that can be converted to an OSCAL InventoryItem type. Subjects reference
+// an InventoryItem by setting their ResourceID to the InventoryItem ID.
+type InventoryItem struct {
+	ID          string
+	Description string
+	Props       []Property
+	Links       []Link
+}
+
 // PVPResult represent a set of policy results generated by a PVP.
 type PVPResult struct {
 	ObservationsByCheck []ObservationByCheck
 	Links               []Link
+	InventoryItems      []InventoryItem
+}
+
+// Failed returns the PVPResult with only the failed subjects and
+// the observations that have failed subjects.
+func (r PVPResult) Failed() PVPResult {
+	failed := PVPResult{Links: r.Links, InventoryItems: r.InventoryItems}
+	for _, observation := range r.ObservationsByCheck {
+		var subjects []Subject
+		for _, subject := range observation.Subjects {
+			if subject.Result == ResultFail {
+				subjects = append(subjects, subject)
+			}
+		}
+		if len(subjects) == 0 {
+			continue
+		}
+		observation.Subjects = subjects
+		failed.ObservationsByCheck = append(failed.ObservationsByCheck, observation)
+	}
+	return failed
+}
+
+// Remediation is a fix proposed by a remediation plugin for
+// a failed subject of a check.
+type Remediation struct {
+	Title       string
+	Description string
+	CheckID     string
+	// ResourceID is the ResourceID of the failed Subject.
+	ResourceID string
+	// Artifacts are the files of the fix, such as patched
+	// manifests or policies.
+	Artifacts []Artifact
+	Props     []Property
+}
+
+// Artifact is a file of a proposed Remediation.
+type Artifact struct {
+	// Name is the file name of the artifact.
+	Name      string
+	MediaType string
+	Content   []byte
+}
+
+// Evidence is a raw evidence blob returned by a collector plugin, such
+// as an exported PolicyReport or a dump of cluster objects.
+type Evidence struct {
+	// Name is the file name of the evidence.
+	Name      string
+	MediaType string
+	Content   []byte
+	// Digest is the digest of the Content in the algorithm:hex form.
+	Digest string
+	// Href references the stored evidence. It is set by the host
+	// when the evidence is stored and can be used as the Href of
+	// the RelevantEvidences of the observations.
+	Href  string
+	Props []Property
+	// Collector is the ID of the collector plugin that collected
+	// the evidence. It is set by the host when the evidence is stored.
+	Collector string
+}
+
+// NewEvidence returns the Evidence for the content with its digest.
+func NewEvidence(name, mediaType string, content []byte) Evidence {
+	return Evidence{
+		Name:      name,
+		MediaType: mediaType,
+		Content:   content,
+		Digest:    Digest(content),
+	}
+}
+
+// Digest returns the sha256 digest of the content in the algorithm:hex form.
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Verify returns an error if the Digest of the Evidence does not
+// match its Content.
+func (e Evidence) Verify() error {
+	if digest := Digest(e.Content); e.Digest != digest {
+		return fmt.Errorf("evidence %s: digest %q does not match content digest %q", e.Name, e.Digest, digest)
+	}
+	return nil
 }
 
 // Policy represents a list of RuleSets.
-type Policy []extensions.RuleSet
+type Policy []RuleSet
+
+// FromRuleSets returns a Policy for the given rule sets
+// without additional context.
+func FromRuleSets(ruleSets []extensions.RuleSet) Policy {
+	var p Policy
+	for _, ruleSet := range ruleSets {
+		p = append(p, RuleSet{RuleSet: ruleSet})
+	}
+	return p
+}
+
+// RuleSets returns the rule sets of the Policy without the additional context.
+func (p Policy) RuleSets() []extensions.RuleSet {
+	var ruleSets []extensions.RuleSet
+	for _, ruleSet := range p {
+		ruleSets = append(ruleSets, ruleSet.RuleSet)
+	}
+	return ruleSets
+}
+
+// RuleSet is a rule with its checks and the compliance
+// context the rule is evaluated in.
+type RuleSet struct {
+	extensions.RuleSet
+	// Props are the additional properties of the rule,
+	// such as the severity or remarks.
+	Props []Property
+	// CheckProps are the additional properties of the checks,
+	// by the ID of a check in Checks.
+	CheckProps map[string][]Property
+	// ParameterDetails has the values of the Rule.Parameter.
+	// It is only set when the rule has a parameter.
+	ParameterDetails ParameterDetails
+	// ControlIDs are the IDs of the controls the rule satisfies.
+	ControlIDs []string
+	// Component is the component the rule applies to.
+	Component *Component
+}
+
+// ParameterDetails has the values of a rule parameter
+// beyond its single selected value.
+type ParameterDetails struct {
+	// Values are all the selected values of a multi-valued
+	// parameter. The first value is the Rule.Parameter.Value.
+	Values []string
+	// Alternatives are the values the parameter can be set to.
+	// Any value is allowed when empty.
+	Alternatives []string
+}
+
+// Component is the component a rule applies to, such as
+// the software or service targeted by the policy.
+type Component struct {
+	UUID        string
+	Title       string
+	Type        string
+	Description string
+	// Props carry the inventory context of the component.
+	Props []Property
+}